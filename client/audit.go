@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"kasir-api/models"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// AuditLogOptions filters the audit log, empty fields match everything.
+// BeforeID pages back from the last entry of the previous page.
+type AuditLogOptions struct {
+	ClaimedActor string
+	Action       string
+	EntityType   string
+	EntityID     string
+	RequestID    string
+	Dates        Dates
+	BeforeID     int64
+	Limit        int
+}
+
+func (c *Client) ListAuditLog(ctx context.Context, options AuditLogOptions) ([]models.AuditEntry, error) {
+	query := url.Values{}
+	setString(query, "claimed_actor", options.ClaimedActor)
+	setString(query, "action", options.Action)
+	setString(query, "entity_type", options.EntityType)
+	setString(query, "entity_id", options.EntityID)
+	setString(query, "request_id", options.RequestID)
+	options.Dates.addTo(query)
+	if options.BeforeID != 0 {
+		query.Set("before_id", strconv.FormatInt(options.BeforeID, 10))
+	}
+	setInt(query, "limit", options.Limit)
+	var entries []models.AuditEntry
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/audit", Query: query}, &entries)
+	return entries, err
+}
+
+func (c *Client) VerifyAuditLog(ctx context.Context) (models.AuditVerification, error) {
+	var verification models.AuditVerification
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/audit/verify"}, &verification)
+	return verification, err
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"kasir-api/models"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) ListCategories(ctx context.Context, includeArchived bool) ([]models.Category, error) {
+	query := url.Values{}
+	setBool(query, "include_archived", includeArchived)
+	var categories []models.Category
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/categories", Query: query}, &categories)
+	return categories, err
+}
+
+func (c *Client) CreateCategory(ctx context.Context, category models.Category) (models.Category, error) {
+	var created models.Category
+	err := c.do(ctx, request{Method: http.MethodPost, Path: "/api/categories", Body: category}, &created)
+	return created, err
+}
+
+func (c *Client) GetCategory(ctx context.Context, id string) (models.Category, error) {
+	var category models.Category
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/categories/" + escape(id)}, &category)
+	return category, err
+}
+
+// UpdateCategory replaces the category, conditionally on a non-zero
+// category.Version like UpdateProduct.
+func (c *Client) UpdateCategory(ctx context.Context, id string, category models.Category) (models.Category, error) {
+	var updated models.Category
+	err := c.do(ctx, request{Method: http.MethodPut, Path: "/api/categories/" + escape(id), Body: category, IfMatch: category.Version}, &updated)
+	return updated, err
+}
+
+func (c *Client) PatchCategory(ctx context.Context, id string, patch any, version int64) (models.Category, error) {
+	var updated models.Category
+	err := c.do(ctx, request{
+		Method: http.MethodPatch, Path: "/api/categories/" + escape(id), Body: patch,
+		ContentType: "application/merge-patch+json", IfMatch: version,
+	}, &updated)
+	return updated, err
+}
+
+// DeleteCategory archives the category. A non-zero version makes it conditional.
+func (c *Client) DeleteCategory(ctx context.Context, id string, version int64) (models.Category, error) {
+	var deleted models.Category
+	err := c.do(ctx, request{Method: http.MethodDelete, Path: "/api/categories/" + escape(id), IfMatch: version}, &deleted)
+	return deleted, err
+}
+
+func (c *Client) RestoreCategory(ctx context.Context, id string) (models.Category, error) {
+	var restored models.Category
+	err := c.do(ctx, request{Method: http.MethodPost, Path: "/api/categories/" + escape(id) + "/restore"}, &restored)
+	return restored, err
+}
+
+func (c *Client) ListCategoryProducts(ctx context.Context, id string) ([]models.CategoryWithProducts, error) {
+	var categories []models.CategoryWithProducts
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/categories/" + escape(id) + "/products"}, &categories)
+	return categories, err
+}
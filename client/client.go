@@ -0,0 +1,266 @@
+// Package client is a typed Go client for the Kasir API. It sends and returns
+// the models structs, turns problem+json responses into *Error and retries
+// requests that failed on the way, sending POST and PATCH with an
+// Idempotency-Key so a retry never applies a change twice.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 2
+	defaultBackoff    = 200 * time.Millisecond
+	maxBackoff        = 5 * time.Second
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	actor      string
+	maxRetries int
+	backoff    time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces the default client, which times out after 30
+// seconds. StreamEvents ignores the timeout, use its context to stop it.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithActor names the caller in the audit log through the X-Actor header. The
+// API records it as a claim, it does not authenticate the name.
+func WithActor(name string) Option {
+	return func(c *Client) {
+		c.actor = name
+	}
+}
+
+// WithRetries sets how many times a failed request is tried again and the
+// delay before the first retry, which doubles on every further one. Zero
+// retries disables retrying.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// New returns a client for the API at baseURL, such as http://localhost:8080.
+func New(baseURL string, options ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// request is one API call. Body is sent as JSON unless RawBody is set, and
+// IfMatch, when not zero, makes the change conditional on that version.
+type request struct {
+	Method      string
+	Path        string
+	Query       url.Values
+	Body        any
+	RawBody     []byte
+	ContentType string
+	IfMatch     int64
+	// Accept lists error statuses whose body is the expected result.
+	Accept []int
+}
+
+// do sends req and decodes the JSON response into out when it is not nil.
+func (c *Client) do(ctx context.Context, req request, out any) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	err = json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", req.Method, req.Path, err)
+	}
+	return nil
+}
+
+// send sends req, retrying connection failures and the statuses of isRetryableStatus,
+// and returns the successful response with its body unread. Every attempt
+// reuses the same Idempotency-Key.
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	body := req.RawBody
+	contentType := req.ContentType
+	if body == nil && req.Body != nil {
+		var err error
+		body, err = json.Marshal(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s %s request: %w", req.Method, req.Path, err)
+		}
+		if contentType == "" {
+			contentType = "application/json"
+		}
+	}
+
+	target := c.baseURL + req.Path
+	if len(req.Query) > 0 {
+		target += "?" + req.Query.Encode()
+	}
+	var idempotencyKey string
+	if req.Method == http.MethodPost || req.Method == http.MethodPatch {
+		idempotencyKey = uuid.NewString()
+	}
+
+	for attempt := 0; ; attempt++ {
+		httpReq, err := http.NewRequestWithContext(ctx, req.Method, target, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Accept", "application/json")
+		if contentType != "" {
+			httpReq.Header.Set("Content-Type", contentType)
+		}
+		if idempotencyKey != "" {
+			httpReq.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+		if req.IfMatch != 0 {
+			httpReq.Header.Set("If-Match", `"`+strconv.FormatInt(req.IfMatch, 10)+`"`)
+		}
+		if c.actor != "" {
+			httpReq.Header.Set("X-Actor", c.actor)
+		}
+
+		resp, err := c.httpClient.Do(httpReq)
+		if err != nil {
+			if ctx.Err() != nil || !isRetryableError(err) || attempt >= c.maxRetries {
+				return nil, err
+			}
+		} else {
+			if resp.StatusCode < http.StatusBadRequest || containsStatus(req.Accept, resp.StatusCode) {
+				return resp, nil
+			}
+			apiErr := readError(resp)
+			if !isRetryableStatus(apiErr) || attempt >= c.maxRetries {
+				return nil, apiErr
+			}
+		}
+
+		err = c.wait(ctx, attempt)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) wait(ctx context.Context, attempt int) error {
+	delay := c.backoff << attempt
+	if delay <= 0 || delay > maxBackoff {
+		delay = maxBackoff
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isRetryableError tells apart failures of the connection, which may not have
+// reached the server, from mistakes such as an invalid URL.
+func isRetryableError(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isRetryableStatus reports the responses a later attempt may turn into a
+// success: overload, gateway failures and an earlier attempt of the same
+// idempotent request that is still running.
+func isRetryableStatus(err *Error) bool {
+	switch err.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusConflict:
+		return err.Problem.Code == "idempotency_key_in_progress"
+	}
+	return false
+}
+
+func containsStatus(statuses []int, status int) bool {
+	for _, candidate := range statuses {
+		if candidate == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Dates limits a listing or report to the store days From to To, both
+// inclusive and written as 2006-01-02. Empty bounds are open.
+type Dates struct {
+	From string
+	To   string
+}
+
+func (d Dates) addTo(query url.Values) {
+	if d.From != "" {
+		query.Set("from", d.From)
+	}
+	if d.To != "" {
+		query.Set("to", d.To)
+	}
+}
+
+func setInt(query url.Values, name string, value int) {
+	if value != 0 {
+		query.Set(name, strconv.Itoa(value))
+	}
+}
+
+func setBool(query url.Values, name string, value bool) {
+	if value {
+		query.Set(name, "true")
+	}
+}
+
+func setString(query url.Values, name string, value string) {
+	if value != "" {
+		query.Set(name, value)
+	}
+}
+
+// escape quotes an id or serial number for use as a path segment.
+func escape(segment string) string {
+	return url.PathEscape(segment)
+}
@@ -0,0 +1,377 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"kasir-api/models"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// attempt is one request as the test server saw it.
+type attempt struct {
+	method         string
+	idempotencyKey string
+	actor          string
+	body           string
+	at             time.Time
+}
+
+// recorder answers each request with the next of its responses, repeating
+// the last one, and records the requests.
+type recorder struct {
+	mu        sync.Mutex
+	responses []func(w http.ResponseWriter)
+	attempts  []attempt
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rec.mu.Lock()
+	rec.attempts = append(rec.attempts, attempt{
+		method:         r.Method,
+		idempotencyKey: r.Header.Get("Idempotency-Key"),
+		actor:          r.Header.Get("X-Actor"),
+		body:           string(body),
+		at:             time.Now(),
+	})
+	respond := rec.responses[min(len(rec.attempts), len(rec.responses))-1]
+	rec.mu.Unlock()
+	respond(w)
+}
+
+func (rec *recorder) recorded() []attempt {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return append([]attempt(nil), rec.attempts...)
+}
+
+func newTestClient(t *testing.T, rec *recorder, options ...Option) *Client {
+	t.Helper()
+	server := httptest.NewServer(rec)
+	t.Cleanup(server.Close)
+	return New(server.URL, append([]Option{WithRetries(2, 10*time.Millisecond)}, options...)...)
+}
+
+func problem(status int, code, detail string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.Header().Set("X-Request-ID", "req-1")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(models.Problem{
+			Type: "about:blank", Title: http.StatusText(status), Status: status, Code: code, Detail: detail, RequestID: "req-1",
+		})
+	}
+}
+
+func jsonBody(status int, body any) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+func TestRetryReusesIdempotencyKey(t *testing.T) {
+	rec := &recorder{responses: []func(w http.ResponseWriter){
+		problem(http.StatusServiceUnavailable, "service_unavailable", ""),
+		problem(http.StatusBadGateway, "bad_gateway", ""),
+		jsonBody(http.StatusCreated, models.Product{ID: "p1", Name: "Tea"}),
+	}}
+	c := newTestClient(t, rec, WithActor("till-1"))
+
+	product, err := c.CreateProduct(context.Background(), models.Product{Name: "Tea"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if product.ID != "p1" {
+		t.Errorf("ID = %q, want p1", product.ID)
+	}
+
+	attempts := rec.recorded()
+	if len(attempts) != 3 {
+		t.Fatalf("%d attempts, want 3", len(attempts))
+	}
+	key := attempts[0].idempotencyKey
+	if key == "" {
+		t.Fatal("POST was sent without an Idempotency-Key")
+	}
+	for i, a := range attempts {
+		if a.idempotencyKey != key {
+			t.Errorf("attempt %d sent key %q, want %q", i, a.idempotencyKey, key)
+		}
+		if a.body != attempts[0].body {
+			t.Errorf("attempt %d sent body %q, want %q", i, a.body, attempts[0].body)
+		}
+		if a.actor != "till-1" {
+			t.Errorf("attempt %d sent X-Actor %q, want till-1", i, a.actor)
+		}
+	}
+
+	// The backoff starts at 10ms and doubles.
+	if gap := attempts[1].at.Sub(attempts[0].at); gap < 10*time.Millisecond {
+		t.Errorf("first retry after %v, want at least 10ms", gap)
+	}
+	if gap := attempts[2].at.Sub(attempts[1].at); gap < 20*time.Millisecond {
+		t.Errorf("second retry after %v, want at least 20ms", gap)
+	}
+}
+
+func TestEveryCallGetsItsOwnIdempotencyKey(t *testing.T) {
+	rec := &recorder{responses: []func(w http.ResponseWriter){jsonBody(http.StatusCreated, models.Product{ID: "p1"})}}
+	c := newTestClient(t, rec)
+
+	for range 2 {
+		if _, err := c.CreateProduct(context.Background(), models.Product{Name: "Tea"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	attempts := rec.recorded()
+	if attempts[0].idempotencyKey == attempts[1].idempotencyKey {
+		t.Errorf("two calls shared the key %q", attempts[0].idempotencyKey)
+	}
+}
+
+func TestReadsAreSentWithoutIdempotencyKey(t *testing.T) {
+	rec := &recorder{responses: []func(w http.ResponseWriter){
+		problem(http.StatusServiceUnavailable, "service_unavailable", ""),
+		jsonBody(http.StatusOK, models.Product{ID: "p1"}),
+	}}
+	c := newTestClient(t, rec)
+
+	if _, err := c.GetProduct(context.Background(), "p1"); err != nil {
+		t.Fatal(err)
+	}
+	attempts := rec.recorded()
+	if len(attempts) != 2 {
+		t.Fatalf("%d attempts, want 2", len(attempts))
+	}
+	for i, a := range attempts {
+		if a.idempotencyKey != "" {
+			t.Errorf("GET attempt %d sent Idempotency-Key %q", i, a.idempotencyKey)
+		}
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		response     func(w http.ResponseWriter)
+		maxRetries   int
+		wantAttempts int
+	}{
+		{"overloaded", problem(http.StatusTooManyRequests, "too_many_requests", ""), 2, 3},
+		{"gateway timeout", problem(http.StatusGatewayTimeout, "gateway_timeout", ""), 2, 3},
+		{"earlier attempt still running", problem(http.StatusConflict, "idempotency_key_in_progress", ""), 2, 3},
+		{"retries disabled", problem(http.StatusServiceUnavailable, "service_unavailable", ""), 0, 1},
+		{"not found", problem(http.StatusNotFound, "not_found", "Product not found"), 2, 1},
+		{"duplicate", problem(http.StatusConflict, "duplicate", "SKU already exists"), 2, 1},
+		{"validation", problem(http.StatusUnprocessableEntity, "validation_failed", ""), 2, 1},
+		{"server error", problem(http.StatusInternalServerError, "internal_server_error", ""), 2, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := &recorder{responses: []func(w http.ResponseWriter){test.response}}
+			c := newTestClient(t, rec, WithRetries(test.maxRetries, time.Millisecond))
+
+			_, err := c.CreateProduct(context.Background(), models.Product{Name: "Tea"})
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want an *Error", err)
+			}
+			if got := len(rec.recorded()); got != test.wantAttempts {
+				t.Errorf("%d attempts, want %d", got, test.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetriesDroppedConnections(t *testing.T) {
+	rec := &recorder{responses: []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+		},
+		jsonBody(http.StatusOK, models.Transaction{ID: "t1"}),
+	}}
+	c := newTestClient(t, rec)
+
+	transaction, err := c.Checkout(context.Background(), models.CheckoutRequest{
+		Items: []models.CheckoutItem{{ProductID: "p1", Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transaction.ID != "t1" {
+		t.Errorf("ID = %q, want t1", transaction.ID)
+	}
+	attempts := rec.recorded()
+	if len(attempts) != 2 || attempts[0].idempotencyKey != attempts[1].idempotencyKey {
+		t.Errorf("attempts = %+v, want two with the same key", attempts)
+	}
+}
+
+func TestErrorsDecodeProblems(t *testing.T) {
+	tests := []struct {
+		name     string
+		response func(w http.ResponseWriter)
+		want     []error
+		notWant  []error
+		wantCode string
+	}{
+		{
+			name:     "not found",
+			response: problem(http.StatusNotFound, "not_found", "Product not found"),
+			want:     []error{ErrNotFound},
+			notWant:  []error{ErrConflict, ErrValidation},
+			wantCode: "not_found",
+		},
+		{
+			name:     "duplicate",
+			response: problem(http.StatusConflict, "duplicate", "SKU already exists"),
+			want:     []error{ErrConflict, ErrDuplicate},
+			notWant:  []error{ErrInsufficientStock},
+			wantCode: "duplicate",
+		},
+		{
+			name:     "insufficient stock",
+			response: problem(http.StatusConflict, "insufficient_stock", "Only 2 left"),
+			want:     []error{ErrConflict, ErrInsufficientStock},
+			notWant:  []error{ErrDuplicate},
+			wantCode: "insufficient_stock",
+		},
+		{
+			name:     "version mismatch",
+			response: problem(http.StatusPreconditionFailed, "version_mismatch", ""),
+			want:     []error{ErrVersionMismatch},
+			notWant:  []error{ErrConflict},
+			wantCode: "version_mismatch",
+		},
+		{
+			name:     "validation",
+			response: problem(http.StatusUnprocessableEntity, "validation_failed", "Invalid product"),
+			want:     []error{ErrValidation},
+			notWant:  []error{ErrBadRequest},
+			wantCode: "validation_failed",
+		},
+		{
+			name:     "bad request",
+			response: problem(http.StatusBadRequest, "bad_request", "Invalid request body"),
+			want:     []error{ErrBadRequest},
+			notWant:  []error{ErrValidation},
+			wantCode: "bad_request",
+		},
+		{
+			name: "not problem details",
+			response: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", "text/html")
+				w.Header().Set("X-Request-ID", "req-1")
+				w.WriteHeader(http.StatusNotFound)
+				io.WriteString(w, "<h1>Not Found</h1>\n")
+			},
+			want:    []error{ErrNotFound},
+			notWant: []error{ErrValidation},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := &recorder{responses: []func(w http.ResponseWriter){test.response}}
+			c := newTestClient(t, rec)
+
+			_, err := c.GetProduct(context.Background(), "p1")
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want an *Error", err)
+			}
+			for _, kind := range test.want {
+				if !errors.Is(err, kind) {
+					t.Errorf("errors.Is(%v, %v) = false", err, kind)
+				}
+			}
+			for _, kind := range test.notWant {
+				if errors.Is(err, kind) {
+					t.Errorf("errors.Is(%v, %v) = true", err, kind)
+				}
+			}
+			if apiErr.Problem.Code != test.wantCode {
+				t.Errorf("Code = %q, want %q", apiErr.Problem.Code, test.wantCode)
+			}
+			if apiErr.Problem.Status != apiErr.StatusCode {
+				t.Errorf("Problem.Status = %d, StatusCode = %d", apiErr.Problem.Status, apiErr.StatusCode)
+			}
+			if apiErr.Problem.RequestID != "req-1" {
+				t.Errorf("RequestID = %q, want req-1", apiErr.Problem.RequestID)
+			}
+		})
+	}
+}
+
+func TestErrorKeepsFieldErrors(t *testing.T) {
+	rec := &recorder{responses: []func(w http.ResponseWriter){func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		io.WriteString(w, `{"type":"about:blank","title":"Unprocessable Entity","status":422,"code":"validation_failed",`+
+			`"detail":"Invalid checkout","errors":[{"field":"items[0].quantity","message":"must be at least 1"}]}`)
+	}}}
+	c := newTestClient(t, rec)
+
+	_, err := c.Checkout(context.Background(), models.CheckoutRequest{Items: []models.CheckoutItem{{ProductID: "p1"}}})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, want an *Error", err)
+	}
+	if len(apiErr.Problem.Errors) != 1 || apiErr.Problem.Errors[0].Field != "items[0].quantity" {
+		t.Errorf("Errors = %+v, want the quantity of the first item", apiErr.Problem.Errors)
+	}
+	if want := "kasir api: unprocessable entity: Invalid checkout"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestContextTimeout(t *testing.T) {
+	t.Run("slow response", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		rec := &recorder{responses: []func(w http.ResponseWriter){func(w http.ResponseWriter) {
+			<-release
+		}}}
+		c := newTestClient(t, rec)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := c.CreateProduct(ctx, models.Product{Name: "Tea"})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("returned after %v", elapsed)
+		}
+		if got := len(rec.recorded()); got != 1 {
+			t.Errorf("%d attempts, want 1: a cancelled request is not retried", got)
+		}
+	})
+
+	t.Run("during backoff", func(t *testing.T) {
+		rec := &recorder{responses: []func(w http.ResponseWriter){problem(http.StatusServiceUnavailable, "service_unavailable", "")}}
+		c := newTestClient(t, rec, WithRetries(5, time.Second))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := c.GetProduct(ctx, "p1")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("returned after %v, the backoff did not stop at the deadline", elapsed)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"kasir-api/models"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) ListCustomerGroups(ctx context.Context) ([]models.CustomerGroup, error) {
+	var groups []models.CustomerGroup
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/customer-groups"}, &groups)
+	return groups, err
+}
+
+func (c *Client) CreateCustomerGroup(ctx context.Context, group models.CustomerGroup) (models.CustomerGroup, error) {
+	var created models.CustomerGroup
+	err := c.do(ctx, request{Method: http.MethodPost, Path: "/api/customer-groups", Body: group}, &created)
+	return created, err
+}
+
+func (c *Client) GetCustomerGroup(ctx context.Context, id string) (models.CustomerGroup, error) {
+	var group models.CustomerGroup
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/customer-groups/" + escape(id)}, &group)
+	return group, err
+}
+
+func (c *Client) UpdateCustomerGroup(ctx context.Context, id string, group models.CustomerGroup) (models.CustomerGroup, error) {
+	var updated models.CustomerGroup
+	err := c.do(ctx, request{Method: http.MethodPut, Path: "/api/customer-groups/" + escape(id), Body: group}, &updated)
+	return updated, err
+}
+
+func (c *Client) DeleteCustomerGroup(ctx context.Context, id string) (models.CustomerGroup, error) {
+	var deleted models.CustomerGroup
+	err := c.do(ctx, request{Method: http.MethodDelete, Path: "/api/customer-groups/" + escape(id)}, &deleted)
+	return deleted, err
+}
+
+// ListCustomers lists the customers whose name contains name, all of them
+// when it is empty.
+func (c *Client) ListCustomers(ctx context.Context, name string) ([]models.Customer, error) {
+	query := url.Values{}
+	setString(query, "name", name)
+	var customers []models.Customer
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/customers", Query: query}, &customers)
+	return customers, err
+}
+
+func (c *Client) CreateCustomer(ctx context.Context, customer models.Customer) (models.Customer, error) {
+	var created models.Customer
+	err := c.do(ctx, request{Method: http.MethodPost, Path: "/api/customers", Body: customer}, &created)
+	return created, err
+}
+
+func (c *Client) GetCustomer(ctx context.Context, id string) (models.Customer, error) {
+	var customer models.Customer
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/customers/" + escape(id)}, &customer)
+	return customer, err
+}
+
+func (c *Client) UpdateCustomer(ctx context.Context, id string, customer models.Customer) (models.Customer, error) {
+	var updated models.Customer
+	err := c.do(ctx, request{Method: http.MethodPut, Path: "/api/customers/" + escape(id), Body: customer}, &updated)
+	return updated, err
+}
+
+func (c *Client) DeleteCustomer(ctx context.Context, id string) (models.Customer, error) {
+	var deleted models.Customer
+	err := c.do(ctx, request{Method: http.MethodDelete, Path: "/api/customers/" + escape(id)}, &deleted)
+	return deleted, err
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"kasir-api/models"
+	"net/http"
+	"strings"
+)
+
+// Kinds of API errors, to be matched with errors.Is against an *Error.
+var (
+	ErrBadRequest        = errors.New("bad request")
+	ErrNotFound          = errors.New("not found")
+	ErrConflict          = errors.New("conflict")
+	ErrDuplicate         = errors.New("duplicate")
+	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrVersionMismatch   = errors.New("version mismatch")
+	ErrValidation        = errors.New("validation failed")
+)
+
+// Error is an error response of the API. Problem holds its problem+json body,
+// including the invalid fields of a validation error and the request id to
+// quote when reporting it.
+type Error struct {
+	StatusCode int
+	Problem    models.Problem
+}
+
+func (e *Error) Error() string {
+	message := "kasir api: " + strings.ToLower(http.StatusText(e.StatusCode))
+	if e.Problem.Detail != "" {
+		message += ": " + e.Problem.Detail
+	}
+	return message
+}
+
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrDuplicate:
+		return e.Problem.Code == "duplicate"
+	case ErrInsufficientStock:
+		return e.Problem.Code == "insufficient_stock"
+	case ErrVersionMismatch:
+		return e.StatusCode == http.StatusPreconditionFailed
+	case ErrValidation:
+		return e.Problem.Code == "validation_failed"
+	}
+	return false
+}
+
+// readError reads and closes an error response. Bodies that are not problem
+// details, such as those of a proxy, become the detail.
+func readError(resp *http.Response) *Error {
+	defer resp.Body.Close()
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(data, &apiErr.Problem) != nil || apiErr.Problem.Status == 0 {
+		apiErr.Problem = models.Problem{
+			Type:   "about:blank",
+			Title:  http.StatusText(resp.StatusCode),
+			Status: resp.StatusCode,
+			Detail: strings.TrimSpace(string(data)),
+		}
+	}
+	if apiErr.Problem.RequestID == "" {
+		apiErr.Problem.RequestID = resp.Header.Get("X-Request-ID")
+	}
+	return apiErr
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"kasir-api/models"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StreamEvents follows /api/events and calls handle with every event, its Data
+// left as json.RawMessage to decode by Type into models.CheckoutEvent,
+// models.LowStockEvent or models.DayTotals. Only the types listed are sent,
+// all of them when types is empty, and a non-zero lastEventID resumes after
+// that event. It returns the error of handle or of the connection, the
+// context error once ctx is done, and nil when the server ends the stream;
+// reconnect then with the last event id seen.
+func (c *Client) StreamEvents(ctx context.Context, types []string, lastEventID int64, handle func(models.Event) error) error {
+	query := url.Values{}
+	setString(query, "types", strings.Join(types, ","))
+	if lastEventID != 0 {
+		query.Set("last_event_id", strconv.FormatInt(lastEventID, 10))
+	}
+
+	// The stream stays open, so the client timeout must not apply to it.
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+	stream := *c
+	stream.httpClient = &httpClient
+	resp, err := stream.send(ctx, request{Method: http.MethodGet, Path: "/api/events", Query: query})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var data strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+			event, err := decodeEvent(data.String())
+			data.Reset()
+			if err != nil {
+				return err
+			}
+			err = handle(event)
+			if err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return scanner.Err()
+}
+
+func decodeEvent(data string) (models.Event, error) {
+	var event struct {
+		ID        int64           `json:"id"`
+		Type      string          `json:"type"`
+		Data      json.RawMessage `json:"data"`
+		CreatedAt time.Time       `json:"created_at"`
+	}
+	err := json.Unmarshal([]byte(data), &event)
+	if err != nil {
+		return models.Event{}, fmt.Errorf("failed to decode event: %w", err)
+	}
+	return models.Event{ID: event.ID, Type: event.Type, Data: event.Data, CreatedAt: event.CreatedAt}, nil
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ExportProducts streams the products as csv, xlsx or jsonl, csv when format
+// is empty. The caller closes the returned reader.
+func (c *Client) ExportProducts(ctx context.Context, format string, dates Dates) (io.ReadCloser, error) {
+	return c.export(ctx, "/api/export/products", format, dates)
+}
+
+func (c *Client) ExportTransactions(ctx context.Context, format string, dates Dates) (io.ReadCloser, error) {
+	return c.export(ctx, "/api/export/transactions", format, dates)
+}
+
+func (c *Client) ExportTransactionItems(ctx context.Context, format string, dates Dates) (io.ReadCloser, error) {
+	return c.export(ctx, "/api/export/transaction-items", format, dates)
+}
+
+func (c *Client) export(ctx context.Context, path string, format string, dates Dates) (io.ReadCloser, error) {
+	query := url.Values{}
+	setString(query, "format", format)
+	dates.addTo(query)
+	resp, err := c.send(ctx, request{Method: http.MethodGet, Path: path, Query: query})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"kasir-api/models"
+	"net/http"
+	"net/url"
+)
+
+type ListForecastsOptions struct {
+	// CoverDays is the stock to cover, the server default when zero.
+	CoverDays   int
+	ReorderOnly bool
+}
+
+func (o ListForecastsOptions) query() url.Values {
+	query := url.Values{}
+	setInt(query, "cover_days", o.CoverDays)
+	setBool(query, "reorder_only", o.ReorderOnly)
+	return query
+}
+
+func (c *Client) ListForecasts(ctx context.Context, options ListForecastsOptions) ([]models.DemandForecast, error) {
+	var forecasts []models.DemandForecast
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/forecasts", Query: options.query()}, &forecasts)
+	return forecasts, err
+}
+
+// GenerateForecasts recomputes the forecasts now and lists them.
+func (c *Client) GenerateForecasts(ctx context.Context, options ListForecastsOptions) ([]models.DemandForecast, error) {
+	var forecasts []models.DemandForecast
+	err := c.do(ctx, request{Method: http.MethodPost, Path: "/api/forecasts", Query: options.query()}, &forecasts)
+	return forecasts, err
+}
+
+// GetProductForecast forecasts days ahead covering coverDays of stock, the
+// server defaults when zero.
+func (c *Client) GetProductForecast(ctx context.Context, productID string, coverDays, days int) (models.DemandForecast, error) {
+	query := url.Values{}
+	setInt(query, "cover_days", coverDays)
+	setInt(query, "days", days)
+	var forecast models.DemandForecast
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/products/" + escape(productID) + "/forecast", Query: query}, &forecast)
+	return forecast, err
+}
+
+func (c *Client) SetLeadTime(ctx context.Context, productID string, days int) error {
+	return c.do(ctx, request{
+		Method: http.MethodPut, Path: "/api/products/" + escape(productID) + "/lead-time",
+		Body: models.LeadTimeRequest{LeadTimeDays: days},
+	}, nil)
+}
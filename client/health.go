@@ -0,0 +1,19 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Health returns nil when the API and its database are up.
+func (c *Client) Health(ctx context.Context) error {
+	return c.do(ctx, request{Method: http.MethodGet, Path: "/healthz"}, nil)
+}
+
+// OpenAPI returns the OpenAPI 3 document describing the API.
+func (c *Client) OpenAPI(ctx context.Context) (json.RawMessage, error) {
+	var document json.RawMessage
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/openapi.json"}, &document)
+	return document, err
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"kasir-api/models"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) ListProductLots(ctx context.Context, productID string) ([]models.ProductLot, error) {
+	var lots []models.ProductLot
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/products/" + escape(productID) + "/lots"}, &lots)
+	return lots, err
+}
+
+func (c *Client) ReceiveLot(ctx context.Context, productID string, lot models.ProductLot) (models.ProductLot, error) {
+	var received models.ProductLot
+	err := c.do(ctx, request{Method: http.MethodPost, Path: "/api/products/" + escape(productID) + "/lots", Body: lot}, &received)
+	return received, err
+}
+
+// ListExpiringLots lists the lots expiring within days, the server default
+// when days is zero.
+func (c *Client) ListExpiringLots(ctx context.Context, days int) ([]models.ExpiringLot, error) {
+	query := url.Values{}
+	setInt(query, "days", days)
+	var lots []models.ExpiringLot
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/reports/expiring", Query: query}, &lots)
+	return lots, err
+}
+
+// ListProductSerials lists the serials of a product, only those in status
+// unless it is empty.
+func (c *Client) ListProductSerials(ctx context.Context, productID string, status string) ([]models.ProductSerial, error) {
+	query := url.Values{}
+	setString(query, "status", status)
+	var serials []models.ProductSerial
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/products/" + escape(productID) + "/serials", Query: query}, &serials)
+	return serials, err
+}
+
+func (c *Client) ReceiveSerials(ctx context.Context, productID string, serialNumbers []string) ([]models.ProductSerial, error) {
+	var serials []models.ProductSerial
+	err := c.do(ctx, request{
+		Method: http.MethodPost, Path: "/api/products/" + escape(productID) + "/serials",
+		Body: models.ReceiveSerialsRequest{SerialNumbers: serialNumbers},
+	}, &serials)
+	return serials, err
+}
+
+func (c *Client) GetSerialHistory(ctx context.Context, serialNumber string) (models.SerialHistory, error) {
+	var history models.SerialHistory
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/serials/" + escape(serialNumber)}, &history)
+	return history, err
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"kasir-api/models"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// GetPriceHistory lists the applied and scheduled prices of a product.
+// GET /api/products/{id}/prices returns the same list.
+func (c *Client) GetPriceHistory(ctx context.Context, productID string) ([]models.ProductPrice, error) {
+	var prices []models.ProductPrice
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/products/" + escape(productID) + "/price-history"}, &prices)
+	return prices, err
+}
+
+func (c *Client) SchedulePriceChange(ctx context.Context, productID string, price int64, effectiveAt time.Time) (models.ProductPrice, error) {
+	var scheduled models.ProductPrice
+	err := c.do(ctx, request{
+		Method: http.MethodPost, Path: "/api/products/" + escape(productID) + "/prices",
+		Body: models.SchedulePriceRequest{Price: price, EffectiveAt: effectiveAt},
+	}, &scheduled)
+	return scheduled, err
+}
+
+func (c *Client) CancelScheduledPrice(ctx context.Context, productID, priceID string) (models.ProductPrice, error) {
+	var cancelled models.ProductPrice
+	err := c.do(ctx, request{Method: http.MethodDelete, Path: "/api/products/" + escape(productID) + "/prices/" + escape(priceID)}, &cancelled)
+	return cancelled, err
+}
+
+// QuotePrice prices quantity units of a product for a customer. An empty
+// customerID quotes the default price list, a zero quantity one unit.
+func (c *Client) QuotePrice(ctx context.Context, productID, customerID string, quantity int) (models.PriceQuote, error) {
+	query := url.Values{}
+	setString(query, "customer_id", customerID)
+	setInt(query, "quantity", quantity)
+	var quote models.PriceQuote
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/products/" + escape(productID) + "/quote", Query: query}, &quote)
+	return quote, err
+}
+
+func (c *Client) ListPriceLists(ctx context.Context) ([]models.PriceList, error) {
+	var priceLists []models.PriceList
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/price-lists"}, &priceLists)
+	return priceLists, err
+}
+
+func (c *Client) CreatePriceList(ctx context.Context, priceList models.PriceList) (models.PriceList, error) {
+	var created models.PriceList
+	err := c.do(ctx, request{Method: http.MethodPost, Path: "/api/price-lists", Body: priceList}, &created)
+	return created, err
+}
+
+func (c *Client) GetPriceList(ctx context.Context, id string) (models.PriceList, error) {
+	var priceList models.PriceList
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/price-lists/" + escape(id)}, &priceList)
+	return priceList, err
+}
+
+func (c *Client) UpdatePriceList(ctx context.Context, id string, priceList models.PriceList) (models.PriceList, error) {
+	var updated models.PriceList
+	err := c.do(ctx, request{Method: http.MethodPut, Path: "/api/price-lists/" + escape(id), Body: priceList}, &updated)
+	return updated, err
+}
+
+func (c *Client) DeletePriceList(ctx context.Context, id string) (models.PriceList, error) {
+	var deleted models.PriceList
+	err := c.do(ctx, request{Method: http.MethodDelete, Path: "/api/price-lists/" + escape(id)}, &deleted)
+	return deleted, err
+}
+
+func (c *Client) ListPriceListItems(ctx context.Context, priceListID string) ([]models.PriceListItem, error) {
+	var items []models.PriceListItem
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/price-lists/" + escape(priceListID) + "/items"}, &items)
+	return items, err
+}
+
+func (c *Client) SetPriceListItem(ctx context.Context, priceListID string, item models.PriceListItem) (models.PriceListItem, error) {
+	var saved models.PriceListItem
+	err := c.do(ctx, request{Method: http.MethodPut, Path: "/api/price-lists/" + escape(priceListID) + "/items", Body: item}, &saved)
+	return saved, err
+}
+
+func (c *Client) DeletePriceListItem(ctx context.Context, priceListID, productID string, minQuantity int) (models.PriceListItem, error) {
+	var deleted models.PriceListItem
+	err := c.do(ctx, request{
+		Method: http.MethodDelete, Path: "/api/price-lists/" + escape(priceListID) + "/items",
+		Body: models.PriceListItemKey{ProductID: productID, MinQuantity: minQuantity},
+	}, &deleted)
+	return deleted, err
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"kasir-api/models"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+type ListProductsOptions struct {
+	// Name keeps the products whose name contains it.
+	Name            string
+	IncludeArchived bool
+}
+
+func (c *Client) ListProducts(ctx context.Context, options ListProductsOptions) ([]models.Product, error) {
+	query := url.Values{}
+	setString(query, "name", options.Name)
+	setBool(query, "include_archived", options.IncludeArchived)
+	var products []models.Product
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/products", Query: query}, &products)
+	return products, err
+}
+
+func (c *Client) CreateProduct(ctx context.Context, product models.Product) (models.Product, error) {
+	var created models.Product
+	err := c.do(ctx, request{Method: http.MethodPost, Path: "/api/products", Body: product}, &created)
+	return created, err
+}
+
+func (c *Client) GetProduct(ctx context.Context, id string) (models.Product, error) {
+	var product models.Product
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/products/" + escape(id)}, &product)
+	return product, err
+}
+
+// UpdateProduct replaces the product. A non-zero product.Version is sent as
+// If-Match, so the update fails with ErrVersionMismatch when the product has
+// changed since it was read.
+func (c *Client) UpdateProduct(ctx context.Context, id string, product models.Product) (models.Product, error) {
+	var updated models.Product
+	err := c.do(ctx, request{Method: http.MethodPut, Path: "/api/products/" + escape(id), Body: product, IfMatch: product.Version}, &updated)
+	return updated, err
+}
+
+// PatchProduct applies a JSON merge patch, such as map[string]any{"price": 5000}.
+// A non-zero version makes it conditional like UpdateProduct.
+func (c *Client) PatchProduct(ctx context.Context, id string, patch any, version int64) (models.Product, error) {
+	var updated models.Product
+	err := c.do(ctx, request{
+		Method: http.MethodPatch, Path: "/api/products/" + escape(id), Body: patch,
+		ContentType: "application/merge-patch+json", IfMatch: version,
+	}, &updated)
+	return updated, err
+}
+
+// DeleteProduct archives the product. A non-zero version makes it conditional.
+func (c *Client) DeleteProduct(ctx context.Context, id string, version int64) (models.Product, error) {
+	var deleted models.Product
+	err := c.do(ctx, request{Method: http.MethodDelete, Path: "/api/products/" + escape(id), IfMatch: version}, &deleted)
+	return deleted, err
+}
+
+func (c *Client) RestoreProduct(ctx context.Context, id string) (models.Product, error) {
+	var restored models.Product
+	err := c.do(ctx, request{Method: http.MethodPost, Path: "/api/products/" + escape(id) + "/restore"}, &restored)
+	return restored, err
+}
+
+// ImportProducts uploads a csv or xlsx file named filename. Rows that fail
+// validation do not return an error: the result lists them and nothing is
+// applied.
+func (c *Client) ImportProducts(ctx context.Context, filename string, file io.Reader, dryRun bool) (models.ProductImportResult, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return models.ProductImportResult{}, err
+	}
+	_, err = io.Copy(part, file)
+	if err != nil {
+		return models.ProductImportResult{}, err
+	}
+	err = writer.Close()
+	if err != nil {
+		return models.ProductImportResult{}, err
+	}
+
+	query := url.Values{}
+	setBool(query, "dry_run", dryRun)
+	var result models.ProductImportResult
+	err = c.do(ctx, request{
+		Method: http.MethodPost, Path: "/api/products/import", Query: query,
+		RawBody: body.Bytes(), ContentType: writer.FormDataContentType(),
+		Accept: []int{http.StatusUnprocessableEntity},
+	}, &result)
+	return result, err
+}
+
+func (c *Client) ListProductCategories(ctx context.Context, productID string) ([]models.Category, error) {
+	var categories []models.Category
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/products/" + escape(productID) + "/categories"}, &categories)
+	return categories, err
+}
+
+func (c *Client) AddProductCategory(ctx context.Context, productID, categoryID string) error {
+	return c.do(ctx, request{
+		Method: http.MethodPost, Path: "/api/products/" + escape(productID) + "/categories",
+		Body: models.ProductCategoryRequest{CategoryID: categoryID},
+	}, nil)
+}
+
+func (c *Client) RemoveProductCategory(ctx context.Context, productID, categoryID string) error {
+	return c.do(ctx, request{
+		Method: http.MethodDelete, Path: "/api/products/" + escape(productID) + "/categories",
+		Body: models.ProductCategoryRequest{CategoryID: categoryID},
+	}, nil)
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"kasir-api/models"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func (c *Client) GetReport(ctx context.Context, dates Dates) (models.Report, error) {
+	query := url.Values{}
+	dates.addTo(query)
+	var report models.Report
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/reports", Query: query}, &report)
+	return report, err
+}
+
+func (c *Client) GetReportToday(ctx context.Context) (models.Report, error) {
+	var report models.Report
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/reports/today"}, &report)
+	return report, err
+}
+
+// GetSalesReport groups sales by one of the models.GroupBy values, by day
+// when groupBy is empty.
+func (c *Client) GetSalesReport(ctx context.Context, groupBy string, dates Dates) (models.SalesReport, error) {
+	query := url.Values{}
+	setString(query, "group_by", groupBy)
+	dates.addTo(query)
+	var report models.SalesReport
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/reports/sales", Query: query}, &report)
+	return report, err
+}
+
+// ProductRankingOptions selects the ranking metric, one of the models.RankBy
+// values, and how many products to list at each end. Zero values take the
+// server defaults.
+type ProductRankingOptions struct {
+	Metric string
+	Top    int
+	Bottom int
+	Dates  Dates
+}
+
+func (c *Client) GetProductRanking(ctx context.Context, options ProductRankingOptions) (models.ProductRankingReport, error) {
+	query := url.Values{}
+	setString(query, "metric", options.Metric)
+	setInt(query, "top", options.Top)
+	setInt(query, "bottom", options.Bottom)
+	options.Dates.addTo(query)
+	var report models.ProductRankingReport
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/reports/products", Query: query}, &report)
+	return report, err
+}
+
+// GetDeadStock lists stocked products without a sale in days, the server
+// default when zero.
+func (c *Client) GetDeadStock(ctx context.Context, days int) (models.DeadStockReport, error) {
+	query := url.Values{}
+	setInt(query, "days", days)
+	var report models.DeadStockReport
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/reports/dead-stock", Query: query}, &report)
+	return report, err
+}
+
+// GetABCReport classifies products by cumulative revenue share, thresholdA
+// and thresholdB being percentages and zero taking the server defaults.
+func (c *Client) GetABCReport(ctx context.Context, thresholdA, thresholdB float64, dates Dates) (models.ABCReport, error) {
+	query := url.Values{}
+	if thresholdA != 0 {
+		query.Set("a", strconv.FormatFloat(thresholdA, 'f', -1, 64))
+	}
+	if thresholdB != 0 {
+		query.Set("b", strconv.FormatFloat(thresholdB, 'f', -1, 64))
+	}
+	dates.addTo(query)
+	var report models.ABCReport
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/reports/abc", Query: query}, &report)
+	return report, err
+}
+
+// BasketOptions tunes the basket analysis, zero values take the server
+// defaults.
+type BasketOptions struct {
+	MinCount int
+	Limit    int
+	Dates    Dates
+}
+
+func (o BasketOptions) query() url.Values {
+	query := url.Values{}
+	setInt(query, "min_count", o.MinCount)
+	setInt(query, "limit", o.Limit)
+	o.Dates.addTo(query)
+	return query
+}
+
+func (c *Client) GetBasketReport(ctx context.Context, options BasketOptions) (models.BasketReport, error) {
+	var report models.BasketReport
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/reports/basket", Query: options.query()}, &report)
+	return report, err
+}
+
+func (c *Client) GetFrequentlyBoughtWith(ctx context.Context, productID string, options BasketOptions) (models.FrequentlyBoughtWith, error) {
+	var result models.FrequentlyBoughtWith
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/products/" + escape(productID) + "/frequently-bought-with", Query: options.query()}, &result)
+	return result, err
+}
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+	"kasir-api/models"
+	"net/http"
+	"net/url"
+)
+
+// Checkout sells the items. It is safe to retry: every attempt carries the
+// same Idempotency-Key, so the sale is recorded once.
+func (c *Client) Checkout(ctx context.Context, checkout models.CheckoutRequest) (models.Transaction, error) {
+	var transaction models.Transaction
+	err := c.do(ctx, request{Method: http.MethodPost, Path: "/api/checkout", Body: checkout}, &transaction)
+	return transaction, err
+}
+
+// ListTransactions lists the transactions of the given days, all of them
+// when dates is empty.
+func (c *Client) ListTransactions(ctx context.Context, dates Dates) ([]models.Transaction, error) {
+	query := url.Values{}
+	dates.addTo(query)
+	var transactions []models.Transaction
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/transactions", Query: query}, &transactions)
+	return transactions, err
+}
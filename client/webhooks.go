@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"kasir-api/models"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func (c *Client) ListWebhooks(ctx context.Context) ([]models.WebhookSubscription, error) {
+	var webhooks []models.WebhookSubscription
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/webhooks"}, &webhooks)
+	return webhooks, err
+}
+
+// CreateWebhook subscribes to outbox events. The returned subscription is the
+// only one carrying the secret.
+func (c *Client) CreateWebhook(ctx context.Context, webhook models.WebhookRequest) (models.WebhookSubscription, error) {
+	var created models.WebhookSubscription
+	err := c.do(ctx, request{Method: http.MethodPost, Path: "/api/webhooks", Body: webhook}, &created)
+	return created, err
+}
+
+func (c *Client) GetWebhook(ctx context.Context, id string) (models.WebhookSubscription, error) {
+	var webhook models.WebhookSubscription
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/webhooks/" + escape(id)}, &webhook)
+	return webhook, err
+}
+
+func (c *Client) UpdateWebhook(ctx context.Context, id string, webhook models.WebhookRequest) (models.WebhookSubscription, error) {
+	var updated models.WebhookSubscription
+	err := c.do(ctx, request{Method: http.MethodPut, Path: "/api/webhooks/" + escape(id), Body: webhook}, &updated)
+	return updated, err
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, id string) (models.WebhookSubscription, error) {
+	var deleted models.WebhookSubscription
+	err := c.do(ctx, request{Method: http.MethodDelete, Path: "/api/webhooks/" + escape(id)}, &deleted)
+	return deleted, err
+}
+
+// ListWebhookDeliveries lists up to limit deliveries in status, the server
+// defaults being dead deliveries and its page size.
+func (c *Client) ListWebhookDeliveries(ctx context.Context, status string, limit int) ([]models.WebhookDelivery, error) {
+	query := url.Values{}
+	setString(query, "status", status)
+	setInt(query, "limit", limit)
+	var deliveries []models.WebhookDelivery
+	err := c.do(ctx, request{Method: http.MethodGet, Path: "/api/webhooks/deliveries", Query: query}, &deliveries)
+	return deliveries, err
+}
+
+func (c *Client) RetryWebhookDelivery(ctx context.Context, id int64) error {
+	return c.do(ctx, request{Method: http.MethodPost, Path: "/api/webhooks/deliveries/" + strconv.FormatInt(id, 10) + "/retry"}, nil)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"kasir-api/internal"
+	"kasir-api/services"
+	"log"
+	"os"
+)
+
+// runCommand runs a maintenance command instead of the server:
+//
+//	rollup-check [-from YYYY-MM-DD] [-to YYYY-MM-DD]
+//	rollup-rebuild [-from YYYY-MM-DD] [-to YYYY-MM-DD]
+//
+// rollup-check prints every day and product where the daily sales rollup
+// differs from the raw transactions as JSON lines and fails if there are any.
+func runCommand(rollupService *services.RollupService, args []string) error {
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	from := flags.String("from", "", "first store day to include, YYYY-MM-DD")
+	to := flags.String("to", "", "last store day to include, YYYY-MM-DD")
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+	dateRange, err := internal.ParseDateRange(*from, *to)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "rollup-check":
+		mismatches, err := rollupService.Check(dateRange)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		for _, mismatch := range mismatches {
+			err = encoder.Encode(mismatch)
+			if err != nil {
+				return err
+			}
+		}
+		if len(mismatches) > 0 {
+			return fmt.Errorf("daily sales rollup has %d mismatches, run rollup-rebuild to repair", len(mismatches))
+		}
+		log.Println("Daily sales rollup matches the transactions")
+		return nil
+	case "rollup-rebuild":
+		err = rollupService.Rebuild(dateRange)
+		if err != nil {
+			return err
+		}
+		log.Println("Daily sales rollup rebuilt")
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q, expected rollup-check or rollup-rebuild", args[0])
+	}
+}
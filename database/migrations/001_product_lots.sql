@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS product_lots (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	product_id UUID NOT NULL REFERENCES products(id),
+	lot_number TEXT NOT NULL,
+	expiry_date DATE,
+	quantity INT NOT NULL CHECK (quantity >= 0),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	UNIQUE (product_id, lot_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_lots_fefo ON product_lots (product_id, expiry_date NULLS LAST, created_at) WHERE quantity > 0;
+
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS lot_id UUID REFERENCES product_lots(id);
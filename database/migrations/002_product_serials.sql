@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS product_serials (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	product_id UUID NOT NULL REFERENCES products(id),
+	serial_number TEXT NOT NULL UNIQUE,
+	status TEXT NOT NULL DEFAULT 'in_stock' CHECK (status IN ('in_stock', 'sold')),
+	transaction_id UUID REFERENCES transactions(id),
+	received_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	sold_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_serials_product ON product_serials (product_id, status);
+
+CREATE TABLE IF NOT EXISTS serial_events (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	serial_id UUID NOT NULL REFERENCES product_serials(id),
+	event TEXT NOT NULL,
+	transaction_id UUID REFERENCES transactions(id),
+	transaction_detail_id UUID REFERENCES transaction_details(id),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_serial_events_serial ON serial_events (serial_id, created_at);
+
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS serial_number TEXT;
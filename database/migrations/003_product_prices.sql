@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS product_prices (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	price BIGINT NOT NULL CHECK (price >= 0),
+	effective_at TIMESTAMPTZ NOT NULL,
+	applied_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_prices_effective ON product_prices (product_id, effective_at DESC, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_product_prices_pending ON product_prices (effective_at) WHERE applied_at IS NULL;
+
+-- Seed history with the current price so every product has a starting point.
+INSERT INTO product_prices (product_id, price, effective_at, applied_at)
+SELECT id, price, created_at, created_at FROM products p
+WHERE NOT EXISTS (SELECT 1 FROM product_prices pp WHERE pp.product_id = p.id);
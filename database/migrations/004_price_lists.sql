@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS price_lists (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	name TEXT NOT NULL UNIQUE,
+	description TEXT NOT NULL DEFAULT '',
+	is_default BOOLEAN NOT NULL DEFAULT false,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Only one list may apply to walk-in customers.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_price_lists_default ON price_lists (is_default) WHERE is_default;
+
+CREATE TABLE IF NOT EXISTS price_list_items (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	price_list_id UUID NOT NULL REFERENCES price_lists(id) ON DELETE CASCADE,
+	product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	min_quantity INT NOT NULL DEFAULT 1 CHECK (min_quantity >= 1),
+	price BIGINT NOT NULL CHECK (price >= 0),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	UNIQUE (price_list_id, product_id, min_quantity)
+);
+
+CREATE TABLE IF NOT EXISTS customer_groups (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	name TEXT NOT NULL UNIQUE,
+	price_list_id UUID REFERENCES price_lists(id) ON DELETE SET NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS customers (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	name TEXT NOT NULL,
+	phone TEXT NOT NULL DEFAULT '',
+	email TEXT NOT NULL DEFAULT '',
+	customer_group_id UUID REFERENCES customer_groups(id) ON DELETE SET NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS customer_id UUID REFERENCES customers(id);
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS price_list_id UUID REFERENCES price_lists(id) ON DELETE SET NULL;
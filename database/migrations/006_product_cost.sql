@@ -0,0 +1 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS cost BIGINT NOT NULL DEFAULT 0 CHECK (cost >= 0);
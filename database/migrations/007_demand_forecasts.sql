@@ -0,0 +1,11 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS lead_time_days INT NOT NULL DEFAULT 7 CHECK (lead_time_days >= 0);
+
+-- One row per product, replaced on every forecast run. weekday_factors holds
+-- seven multipliers starting on Sunday.
+CREATE TABLE IF NOT EXISTS demand_forecasts (
+	product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+	daily_demand DOUBLE PRECISION NOT NULL,
+	weekday_factors DOUBLE PRECISION[] NOT NULL,
+	history_days INT NOT NULL,
+	generated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,29 @@
+-- Sales rolled up per store calendar day. daily_sales is per product and
+-- daily_sales_totals per day, since a transaction with several products must
+-- only be counted once in the day's transaction count. Both are kept up to
+-- date by checkout and can be rebuilt from the raw transactions at any time.
+CREATE TABLE IF NOT EXISTS daily_sales (
+	sale_date DATE NOT NULL,
+	product_id UUID NOT NULL,
+	quantity BIGINT NOT NULL DEFAULT 0,
+	revenue BIGINT NOT NULL DEFAULT 0,
+	transaction_count BIGINT NOT NULL DEFAULT 0,
+	PRIMARY KEY (sale_date, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_sales_product ON daily_sales (product_id, sale_date);
+
+CREATE TABLE IF NOT EXISTS daily_sales_totals (
+	sale_date DATE PRIMARY KEY,
+	quantity BIGINT NOT NULL DEFAULT 0,
+	revenue BIGINT NOT NULL DEFAULT 0,
+	transaction_count BIGINT NOT NULL DEFAULT 0
+);
+
+-- The timezone the rollup was built in. Days are cut in the store timezone,
+-- so changing it requires a full rebuild.
+CREATE TABLE IF NOT EXISTS daily_sales_state (
+	id BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+	timezone TEXT NOT NULL,
+	rebuilt_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
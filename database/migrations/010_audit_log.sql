@@ -0,0 +1,113 @@
+-- Append-only record of every row change to the catalog, pricing, customer,
+-- sales and webhook tables, written by triggers so no code path can skip it.
+-- The API sets audit.actor, audit.request_id and audit.ip for its transaction;
+-- changes made without them, such as scheduled jobs, are attributed to "system".
+CREATE TABLE IF NOT EXISTS audit_log (
+	id BIGSERIAL PRIMARY KEY,
+	actor TEXT NOT NULL,
+	action TEXT NOT NULL,
+	entity_type TEXT NOT NULL,
+	entity_id TEXT NOT NULL,
+	before JSONB,
+	after JSONB,
+	request_id TEXT NOT NULL DEFAULT '',
+	ip TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	prev_hash TEXT NOT NULL,
+	hash TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log (actor, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log (created_at);
+
+-- Each entry hashes its own content together with the previous entry's hash,
+-- so editing or removing an entry breaks every hash after it.
+CREATE OR REPLACE FUNCTION audit_entry_hash(
+	prev_hash TEXT, actor TEXT, action TEXT, entity_type TEXT, entity_id TEXT,
+	before JSONB, after JSONB, request_id TEXT, ip TEXT, created_at TIMESTAMPTZ
+) RETURNS TEXT AS $$
+	SELECT encode(sha256(convert_to(concat_ws('|',
+		prev_hash, actor, action, entity_type, entity_id,
+		COALESCE(before::text, ''), COALESCE(after::text, ''), request_id, ip,
+		to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US')
+	), 'UTF8')), 'hex')
+$$ LANGUAGE sql STABLE;
+
+-- audit_row logs the changed row. Trigger arguments name the key columns that
+-- make up the entity id, "id" when none are given. Secrets are never logged.
+CREATE OR REPLACE FUNCTION audit_row() RETURNS trigger AS $$
+DECLARE
+	old_row JSONB;
+	new_row JSONB;
+	key_row JSONB;
+	entity_id TEXT;
+	prev TEXT;
+	actor TEXT := COALESCE(NULLIF(current_setting('audit.actor', true), ''), 'system');
+	request_id TEXT := COALESCE(current_setting('audit.request_id', true), '');
+	ip TEXT := COALESCE(current_setting('audit.ip', true), '');
+	created TIMESTAMPTZ := now();
+	i INT;
+BEGIN
+	IF TG_OP <> 'INSERT' THEN
+		old_row := to_jsonb(OLD) - 'secret';
+	END IF;
+	IF TG_OP <> 'DELETE' THEN
+		new_row := to_jsonb(NEW) - 'secret';
+	END IF;
+	IF TG_OP = 'UPDATE' AND old_row = new_row THEN
+		RETURN NULL;
+	END IF;
+
+	key_row := COALESCE(new_row, old_row);
+	IF TG_NARGS = 0 THEN
+		entity_id := key_row->>'id';
+	ELSE
+		entity_id := key_row->>TG_ARGV[0];
+		FOR i IN 1 .. TG_NARGS - 1 LOOP
+			entity_id := entity_id || ':' || (key_row->>TG_ARGV[i]);
+		END LOOP;
+	END IF;
+
+	-- Serialize writers so the chain follows id order.
+	PERFORM pg_advisory_xact_lock(hashtext('audit_log'));
+	SELECT a.hash INTO prev FROM audit_log a ORDER BY a.id DESC LIMIT 1;
+	prev := COALESCE(prev, '');
+
+	INSERT INTO audit_log (actor, action, entity_type, entity_id, before, after, request_id, ip, created_at, prev_hash, hash)
+	VALUES (actor, lower(TG_OP), TG_TABLE_NAME, entity_id, old_row, new_row, request_id, ip, created, prev,
+		audit_entry_hash(prev, actor, lower(TG_OP), TG_TABLE_NAME, entity_id, old_row, new_row, request_id, ip, created));
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+CREATE TRIGGER audit_log_append_only BEFORE UPDATE OR DELETE ON audit_log
+	FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+CREATE TRIGGER audit_log_no_truncate BEFORE TRUNCATE ON audit_log
+	FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
+
+DO $$
+DECLARE
+	audited TEXT;
+BEGIN
+	FOREACH audited IN ARRAY ARRAY[
+		'products', 'categories', 'product_lots', 'product_serials', 'product_prices',
+		'price_lists', 'price_list_items', 'customer_groups', 'customers', 'transactions', 'webhook_subscriptions'
+	] LOOP
+		EXECUTE format('DROP TRIGGER IF EXISTS audit_row ON %I', audited);
+		EXECUTE format('CREATE TRIGGER audit_row AFTER INSERT OR UPDATE OR DELETE ON %I FOR EACH ROW EXECUTE FUNCTION audit_row()', audited);
+	END LOOP;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS audit_row ON product_categories;
+CREATE TRIGGER audit_row AFTER INSERT OR UPDATE OR DELETE ON product_categories
+	FOR EACH ROW EXECUTE FUNCTION audit_row('product_id', 'category_id');
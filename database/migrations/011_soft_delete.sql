@@ -0,0 +1,4 @@
+-- Products and categories are archived instead of deleted, so transaction
+-- lines and reports keep resolving the names of what was sold.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
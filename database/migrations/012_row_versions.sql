@@ -0,0 +1,23 @@
+-- Products and categories carry a version that changes with every update,
+-- whichever code path makes it. The API exposes it as the ETag and checks
+-- If-Match against it, so concurrent edits fail instead of overwriting.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+CREATE OR REPLACE FUNCTION bump_row_version() RETURNS trigger AS $$
+BEGIN
+	NEW.version := OLD.version;
+	IF NEW IS DISTINCT FROM OLD THEN
+		NEW.version := OLD.version + 1;
+	END IF;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS products_version ON products;
+CREATE TRIGGER products_version BEFORE UPDATE ON products
+	FOR EACH ROW EXECUTE FUNCTION bump_row_version();
+
+DROP TRIGGER IF EXISTS categories_version ON categories;
+CREATE TRIGGER categories_version BEFORE UPDATE ON categories
+	FOR EACH ROW EXECUTE FUNCTION bump_row_version();
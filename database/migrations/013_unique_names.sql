@@ -0,0 +1,27 @@
+-- Live category names are unique regardless of case, so a name archived
+-- categories used can be taken again. Existing duplicates are settled first:
+-- the oldest category keeps the name and the products of the others, which
+-- are archived.
+CREATE TEMPORARY TABLE duplicate_categories ON COMMIT DROP AS
+SELECT c.id, (
+	SELECT keeper.id FROM categories keeper
+	WHERE keeper.deleted_at IS NULL AND lower(keeper.name) = lower(c.name)
+	ORDER BY keeper.created_at, keeper.id
+	LIMIT 1
+) AS keeper_id
+FROM categories c
+WHERE c.deleted_at IS NULL;
+DELETE FROM duplicate_categories WHERE id = keeper_id;
+
+INSERT INTO product_categories (product_id, category_id)
+SELECT pc.product_id, d.keeper_id
+FROM product_categories pc
+INNER JOIN duplicate_categories d ON d.id = pc.category_id;
+UPDATE categories SET deleted_at = now() WHERE id IN (SELECT id FROM duplicate_categories);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name ON categories (lower(name)) WHERE deleted_at IS NULL;
+
+-- A product is in a category at most once.
+DELETE FROM product_categories a USING product_categories b
+WHERE a.ctid < b.ctid AND a.product_id = b.product_id AND a.category_id = b.category_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_categories_pair ON product_categories (product_id, category_id);
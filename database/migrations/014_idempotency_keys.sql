@@ -0,0 +1,15 @@
+-- Responses to POST and PATCH requests sent with an Idempotency-Key header,
+-- replayed when the client retries. A row without a status code belongs to a
+-- request that is still running.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+	key TEXT PRIMARY KEY,
+	method TEXT NOT NULL,
+	path TEXT NOT NULL,
+	request_hash TEXT NOT NULL,
+	status_code INT,
+	header JSONB NOT NULL DEFAULT '{}',
+	body BYTEA NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys (created_at);
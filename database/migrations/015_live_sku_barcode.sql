@@ -0,0 +1,7 @@
+-- SKUs and barcodes only need to be unique among live products, so a code an
+-- archived product used can be given to a new one. Restoring the archived
+-- product then fails as a duplicate until one of them is changed.
+DROP INDEX IF EXISTS idx_products_sku;
+DROP INDEX IF EXISTS idx_products_barcode;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_sku ON products (sku) WHERE sku IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_barcode ON products (barcode) WHERE barcode IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,7 @@
+-- Clients pick their own idempotency keys, so two of them may pick the same
+-- one. Keys are scoped to the X-Actor of the request, and keys stored before
+-- this migration belong to no actor and expire unused.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS actor TEXT NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys ALTER COLUMN actor DROP DEFAULT;
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (actor, key);
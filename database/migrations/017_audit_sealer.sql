@@ -0,0 +1,67 @@
+-- Chaining entries inside audit_row took a database-wide lock until commit,
+-- which serialized every audited write and deadlocked checkouts against each
+-- other. Triggers now insert entries unsealed and a background sealer links
+-- them. seq is the position in the chain: entries are sealed in id order as
+-- they become visible, so an entry whose transaction commits late is sealed
+-- after entries with higher ids rather than breaking the chain.
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS seq BIGINT;
+ALTER TABLE audit_log ALTER COLUMN prev_hash DROP NOT NULL;
+ALTER TABLE audit_log ALTER COLUMN hash DROP NOT NULL;
+
+ALTER TABLE audit_log DISABLE TRIGGER audit_log_append_only;
+UPDATE audit_log SET seq = id WHERE seq IS NULL;
+ALTER TABLE audit_log ENABLE TRIGGER audit_log_append_only;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_log_seq ON audit_log (seq);
+CREATE INDEX IF NOT EXISTS idx_audit_log_unsealed ON audit_log (id) WHERE seq IS NULL;
+
+CREATE OR REPLACE FUNCTION audit_row() RETURNS trigger AS $$
+DECLARE
+	old_row JSONB;
+	new_row JSONB;
+	key_row JSONB;
+	entity_id TEXT;
+	actor TEXT := COALESCE(NULLIF(current_setting('audit.actor', true), ''), 'system');
+	request_id TEXT := COALESCE(current_setting('audit.request_id', true), '');
+	ip TEXT := COALESCE(current_setting('audit.ip', true), '');
+	i INT;
+BEGIN
+	IF TG_OP <> 'INSERT' THEN
+		old_row := to_jsonb(OLD) - 'secret';
+	END IF;
+	IF TG_OP <> 'DELETE' THEN
+		new_row := to_jsonb(NEW) - 'secret';
+	END IF;
+	IF TG_OP = 'UPDATE' AND old_row = new_row THEN
+		RETURN NULL;
+	END IF;
+
+	key_row := COALESCE(new_row, old_row);
+	IF TG_NARGS = 0 THEN
+		entity_id := key_row->>'id';
+	ELSE
+		entity_id := key_row->>TG_ARGV[0];
+		FOR i IN 1 .. TG_NARGS - 1 LOOP
+			entity_id := entity_id || ':' || (key_row->>TG_ARGV[i]);
+		END LOOP;
+	END IF;
+
+	INSERT INTO audit_log (actor, action, entity_type, entity_id, before, after, request_id, ip)
+	VALUES (actor, lower(TG_OP), TG_TABLE_NAME, entity_id, old_row, new_row, request_id, ip);
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The only change allowed to an entry is sealing it once: setting seq and its
+-- hashes while every other column stays as written.
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'UPDATE' THEN
+		IF OLD.seq IS NULL AND NEW.seq IS NOT NULL
+			AND to_jsonb(OLD) - 'seq' - 'prev_hash' - 'hash' = to_jsonb(NEW) - 'seq' - 'prev_hash' - 'hash' THEN
+			RETURN NEW;
+		END IF;
+	END IF;
+	RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,44 @@
+-- The actor of an entry is the name the client sent in X-Actor, which nothing
+-- checks, so it is stored as a claim. source_ip is the address of the
+-- connection the change came in on, read by the server rather than sent by
+-- the client.
+ALTER TABLE audit_log RENAME COLUMN actor TO claimed_actor;
+ALTER TABLE audit_log RENAME COLUMN ip TO source_ip;
+ALTER INDEX IF EXISTS idx_audit_log_actor RENAME TO idx_audit_log_claimed_actor;
+
+CREATE OR REPLACE FUNCTION audit_row() RETURNS trigger AS $$
+DECLARE
+	old_row JSONB;
+	new_row JSONB;
+	key_row JSONB;
+	entity_id TEXT;
+	claimed_actor TEXT := COALESCE(NULLIF(current_setting('audit.claimed_actor', true), ''), 'system');
+	request_id TEXT := COALESCE(current_setting('audit.request_id', true), '');
+	source_ip TEXT := COALESCE(current_setting('audit.source_ip', true), '');
+	i INT;
+BEGIN
+	IF TG_OP <> 'INSERT' THEN
+		old_row := to_jsonb(OLD) - 'secret';
+	END IF;
+	IF TG_OP <> 'DELETE' THEN
+		new_row := to_jsonb(NEW) - 'secret';
+	END IF;
+	IF TG_OP = 'UPDATE' AND old_row = new_row THEN
+		RETURN NULL;
+	END IF;
+
+	key_row := COALESCE(new_row, old_row);
+	IF TG_NARGS = 0 THEN
+		entity_id := key_row->>'id';
+	ELSE
+		entity_id := key_row->>TG_ARGV[0];
+		FOR i IN 1 .. TG_NARGS - 1 LOOP
+			entity_id := entity_id || ':' || (key_row->>TG_ARGV[i]);
+		END LOOP;
+	END IF;
+
+	INSERT INTO audit_log (claimed_actor, action, entity_type, entity_id, before, after, request_id, source_ip)
+	VALUES (claimed_actor, lower(TG_OP), TG_TABLE_NAME, entity_id, old_row, new_row, request_id, source_ip);
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,7 @@
+-- Each attempt at a request holds its key under a token of its own and keeps
+-- a short lease on it alive while it runs. A retry only takes the key over
+-- once the lease has run out, and an attempt that was taken over can no
+-- longer store or release the key. Keys still running before this migration
+-- have no lease and can be taken over straight away.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS attempt UUID NOT NULL DEFAULT gen_random_uuid();
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS lease_until TIMESTAMPTZ;
@@ -0,0 +1,78 @@
+package graphqlserver
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	loaderWait     = 2 * time.Millisecond
+	loaderMaxBatch = 500
+)
+
+// loader batches the keys that resolvers ask for into a single fetch. The
+// resolvers of a list run concurrently, so the first key of a batch waits
+// loaderWait for its siblings before the fetch runs. Results are cached for
+// the lifetime of the loader, which is one request.
+type loader[K comparable, V any] struct {
+	fetch func(keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending *batch[K, V]
+	cache   map[K]*batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	keys   []K
+	full   chan struct{}
+	done   chan struct{}
+	values map[K]V
+	err    error
+}
+
+func newLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{fetch: fetch, cache: make(map[K]*batch[K, V])}
+}
+
+// Load returns the value of key, the zero value when the fetch did not
+// return one.
+func (l *loader[K, V]) Load(key K) (V, error) {
+	l.mu.Lock()
+	b, ok := l.cache[key]
+	if !ok {
+		b = l.pending
+		if b == nil {
+			b = &batch[K, V]{full: make(chan struct{}), done: make(chan struct{})}
+			l.pending = b
+			go l.run(b)
+		}
+		b.keys = append(b.keys, key)
+		l.cache[key] = b
+		if len(b.keys) >= loaderMaxBatch {
+			l.pending = nil
+			close(b.full)
+		}
+	}
+	l.mu.Unlock()
+
+	<-b.done
+	return b.values[key], b.err
+}
+
+func (l *loader[K, V]) run(b *batch[K, V]) {
+	timer := time.NewTimer(loaderWait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-b.full:
+	}
+
+	l.mu.Lock()
+	if l.pending == b {
+		l.pending = nil
+	}
+	l.mu.Unlock()
+
+	b.values, b.err = l.fetch(b.keys)
+	close(b.done)
+}
@@ -0,0 +1,39 @@
+package graphqlserver
+
+import (
+	"context"
+	"kasir-api/internal"
+	apperrors "kasir-api/internal/errors"
+	"kasir-api/models"
+
+	"github.com/google/uuid"
+)
+
+// loadProduct returns nil for a product that no longer exists, such as the
+// product of a sales line after a hard delete.
+func loadProduct(ctx context.Context, id string) (*models.Product, error) {
+	if id == "" {
+		return nil, nil
+	}
+	product, err := loadersFrom(ctx).products.Load(id)
+	if err != nil || product.ID == "" {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func parseID(id string) (string, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return "", apperrors.NewBadRequestError("Invalid uuid")
+	}
+	return parsed.String(), nil
+}
+
+func parseDateRange(from, to string) (models.DateRange, error) {
+	dateRange, err := internal.ParseDateRange(from, to)
+	if err != nil {
+		return models.DateRange{}, apperrors.NewBadRequestError(err.Error())
+	}
+	return dateRange, nil
+}
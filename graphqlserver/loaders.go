@@ -0,0 +1,84 @@
+package graphqlserver
+
+import (
+	"context"
+	"kasir-api/models"
+	"net/http"
+)
+
+// limitKey asks for the latest Limit rows of a product. Keys of one batch
+// may carry different limits, so the batch is fetched once per limit.
+type limitKey struct {
+	ID    string
+	Limit int
+}
+
+// loaders hold the batching loaders of one request, so every nested field of
+// a query costs one query per level rather than one per parent.
+type loaders struct {
+	products           *loader[string, models.Product]
+	productCategories  *loader[string, []models.Category]
+	categoryProducts   *loader[string, []models.Product]
+	transactionDetails *loader[string, []models.TransactionDetail]
+	productSales       *loader[limitKey, []models.TransactionDetail]
+	stockHistory       *loader[limitKey, []models.StockChange]
+}
+
+type loadersKey struct{}
+
+func newLoaders(s Services) *loaders {
+	return &loaders{
+		products: newLoader(func(ids []string) (map[string]models.Product, error) {
+			products, err := s.Products.GetProductsByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[string]models.Product, len(products))
+			for _, product := range products {
+				byID[product.ID] = product
+			}
+			return byID, nil
+		}),
+		productCategories:  newLoader(s.Products.GetCategoriesByProductIDs),
+		categoryProducts:   newLoader(s.Categories.GetProductsByCategoryIDs),
+		transactionDetails: newLoader(s.Transactions.GetDetailsByTransactionIDs),
+		productSales: newLoader(func(keys []limitKey) (map[limitKey][]models.TransactionDetail, error) {
+			return fetchByLimit(keys, s.Transactions.GetDetailsByProductIDs)
+		}),
+		stockHistory: newLoader(func(keys []limitKey) (map[limitKey][]models.StockChange, error) {
+			return fetchByLimit(keys, s.Audit.GetStockHistory)
+		}),
+	}
+}
+
+// fetchByLimit runs fetch once for every distinct limit among keys.
+func fetchByLimit[V any](keys []limitKey, fetch func(ids []string, limit int) (map[string][]V, error)) (map[limitKey][]V, error) {
+	idsByLimit := make(map[int][]string)
+	for _, key := range keys {
+		idsByLimit[key.Limit] = append(idsByLimit[key.Limit], key.ID)
+	}
+	values := make(map[limitKey][]V, len(keys))
+	for limit, ids := range idsByLimit {
+		rows, err := fetch(ids, limit)
+		if err != nil {
+			return nil, err
+		}
+		for id, row := range rows {
+			values[limitKey{ID: id, Limit: limit}] = row
+		}
+	}
+	return values, nil
+}
+
+// withLoaders gives every request its own loaders, so nothing is cached
+// across requests.
+func withLoaders(s Services, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), loadersKey{}, newLoaders(s))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
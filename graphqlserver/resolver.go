@@ -0,0 +1,7 @@
+package graphqlserver
+
+//go:generate go tool gqlgen generate
+
+type Resolver struct {
+	services Services
+}
@@ -0,0 +1,252 @@
+package graphqlserver
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.86
+
+import (
+	"context"
+	"fmt"
+	apperrors "kasir-api/internal/errors"
+	"kasir-api/models"
+)
+
+// Products is the resolver for the products field.
+func (r *categoryResolver) Products(ctx context.Context, obj *models.Category) ([]models.Product, error) {
+	products, err := loadersFrom(ctx).categoryProducts.Load(obj.ID)
+	if products == nil {
+		products = []models.Product{}
+	}
+	return products, err
+}
+
+// Product is the resolver for the product field.
+func (r *deadStockItemResolver) Product(ctx context.Context, obj *models.DeadStockItem) (*models.Product, error) {
+	return loadProduct(ctx, obj.ProductID)
+}
+
+// Categories is the resolver for the categories field.
+func (r *productResolver) Categories(ctx context.Context, obj *models.Product) ([]models.Category, error) {
+	if obj.Categories != nil {
+		return obj.Categories, nil
+	}
+	categories, err := loadersFrom(ctx).productCategories.Load(obj.ID)
+	if categories == nil {
+		categories = []models.Category{}
+	}
+	return categories, err
+}
+
+// Sales is the resolver for the sales field.
+func (r *productResolver) Sales(ctx context.Context, obj *models.Product, limit int) ([]models.TransactionDetail, error) {
+	if limit < 0 || limit > maxLimit {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Invalid limit, expected 0 to %d", maxLimit))
+	}
+	sales, err := loadersFrom(ctx).productSales.Load(limitKey{ID: obj.ID, Limit: limit})
+	if sales == nil {
+		sales = []models.TransactionDetail{}
+	}
+	return sales, err
+}
+
+// StockHistory is the resolver for the stockHistory field.
+func (r *productResolver) StockHistory(ctx context.Context, obj *models.Product, limit int) ([]models.StockChange, error) {
+	if limit < 0 || limit > maxLimit {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Invalid limit, expected 0 to %d", maxLimit))
+	}
+	history, err := loadersFrom(ctx).stockHistory.Load(limitKey{ID: obj.ID, Limit: limit})
+	if history == nil {
+		history = []models.StockChange{}
+	}
+	return history, err
+}
+
+// Product is the resolver for the product field.
+func (r *productPerformanceResolver) Product(ctx context.Context, obj *models.ProductPerformance) (*models.Product, error) {
+	return loadProduct(ctx, obj.ProductID)
+}
+
+// Products is the resolver for the products field.
+func (r *queryResolver) Products(ctx context.Context, name string, includeArchived bool) ([]models.Product, error) {
+	return r.services.Products.GetProducts(name, includeArchived)
+}
+
+// Product is the resolver for the product field.
+func (r *queryResolver) Product(ctx context.Context, id string) (*models.Product, error) {
+	productID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	product, err := r.services.Products.GetProductByID(productID)
+	if err != nil || product.ID == "" {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// Categories is the resolver for the categories field.
+func (r *queryResolver) Categories(ctx context.Context, includeArchived bool) ([]models.Category, error) {
+	return r.services.Categories.GetCategories(includeArchived)
+}
+
+// Category is the resolver for the category field.
+func (r *queryResolver) Category(ctx context.Context, id string) (*models.Category, error) {
+	categoryID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	category, err := r.services.Categories.GetCategoryByID(categoryID)
+	if err != nil || category.ID == "" {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// Transactions is the resolver for the transactions field.
+func (r *queryResolver) Transactions(ctx context.Context, from string, to string, limit int) ([]models.Transaction, error) {
+	if limit < 0 || limit > maxLimit {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Invalid limit, expected 0 to %d", maxLimit))
+	}
+	dateRange, err := parseDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := r.services.Transactions.GetLatestTransactions(dateRange, limit)
+	if transactions == nil {
+		transactions = []models.Transaction{}
+	}
+	return transactions, err
+}
+
+// Report is the resolver for the report field.
+func (r *queryResolver) Report(ctx context.Context, from string, to string) (*models.Report, error) {
+	dateRange, err := parseDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	report, err := r.services.Reports.GetReportsRange(dateRange)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ReportToday is the resolver for the reportToday field.
+func (r *queryResolver) ReportToday(ctx context.Context) (*models.Report, error) {
+	report, err := r.services.Reports.GetReportToday()
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// SalesReport is the resolver for the salesReport field.
+func (r *queryResolver) SalesReport(ctx context.Context, groupBy string, from string, to string) (*models.SalesReport, error) {
+	switch groupBy {
+	case models.GroupByHour, models.GroupByDay, models.GroupByWeek, models.GroupByMonth, models.GroupByProduct, models.GroupByCategory:
+	case models.GroupByCashier:
+		return nil, apperrors.NewBadRequestError("Grouping by cashier is not available, transactions do not record a cashier yet")
+	default:
+		return nil, apperrors.NewBadRequestError("Invalid groupBy, expected hour, day, week, month, product or category")
+	}
+
+	dateRange, err := parseDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	report, err := r.services.Reports.GetSalesReport(groupBy, dateRange)
+	if err != nil {
+		return nil, err
+	}
+	report.From = from
+	report.To = to
+	return &report, nil
+}
+
+// ProductRanking is the resolver for the productRanking field.
+func (r *queryResolver) ProductRanking(ctx context.Context, metric string, top int, bottom int, from string, to string) (*models.ProductRankingReport, error) {
+	if metric != models.RankByQuantity && metric != models.RankByRevenue && metric != models.RankByMargin {
+		return nil, apperrors.NewBadRequestError("Invalid metric, expected quantity, revenue or margin")
+	}
+	if top < 0 || top > maxLimit {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Invalid top, expected 0 to %d", maxLimit))
+	}
+	if bottom < 0 || bottom > maxLimit {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Invalid bottom, expected 0 to %d", maxLimit))
+	}
+
+	dateRange, err := parseDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	report, err := r.services.Reports.GetProductRanking(metric, top, bottom, dateRange)
+	if err != nil {
+		return nil, err
+	}
+	report.From = from
+	report.To = to
+	return &report, nil
+}
+
+// DeadStock is the resolver for the deadStock field.
+func (r *queryResolver) DeadStock(ctx context.Context, days int) (*models.DeadStockReport, error) {
+	if days <= 0 || days > maxDeadStockDays {
+		return nil, apperrors.NewBadRequestError("Invalid days")
+	}
+	report, err := r.services.Reports.GetDeadStock(days)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Details is the resolver for the details field.
+func (r *transactionResolver) Details(ctx context.Context, obj *models.Transaction) ([]models.TransactionDetail, error) {
+	if obj.Details != nil {
+		return obj.Details, nil
+	}
+	details, err := loadersFrom(ctx).transactionDetails.Load(obj.ID)
+	if details == nil {
+		details = []models.TransactionDetail{}
+	}
+	return details, err
+}
+
+// Product is the resolver for the product field.
+func (r *transactionDetailResolver) Product(ctx context.Context, obj *models.TransactionDetail) (*models.Product, error) {
+	return loadProduct(ctx, obj.ProductID)
+}
+
+// Category returns CategoryResolver implementation.
+func (r *Resolver) Category() CategoryResolver { return &categoryResolver{r} }
+
+// DeadStockItem returns DeadStockItemResolver implementation.
+func (r *Resolver) DeadStockItem() DeadStockItemResolver { return &deadStockItemResolver{r} }
+
+// Product returns ProductResolver implementation.
+func (r *Resolver) Product() ProductResolver { return &productResolver{r} }
+
+// ProductPerformance returns ProductPerformanceResolver implementation.
+func (r *Resolver) ProductPerformance() ProductPerformanceResolver {
+	return &productPerformanceResolver{r}
+}
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Transaction returns TransactionResolver implementation.
+func (r *Resolver) Transaction() TransactionResolver { return &transactionResolver{r} }
+
+// TransactionDetail returns TransactionDetailResolver implementation.
+func (r *Resolver) TransactionDetail() TransactionDetailResolver {
+	return &transactionDetailResolver{r}
+}
+
+type categoryResolver struct{ *Resolver }
+type deadStockItemResolver struct{ *Resolver }
+type productResolver struct{ *Resolver }
+type productPerformanceResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type transactionResolver struct{ *Resolver }
+type transactionDetailResolver struct{ *Resolver }
@@ -0,0 +1,116 @@
+// Package graphqlserver serves a read-only GraphQL API for the back-office
+// over the same services as the REST and gRPC APIs. The schema lives in
+// schema.graphqls and the executor is generated by gqlgen.
+package graphqlserver
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal"
+	apperrors "kasir-api/internal/errors"
+	"kasir-api/services"
+	"log"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	// MaxComplexity bounds the cost of a query, counting one per field and
+	// multiplying the fields below a list by its expected length.
+	MaxComplexity = 5000
+	// listEstimate and nestedListEstimate are the lengths assumed for lists
+	// without a limit argument, at the top of a query and below it.
+	listEstimate       = 100
+	nestedListEstimate = 10
+	maxLimit           = 100
+	maxDeadStockDays   = 3650
+)
+
+type Services struct {
+	Products     *services.ProductService
+	Categories   *services.CategoryService
+	Transactions *services.TransactionService
+	Reports      *services.ReportService
+	Audit        *services.AuditService
+}
+
+// New returns the /graphql handler. It answers GET and POST requests and
+// rejects queries above MaxComplexity before running them.
+func New(s Services) http.Handler {
+	config := Config{Resolvers: &Resolver{services: s}}
+	setComplexity(&config.Complexity)
+
+	server := handler.New(NewExecutableSchema(config))
+	server.AddTransport(transport.GET{})
+	server.AddTransport(transport.POST{})
+	server.Use(extension.Introspection{})
+	server.Use(extension.FixedComplexityLimit(MaxComplexity))
+	server.SetErrorPresenter(presentError)
+	server.SetRecoverFunc(func(ctx context.Context, err any) error {
+		log.Println("GraphQL resolver panic: ", err)
+		return errors.New("internal server error")
+	})
+	next := withLoaders(s, server)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodPost:
+			next.ServeHTTP(w, r)
+		default:
+			internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	})
+}
+
+func setComplexity(c *ComplexityRoot) {
+	list := func(child int) int { return 1 + listEstimate*child }
+	nested := func(child int) int { return 1 + nestedListEstimate*child }
+
+	c.Query.Products = func(child int, _ string, _ bool) int { return list(child) }
+	c.Query.Categories = func(child int, _ bool) int { return list(child) }
+	c.Query.Transactions = func(child int, _ string, _ string, limit int) int { return 1 + max(limit, 1)*child }
+	c.Query.ProductRanking = func(child int, _ string, top int, bottom int, _ string, _ string) int {
+		return 1 + max(top+bottom, 1)*child
+	}
+	c.Query.DeadStock = func(child int, _ int) int { return list(child) }
+	c.Product.Categories = func(child int) int { return nested(child) }
+	c.Product.Sales = func(child int, limit int) int { return 1 + max(limit, 1)*child }
+	c.Product.StockHistory = func(child int, limit int) int { return 1 + max(limit, 1)*child }
+	c.Category.Products = func(child int) int { return nested(child) }
+	c.Transaction.Details = func(child int) int { return nested(child) }
+	c.SalesReport.Rows = func(child int) int { return list(child) }
+}
+
+// presentError gives resolver errors a code extension, the counterpart of
+// internal.WriteError. Anything that is not a typed error is logged and
+// reported as a bare internal error so database messages never reach the
+// client. Parse and validation errors are left as gqlgen reports them.
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+	if presented.Err == nil {
+		return presented
+	}
+
+	var notFound *apperrors.NotFoundError
+	var badRequest *apperrors.BadRequestError
+	code := ""
+	switch {
+	case errors.As(err, &notFound):
+		code = "not_found"
+	case errors.As(err, &badRequest):
+		code = "bad_request"
+	default:
+		log.Println(err)
+		presented.Message = "Internal server error"
+		code = "internal_server_error"
+	}
+	if presented.Extensions == nil {
+		presented.Extensions = map[string]any{}
+	}
+	presented.Extensions["code"] = code
+	return presented
+}
@@ -0,0 +1,130 @@
+package grpcserver
+
+import (
+	"context"
+	"kasir-api/internal"
+	"kasir-api/proto/kasirpb"
+	"kasir-api/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type categoryServer struct {
+	kasirpb.UnimplementedCategoryServiceServer
+	categories *services.CategoryService
+}
+
+func (s *categoryServer) ListCategories(ctx context.Context, req *kasirpb.ListCategoriesRequest) (*kasirpb.ListCategoriesResponse, error) {
+	categories, err := s.categories.GetCategories(req.GetIncludeArchived())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &kasirpb.ListCategoriesResponse{Categories: toCategories(categories)}, nil
+}
+
+func (s *categoryServer) GetCategory(ctx context.Context, req *kasirpb.GetCategoryRequest) (*kasirpb.Category, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	category, err := s.categories.GetCategoryByID(id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if category.ID == "" {
+		return nil, status.Error(codes.NotFound, "Category not found")
+	}
+	return toCategory(category), nil
+}
+
+func (s *categoryServer) CreateCategory(ctx context.Context, req *kasirpb.CreateCategoryRequest) (*kasirpb.Category, error) {
+	category := fromCategory(req.GetCategory())
+	err := internal.Validate(&category)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	category, err = s.categories.CreateCategory(actorFromContext(ctx), category)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toCategory(category), nil
+}
+
+func (s *categoryServer) UpdateCategory(ctx context.Context, req *kasirpb.UpdateCategoryRequest) (*kasirpb.Category, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	category := fromCategory(req.GetCategory())
+	err = internal.Validate(&category)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	category, err = s.categories.UpdateCategoryByID(actorFromContext(ctx), id, category, ifMatch(req.GetIfMatch()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if category.ID == "" {
+		return nil, status.Error(codes.NotFound, "Category not found")
+	}
+	return toCategory(category), nil
+}
+
+func (s *categoryServer) DeleteCategory(ctx context.Context, req *kasirpb.DeleteCategoryRequest) (*kasirpb.Category, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	category, err := s.categories.DeleteCategoryByID(actorFromContext(ctx), id, ifMatch(req.GetIfMatch()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if category.ID == "" {
+		return nil, status.Error(codes.NotFound, "Category not found")
+	}
+	return toCategory(category), nil
+}
+
+func (s *categoryServer) RestoreCategory(ctx context.Context, req *kasirpb.RestoreCategoryRequest) (*kasirpb.Category, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	category, err := s.categories.RestoreCategoryByID(actorFromContext(ctx), id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if category.ID == "" {
+		return nil, status.Error(codes.NotFound, "Archived category not found")
+	}
+	return toCategory(category), nil
+}
+
+// ListCategoryProducts lists the live products of a category. The repository
+// returns no row for an empty category, so its existence is checked apart.
+func (s *categoryServer) ListCategoryProducts(ctx context.Context, req *kasirpb.ListCategoryProductsRequest) (*kasirpb.ListProductsResponse, error) {
+	id, err := parseID("category_id", req.GetCategoryId())
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categories.GetProductsByCategoryID(id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	response := &kasirpb.ListProductsResponse{Products: []*kasirpb.Product{}}
+	if len(categories) == 0 {
+		category, err := s.categories.GetCategoryByID(id)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		if category.ID == "" {
+			return nil, status.Error(codes.NotFound, "Category not found")
+		}
+		return response, nil
+	}
+	for _, product := range categories[0].Products {
+		response.Products = append(response.Products, toProduct(product.Product))
+	}
+	return response, nil
+}
@@ -0,0 +1,197 @@
+package grpcserver
+
+import (
+	"kasir-api/models"
+	"kasir-api/proto/kasirpb"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toProduct(product models.Product) *kasirpb.Product {
+	return &kasirpb.Product{
+		Id:         product.ID,
+		Name:       product.Name,
+		Sku:        product.SKU,
+		Barcode:    product.Barcode,
+		Price:      product.Price,
+		Cost:       product.Cost,
+		Stock:      int32(product.Stock),
+		Version:    product.Version,
+		CreatedAt:  timestamp(product.CreatedAt),
+		DeletedAt:  optionalTimestamp(product.DeletedAt),
+		Categories: toCategories(product.Categories),
+	}
+}
+
+func toProducts(products []models.Product) []*kasirpb.Product {
+	result := make([]*kasirpb.Product, 0, len(products))
+	for _, product := range products {
+		result = append(result, toProduct(product))
+	}
+	return result
+}
+
+// fromProduct reads the writable fields of a product.
+func fromProduct(product *kasirpb.Product) models.Product {
+	return models.Product{
+		Name:    product.GetName(),
+		SKU:     product.GetSku(),
+		Barcode: product.GetBarcode(),
+		Price:   product.GetPrice(),
+		Cost:    product.GetCost(),
+		Stock:   int(product.GetStock()),
+	}
+}
+
+func toCategory(category models.Category) *kasirpb.Category {
+	return &kasirpb.Category{
+		Id:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+		Version:     category.Version,
+		CreatedAt:   timestamp(category.CreatedAt),
+		DeletedAt:   optionalTimestamp(category.DeletedAt),
+	}
+}
+
+func toCategories(categories []models.Category) []*kasirpb.Category {
+	result := make([]*kasirpb.Category, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, toCategory(category))
+	}
+	return result
+}
+
+func fromCategory(category *kasirpb.Category) models.Category {
+	return models.Category{
+		Name:        category.GetName(),
+		Description: category.GetDescription(),
+	}
+}
+
+func toTransaction(transaction models.Transaction) *kasirpb.Transaction {
+	details := make([]*kasirpb.TransactionDetail, 0, len(transaction.Details))
+	for _, detail := range transaction.Details {
+		details = append(details, &kasirpb.TransactionDetail{
+			Id:            detail.ID,
+			TransactionId: detail.TransactionID,
+			ProductId:     detail.ProductID,
+			ProductName:   detail.ProductName,
+			Quantity:      int32(detail.Quantity),
+			Subtotal:      detail.Subtotal,
+			Price:         detail.Price,
+			LotId:         detail.LotID,
+			SerialNumber:  detail.SerialNumber,
+			PriceListId:   detail.PriceListID,
+			CreatedAt:     timestamp(detail.CreatedAt),
+		})
+	}
+	return &kasirpb.Transaction{
+		Id:          transaction.ID,
+		TotalAmount: transaction.TotalAmount,
+		CustomerId:  transaction.CustomerID,
+		CreatedAt:   timestamp(transaction.CreatedAt),
+		Details:     details,
+	}
+}
+
+func fromCheckout(request *kasirpb.CheckoutRequest) models.CheckoutRequest {
+	checkout := models.CheckoutRequest{CustomerID: request.GetCustomerId()}
+	for _, item := range request.GetItems() {
+		checkout.Items = append(checkout.Items, models.CheckoutItem{
+			ProductID:     item.GetProductId(),
+			Quantity:      int(item.GetQuantity()),
+			SerialNumbers: item.GetSerialNumbers(),
+		})
+	}
+	return checkout
+}
+
+func toDayTotals(totals models.DayTotals) *kasirpb.DayTotals {
+	return &kasirpb.DayTotals{
+		Date:             totals.Date,
+		Revenue:          totals.Revenue,
+		Quantity:         totals.Quantity,
+		TransactionCount: totals.TransactionCount,
+	}
+}
+
+// toEvent converts an event of the broker, whose data is one of the event
+// models, and reports false for data it does not know.
+func toEvent(event models.Event) (*kasirpb.Event, bool) {
+	result := &kasirpb.Event{Id: event.ID, Type: event.Type, CreatedAt: timestamp(event.CreatedAt)}
+	switch data := event.Data.(type) {
+	case models.CheckoutEvent:
+		result.Data = &kasirpb.Event_Checkout{Checkout: &kasirpb.CheckoutEvent{
+			Transaction: toTransaction(data.Transaction),
+			Today:       toDayTotals(data.Today),
+		}}
+	case models.LowStockEvent:
+		result.Data = &kasirpb.Event_LowStock{LowStock: &kasirpb.LowStockEvent{
+			ProductId:   data.ProductID,
+			ProductName: data.ProductName,
+			Stock:       int32(data.Stock),
+			Threshold:   int32(data.Threshold),
+		}}
+	case models.DayTotals:
+		result.Data = &kasirpb.Event_Totals{Totals: toDayTotals(data)}
+	default:
+		return nil, false
+	}
+	return result, true
+}
+
+func toReport(report models.Report) *kasirpb.Report {
+	return &kasirpb.Report{
+		TotalRevenue:      report.TotalRevenue,
+		TotalTransactions: report.TotalTransactions,
+		BestSeller: &kasirpb.ReportBestSeller{
+			ProductId:   report.BestSeller.ProductID,
+			ProductName: report.BestSeller.ProductName,
+			Quantity:    report.BestSeller.Quantity,
+			TotalAmount: report.BestSeller.TotalAmount,
+		},
+	}
+}
+
+func toSalesReportRow(row models.SalesReportRow) *kasirpb.SalesReportRow {
+	return &kasirpb.SalesReportRow{
+		Key:              row.Key,
+		Label:            row.Label,
+		Revenue:          row.Revenue,
+		Quantity:         row.Quantity,
+		TransactionCount: row.TransactionCount,
+		AverageBasket:    row.AverageBasket,
+	}
+}
+
+func toProductPerformances(products []models.ProductPerformance) []*kasirpb.ProductPerformance {
+	result := make([]*kasirpb.ProductPerformance, 0, len(products))
+	for _, product := range products {
+		result = append(result, &kasirpb.ProductPerformance{
+			ProductId:   product.ProductID,
+			ProductName: product.ProductName,
+			Stock:       int32(product.Stock),
+			Quantity:    product.Quantity,
+			Revenue:     product.Revenue,
+			Cost:        product.Cost,
+			Margin:      product.Margin,
+		})
+	}
+	return result
+}
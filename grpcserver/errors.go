@@ -0,0 +1,60 @@
+package grpcserver
+
+import (
+	"errors"
+	apperrors "kasir-api/internal/errors"
+	"log"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// toStatus maps the typed errors of internal/errors onto gRPC codes, the
+// counterpart of internal.WriteError. Invalid fields travel as a BadRequest
+// detail. Anything else is logged and reported as a bare INTERNAL.
+func toStatus(err error) error {
+	var notFound *apperrors.NotFoundError
+	var validation *apperrors.ValidationError
+	var stock *apperrors.InsufficientStockError
+	var conflict *apperrors.ConflictError
+	var duplicate *apperrors.DuplicateError
+	var version *apperrors.VersionMismatchError
+	var badRequest *apperrors.BadRequestError
+	switch {
+	case errors.As(err, &notFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &validation):
+		detail := &errdetails.BadRequest{}
+		for _, field := range validation.Fields {
+			detail.FieldViolations = append(detail.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+				Reason:      field.Code,
+			})
+		}
+		return withDetail(codes.InvalidArgument, "The request has invalid fields", detail)
+	case errors.As(err, &stock):
+		return withDetail(codes.FailedPrecondition, err.Error(), &errdetails.ErrorInfo{Reason: "insufficient_stock"})
+	case errors.As(err, &conflict):
+		return withDetail(codes.FailedPrecondition, err.Error(), &errdetails.ErrorInfo{Reason: conflict.Code})
+	case errors.As(err, &duplicate):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.As(err, &version):
+		return withDetail(codes.FailedPrecondition, err.Error(), &errdetails.ErrorInfo{Reason: "version_mismatch"})
+	case errors.As(err, &badRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		log.Println(err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+}
+
+func withDetail(code codes.Code, message string, detail protoadapt.MessageV1) error {
+	st, err := status.New(code, message).WithDetails(detail)
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
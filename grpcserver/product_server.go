@@ -0,0 +1,176 @@
+package grpcserver
+
+import (
+	"context"
+	"kasir-api/internal"
+	"kasir-api/proto/kasirpb"
+	"kasir-api/services"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type productServer struct {
+	kasirpb.UnimplementedProductServiceServer
+	products *services.ProductService
+}
+
+func (s *productServer) ListProducts(ctx context.Context, req *kasirpb.ListProductsRequest) (*kasirpb.ListProductsResponse, error) {
+	products, err := s.products.GetProducts(req.GetName(), req.GetIncludeArchived())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &kasirpb.ListProductsResponse{Products: toProducts(products)}, nil
+}
+
+func (s *productServer) GetProduct(ctx context.Context, req *kasirpb.GetProductRequest) (*kasirpb.Product, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.products.GetProductByID(id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if product.ID == "" {
+		return nil, status.Error(codes.NotFound, "Product not found")
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) CreateProduct(ctx context.Context, req *kasirpb.CreateProductRequest) (*kasirpb.Product, error) {
+	product := fromProduct(req.GetProduct())
+	err := internal.Validate(&product)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	product, err = s.products.CreateProduct(actorFromContext(ctx), product)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) UpdateProduct(ctx context.Context, req *kasirpb.UpdateProductRequest) (*kasirpb.Product, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	product := fromProduct(req.GetProduct())
+	err = internal.Validate(&product)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	product, err = s.products.UpdateProductByID(actorFromContext(ctx), id, product, ifMatch(req.GetIfMatch()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if product.ID == "" {
+		return nil, status.Error(codes.NotFound, "Product not found")
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) DeleteProduct(ctx context.Context, req *kasirpb.DeleteProductRequest) (*kasirpb.Product, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.products.DeleteProductByID(actorFromContext(ctx), id, ifMatch(req.GetIfMatch()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if product.ID == "" {
+		return nil, status.Error(codes.NotFound, "Product not found")
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) RestoreProduct(ctx context.Context, req *kasirpb.RestoreProductRequest) (*kasirpb.Product, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.products.RestoreProductByID(actorFromContext(ctx), id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if product.ID == "" {
+		return nil, status.Error(codes.NotFound, "Archived product not found")
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) ListProductCategories(ctx context.Context, req *kasirpb.ListProductCategoriesRequest) (*kasirpb.ListCategoriesResponse, error) {
+	id, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.products.GetProductByID(id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if product.ID == "" {
+		return nil, status.Error(codes.NotFound, "Product not found")
+	}
+	categories, err := s.products.GetCategoriesByProductID(id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &kasirpb.ListCategoriesResponse{Categories: toCategories(categories)}, nil
+}
+
+func (s *productServer) AddProductCategory(ctx context.Context, req *kasirpb.ProductCategoryRequest) (*kasirpb.ProductCategoryResponse, error) {
+	productID, categoryID, err := parseProductCategory(req)
+	if err != nil {
+		return nil, err
+	}
+	err = s.products.AddCategoryToProduct(actorFromContext(ctx), productID, categoryID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &kasirpb.ProductCategoryResponse{}, nil
+}
+
+func (s *productServer) RemoveProductCategory(ctx context.Context, req *kasirpb.ProductCategoryRequest) (*kasirpb.ProductCategoryResponse, error) {
+	productID, categoryID, err := parseProductCategory(req)
+	if err != nil {
+		return nil, err
+	}
+	err = s.products.RemoveCategoryFromProduct(actorFromContext(ctx), productID, categoryID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &kasirpb.ProductCategoryResponse{}, nil
+}
+
+func parseProductCategory(req *kasirpb.ProductCategoryRequest) (string, string, error) {
+	productID, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return "", "", err
+	}
+	categoryID, err := parseID("category_id", req.GetCategoryId())
+	if err != nil {
+		return "", "", err
+	}
+	return productID, categoryID, nil
+}
+
+// parseID checks that value is a uuid and returns it in canonical form.
+func parseID(field, value string) (string, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, "Invalid "+field)
+	}
+	return id.String(), nil
+}
+
+// ifMatch turns the optional version of a request into the list taken by the
+// services, where nil accepts any version.
+func ifMatch(version int64) []int64 {
+	if version == 0 {
+		return nil
+	}
+	return []int64{version}
+}
@@ -0,0 +1,162 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/proto/kasirpb"
+	"kasir-api/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The limits match the query parameters of the REST report endpoints.
+const (
+	defaultRankingLimit  = 10
+	maxRankingLimit      = 100
+	defaultDeadStockDays = 90
+	maxDeadStockDays     = 3650
+)
+
+type reportServer struct {
+	kasirpb.UnimplementedReportServiceServer
+	reports *services.ReportService
+}
+
+func (s *reportServer) GetReport(ctx context.Context, req *kasirpb.GetReportRequest) (*kasirpb.Report, error) {
+	dateRange, err := parseDateRange(req.GetRange())
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.reports.GetReportsRange(dateRange)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toReport(report), nil
+}
+
+func (s *reportServer) GetReportToday(ctx context.Context, req *kasirpb.GetReportTodayRequest) (*kasirpb.Report, error) {
+	report, err := s.reports.GetReportToday()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toReport(report), nil
+}
+
+func (s *reportServer) GetSalesReport(ctx context.Context, req *kasirpb.GetSalesReportRequest) (*kasirpb.SalesReport, error) {
+	groupBy := req.GetGroupBy()
+	if groupBy == "" {
+		groupBy = models.GroupByDay
+	}
+	switch groupBy {
+	case models.GroupByHour, models.GroupByDay, models.GroupByWeek, models.GroupByMonth, models.GroupByProduct, models.GroupByCategory:
+	case models.GroupByCashier:
+		return nil, status.Error(codes.InvalidArgument, "Grouping by cashier is not available, transactions do not record a cashier yet")
+	default:
+		return nil, status.Error(codes.InvalidArgument, "Invalid group_by, expected hour, day, week, month, product or category")
+	}
+
+	dateRange, err := parseDateRange(req.GetRange())
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.reports.GetSalesReport(groupBy, dateRange)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &kasirpb.SalesReport{
+		GroupBy: report.GroupBy,
+		From:    req.GetRange().GetFrom(),
+		To:      req.GetRange().GetTo(),
+		Totals:  toSalesReportRow(report.Totals),
+		Rows:    make([]*kasirpb.SalesReportRow, 0, len(report.Rows)),
+	}
+	for _, row := range report.Rows {
+		response.Rows = append(response.Rows, toSalesReportRow(row))
+	}
+	return response, nil
+}
+
+func (s *reportServer) GetProductRanking(ctx context.Context, req *kasirpb.GetProductRankingRequest) (*kasirpb.ProductRankingReport, error) {
+	metric := req.GetMetric()
+	if metric == "" {
+		metric = models.RankByQuantity
+	}
+	if metric != models.RankByQuantity && metric != models.RankByRevenue && metric != models.RankByMargin {
+		return nil, status.Error(codes.InvalidArgument, "Invalid metric, expected quantity, revenue or margin")
+	}
+
+	top, ok := rankingLimit(req.GetTop())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid top, expected 0 to %d", maxRankingLimit))
+	}
+	bottom, ok := rankingLimit(req.GetBottom())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid bottom, expected 0 to %d", maxRankingLimit))
+	}
+
+	dateRange, err := parseDateRange(req.GetRange())
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.reports.GetProductRanking(metric, top, bottom, dateRange)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &kasirpb.ProductRankingReport{
+		Metric: report.Metric,
+		From:   req.GetRange().GetFrom(),
+		To:     req.GetRange().GetTo(),
+		Top:    toProductPerformances(report.Top),
+		Bottom: toProductPerformances(report.Bottom),
+	}, nil
+}
+
+func (s *reportServer) GetDeadStock(ctx context.Context, req *kasirpb.GetDeadStockRequest) (*kasirpb.DeadStockReport, error) {
+	days := int(req.GetDays())
+	if days == 0 {
+		days = defaultDeadStockDays
+	}
+	if days < 0 || days > maxDeadStockDays {
+		return nil, status.Error(codes.InvalidArgument, "Invalid days")
+	}
+
+	report, err := s.reports.GetDeadStock(days)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	response := &kasirpb.DeadStockReport{
+		Days:                int32(report.Days),
+		Since:               timestamp(report.Since),
+		TotalInventoryCost:  report.TotalInventoryCost,
+		TotalInventoryValue: report.TotalInventoryValue,
+		Items:               make([]*kasirpb.DeadStockItem, 0, len(report.Items)),
+	}
+	for _, item := range report.Items {
+		response.Items = append(response.Items, &kasirpb.DeadStockItem{
+			ProductId:      item.ProductID,
+			ProductName:    item.ProductName,
+			Stock:          int32(item.Stock),
+			Cost:           item.Cost,
+			Price:          item.Price,
+			InventoryCost:  item.InventoryCost,
+			InventoryValue: item.InventoryValue,
+			LastSoldAt:     optionalTimestamp(item.LastSoldAt),
+		})
+	}
+	return response, nil
+}
+
+// rankingLimit applies the default of 10 to an unset top or bottom, proto3
+// not telling zero apart from absent.
+func rankingLimit(limit int32) (int, bool) {
+	if limit == 0 {
+		return defaultRankingLimit, true
+	}
+	if limit < 0 || limit > maxRankingLimit {
+		return 0, false
+	}
+	return int(limit), true
+}
@@ -0,0 +1,122 @@
+// Package grpcserver serves the gRPC API of proto/kasir.proto over the same
+// services as the REST handlers.
+package grpcserver
+
+//go:generate sh -c "cd ../proto && buf generate"
+
+import (
+	"context"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/proto/kasirpb"
+	"kasir-api/services"
+	"log"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type Services struct {
+	Products     *services.ProductService
+	Categories   *services.CategoryService
+	Transactions *services.TransactionService
+	Reports      *services.ReportService
+	Events       *internal.EventBroker
+}
+
+// New returns a server with every service registered and reflection enabled,
+// so tools such as grpcurl can list and call them.
+func New(s Services) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryLogging),
+		grpc.ChainStreamInterceptor(streamLogging),
+	)
+	kasirpb.RegisterProductServiceServer(server, &productServer{products: s.Products})
+	kasirpb.RegisterCategoryServiceServer(server, &categoryServer{categories: s.Categories})
+	kasirpb.RegisterTransactionServiceServer(server, &transactionServer{transactions: s.Transactions, events: s.Events})
+	kasirpb.RegisterReportServiceServer(server, &reportServer{reports: s.Reports})
+	reflection.Register(server)
+	return server
+}
+
+type contextKey string
+
+const requestIDKey contextKey = "request_id"
+
+// withRequestID tags the call with the id from its x-request-id metadata, or
+// a new one, and echoes it in the response header.
+func withRequestID(ctx context.Context) context.Context {
+	id := metadataValue(ctx, "x-request-id")
+	if id == "" || len(id) > 128 {
+		id = uuid.NewString()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", id))
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// actorFromContext identifies the caller for the audit log like
+// internal.ActorFromRequest, reading the name from x-actor metadata.
+func actorFromContext(ctx context.Context) models.Actor {
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	name := metadataValue(ctx, "x-actor")
+	if name == "" {
+		name = "anonymous"
+	}
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return models.Actor{ClaimedName: name, RequestID: requestID, SourceIP: ip}
+}
+
+func unaryLogging(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	ctx = withRequestID(ctx)
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	log.Printf("[GRPC REQUEST] %s %s", requestID, info.FullMethod)
+	resp, err := handler(ctx, req)
+	log.Printf("[GRPC RESPONSE] %s %s %s %s", requestID, info.FullMethod, status.Code(err), time.Since(start))
+	return resp, err
+}
+
+func streamLogging(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx := withRequestID(stream.Context())
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	log.Printf("[GRPC REQUEST] %s %s", requestID, info.FullMethod)
+	err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	log.Printf("[GRPC RESPONSE] %s %s %s %s", requestID, info.FullMethod, status.Code(err), time.Since(start))
+	return err
+}
+
+// contextStream replaces the context of a stream with one carrying the
+// request id.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,120 @@
+package grpcserver
+
+import (
+	"context"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/proto/kasirpb"
+	"kasir-api/services"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type transactionServer struct {
+	kasirpb.UnimplementedTransactionServiceServer
+	transactions *services.TransactionService
+	events       *internal.EventBroker
+}
+
+func (s *transactionServer) Checkout(ctx context.Context, req *kasirpb.CheckoutRequest) (*kasirpb.Transaction, error) {
+	checkout := fromCheckout(req)
+	err := internal.Validate(&checkout)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	transaction, err := s.transactions.Checkout(actorFromContext(ctx), checkout)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTransaction(*transaction), nil
+}
+
+func (s *transactionServer) ListTransactions(ctx context.Context, req *kasirpb.ListTransactionsRequest) (*kasirpb.ListTransactionsResponse, error) {
+	var transactions []models.Transaction
+	var err error
+	if req.GetRange().GetFrom() == "" && req.GetRange().GetTo() == "" {
+		transactions, err = s.transactions.GetTransactions()
+	} else {
+		var dateRange models.DateRange
+		dateRange, err = parseDateRange(req.GetRange())
+		if err != nil {
+			return nil, err
+		}
+		transactions, err = s.transactions.GetTransactionsRange(dateRange)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &kasirpb.ListTransactionsResponse{Transactions: make([]*kasirpb.Transaction, 0, len(transactions))}
+	for _, transaction := range transactions {
+		response.Transactions = append(response.Transactions, toTransaction(transaction))
+	}
+	return response, nil
+}
+
+// StreamEvents follows the event broker like the /api/events handler: today's
+// totals first, then the missed events after last_event_id and the live ones.
+// A subscriber that falls behind is dropped and resumes with its last id.
+func (s *transactionServer) StreamEvents(req *kasirpb.StreamEventsRequest, stream grpc.ServerStreamingServer[kasirpb.Event]) error {
+	var types map[string]bool
+	if len(req.GetTypes()) > 0 {
+		types = make(map[string]bool)
+		for _, eventType := range req.GetTypes() {
+			types[eventType] = true
+		}
+	}
+	send := func(event models.Event) error {
+		if types != nil && !types[event.Type] {
+			return nil
+		}
+		message, ok := toEvent(event)
+		if !ok {
+			return nil
+		}
+		return stream.Send(message)
+	}
+
+	today, err := s.transactions.GetTodayTotals()
+	if err != nil {
+		return toStatus(err)
+	}
+	missed, live, cancel := s.events.Subscribe(req.GetLastEventId())
+	defer cancel()
+
+	err = send(models.Event{Type: models.EventTotals, Data: today, CreatedAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	for _, event := range missed {
+		err = send(event)
+		if err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-live:
+			if !ok {
+				return nil
+			}
+			err = send(event)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func parseDateRange(dateRange *kasirpb.DateRange) (models.DateRange, error) {
+	result, err := internal.ParseDateRange(dateRange.GetFrom(), dateRange.GetTo())
+	if err != nil {
+		return models.DateRange{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return result, nil
+}
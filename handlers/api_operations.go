@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"kasir-api/internal"
+	"kasir-api/models"
+	"net/http"
+	"strconv"
+)
+
+// APIOperations documents every route registered in main.go for the OpenAPI
+// document. Keep it next to the handlers: a route missing here is logged at
+// startup, a changed body type shows up through the generated schemas.
+var APIOperations = []internal.APIOperation{
+	{ID: "getHealth", Method: http.MethodGet, Path: "/healthz", Tag: "health", Summary: "Check that the API and its database are up",
+		Response: map[string]string{}},
+	{ID: "getOpenAPI", Method: http.MethodGet, Path: "/openapi.json", Tag: "docs", Summary: "Get this OpenAPI document",
+		Response: map[string]any{}},
+	{ID: "getDocs", Method: http.MethodGet, Path: "/docs", Tag: "docs", Summary: "Browse this document in Swagger UI",
+		ResponseTypes: []string{"text/html"}},
+	{ID: "getDocsAsset", Method: http.MethodGet, Path: "/docs/{file}", Tag: "docs", Summary: "Get a file of the vendored Swagger UI",
+		Parameters: []internal.APIParameter{
+			{Name: "file", In: "path", Type: "string", Description: "Name of the file", Enum: DocsAssets},
+		},
+		ResponseTypes: []string{"text/css", "text/javascript"}},
+	{ID: "getGraphQL", Method: http.MethodGet, Path: "/graphql", Tag: "graphql", Summary: "Run a read-only GraphQL query, the schema is available by introspection",
+		Parameters: []internal.APIParameter{
+			{Name: "query", In: "query", Type: "string", Description: "The GraphQL query", Required: true},
+			queryParam("variables", "string", "The variables of the query as a JSON object"),
+			queryParam("operationName", "string", "The operation to run when the query has several"),
+		},
+		Response: models.GraphQLResponse{}, ErrorResponse: models.GraphQLResponse{}, Errors: []int{http.StatusUnprocessableEntity}},
+	{ID: "postGraphQL", Method: http.MethodPost, Path: "/graphql", Tag: "graphql", Summary: "Run a read-only GraphQL query sent as a JSON body",
+		Request: models.GraphQLRequest{}, Response: models.GraphQLResponse{}, ErrorResponse: models.GraphQLResponse{},
+		Errors: []int{http.StatusUnprocessableEntity}},
+
+	{ID: "listProducts", Method: http.MethodGet, Path: "/api/products", Tag: "products", Summary: "List products",
+		Parameters: []internal.APIParameter{
+			queryParam("name", "string", "Only products whose name contains this text"),
+			includeArchivedParam,
+		},
+		Response: []models.Product{}},
+	{ID: "createProduct", Method: http.MethodPost, Path: "/api/products", Tag: "products", Summary: "Create a product",
+		Request: models.Product{}, Status: http.StatusCreated, Response: models.Product{}, Versioned: true,
+		Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+	{ID: "importProducts", Method: http.MethodPost, Path: "/api/products/import", Tag: "products",
+		Summary: "Import products from a csv or xlsx file, sent raw or as the file field of a multipart form; rejected rows answer 422 with the same body",
+		Parameters: []internal.APIParameter{
+			queryParam("dry_run", "boolean", "Validate the file without saving anything"),
+		},
+		RequestTypes: []string{"text/csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "multipart/form-data"},
+		Response:     models.ProductImportResult{},
+		Errors:       []int{http.StatusUnsupportedMediaType}},
+	{ID: "getProduct", Method: http.MethodGet, Path: "/api/products/{id}", Tag: "products", Summary: "Get a product",
+		Response: models.Product{}, Versioned: true},
+	{ID: "replaceProduct", Method: http.MethodPut, Path: "/api/products/{id}", Tag: "products", Summary: "Replace a product",
+		Request: models.Product{}, Response: models.Product{}, Versioned: true,
+		Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+	{ID: "patchProduct", Method: http.MethodPatch, Path: "/api/products/{id}", Tag: "products", Summary: "Change some fields of a product with a JSON merge patch",
+		Request: models.Product{}, RequestTypes: mergePatchTypes, Response: models.Product{}, Versioned: true,
+		Errors: []int{http.StatusConflict, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity}},
+	{ID: "archiveProduct", Method: http.MethodDelete, Path: "/api/products/{id}", Tag: "products", Summary: "Archive a product",
+		Response: models.Product{}, Versioned: true},
+	{ID: "listProductCategories", Method: http.MethodGet, Path: "/api/products/{id}/categories", Tag: "products", Summary: "List the categories of a product",
+		Response: []models.Category{}},
+	{ID: "addProductCategory", Method: http.MethodPost, Path: "/api/products/{id}/categories", Tag: "products", Summary: "Add a product to a category",
+		Request: models.ProductCategoryRequest{}, Status: http.StatusCreated, Response: map[string]string{},
+		Errors: []int{http.StatusConflict}},
+	{ID: "removeProductCategory", Method: http.MethodDelete, Path: "/api/products/{id}/categories", Tag: "products", Summary: "Remove a product from a category",
+		Request: models.ProductCategoryRequest{}, Response: map[string]string{}},
+	{ID: "restoreProduct", Method: http.MethodPost, Path: "/api/products/{id}/restore", Tag: "products", Summary: "Restore an archived product",
+		Response: models.Product{}, Versioned: true,
+		Errors: []int{http.StatusConflict}},
+	{ID: "listProductLots", Method: http.MethodGet, Path: "/api/products/{id}/lots", Tag: "inventory", Summary: "List the lots of a product",
+		Response: []models.ProductLot{}},
+	{ID: "receiveLot", Method: http.MethodPost, Path: "/api/products/{id}/lots", Tag: "inventory", Summary: "Receive a lot of a product, adding to the lot when its number was received before",
+		Request: models.ProductLot{}, Status: http.StatusCreated, Response: models.ProductLot{},
+		Errors: []int{http.StatusConflict}},
+	{ID: "listProductSerials", Method: http.MethodGet, Path: "/api/products/{id}/serials", Tag: "inventory", Summary: "List the serial numbers of a product",
+		Parameters: []internal.APIParameter{
+			enumParam("status", "Only serials in this status", models.SerialStatusInStock, models.SerialStatusSold),
+		},
+		Response: []models.ProductSerial{}},
+	{ID: "receiveSerials", Method: http.MethodPost, Path: "/api/products/{id}/serials", Tag: "inventory", Summary: "Receive serial numbers of a product",
+		Request: models.ReceiveSerialsRequest{}, Status: http.StatusCreated, Response: []models.ProductSerial{},
+		Errors: []int{http.StatusConflict}},
+	{ID: "listProductPrices", Method: http.MethodGet, Path: "/api/products/{id}/prices", Tag: "pricing", Summary: "List the price history of a product",
+		Response: []models.ProductPrice{}},
+	{ID: "schedulePrice", Method: http.MethodPost, Path: "/api/products/{id}/prices", Tag: "pricing", Summary: "Schedule a price change",
+		Request: models.SchedulePriceRequest{}, Status: http.StatusCreated, Response: models.ProductPrice{}},
+	{ID: "cancelScheduledPrice", Method: http.MethodDelete, Path: "/api/products/{id}/prices/{price_id}", Tag: "pricing", Summary: "Cancel a scheduled price change",
+		Response: models.ProductPrice{}},
+	{ID: "getPriceHistory", Method: http.MethodGet, Path: "/api/products/{id}/price-history", Tag: "pricing", Summary: "List the price history of a product",
+		Response: []models.ProductPrice{}},
+	{ID: "quotePrice", Method: http.MethodGet, Path: "/api/products/{id}/quote", Tag: "pricing", Summary: "Quote the price of a product for a customer and quantity",
+		Parameters: []internal.APIParameter{
+			{Name: "customer_id", In: "query", Type: "string", Format: "uuid", Description: "Customer whose group price lists apply"},
+			queryParam("quantity", "integer", "Quantity to price, 1 by default"),
+		},
+		Response: models.PriceQuote{}},
+	{ID: "getFrequentlyBoughtWith", Method: http.MethodGet, Path: "/api/products/{id}/frequently-bought-with", Tag: "reports", Summary: "List the products most often bought together with a product",
+		Parameters: basketParams, Response: models.FrequentlyBoughtWith{}},
+	{ID: "getProductForecast", Method: http.MethodGet, Path: "/api/products/{id}/forecast", Tag: "forecasts", Summary: "Get the demand forecast of a product",
+		Parameters: []internal.APIParameter{
+			queryParam("cover_days", "integer", "Days of stock to cover, "+strconv.Itoa(defaultCoverDays)+" by default"),
+			queryParam("days", "integer", "Days to forecast, "+strconv.Itoa(defaultForecastDays)+" by default"),
+		},
+		Response: models.DemandForecast{}},
+	{ID: "setLeadTime", Method: http.MethodPut, Path: "/api/products/{id}/lead-time", Tag: "forecasts", Summary: "Set the supplier lead time of a product",
+		Request: models.LeadTimeRequest{}, Response: models.LeadTimeRequest{}},
+
+	{ID: "listCategories", Method: http.MethodGet, Path: "/api/categories", Tag: "categories", Summary: "List categories",
+		Parameters: []internal.APIParameter{includeArchivedParam},
+		Response:   []models.Category{}},
+	{ID: "createCategory", Method: http.MethodPost, Path: "/api/categories", Tag: "categories", Summary: "Create a category",
+		Request: models.Category{}, Status: http.StatusCreated, Response: models.Category{}, Versioned: true,
+		Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+	{ID: "getCategory", Method: http.MethodGet, Path: "/api/categories/{id}", Tag: "categories", Summary: "Get a category",
+		Response: models.Category{}, Versioned: true},
+	{ID: "replaceCategory", Method: http.MethodPut, Path: "/api/categories/{id}", Tag: "categories", Summary: "Replace a category",
+		Request: models.Category{}, Response: models.Category{}, Versioned: true,
+		Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+	{ID: "patchCategory", Method: http.MethodPatch, Path: "/api/categories/{id}", Tag: "categories", Summary: "Change some fields of a category with a JSON merge patch",
+		Request: models.Category{}, RequestTypes: mergePatchTypes, Response: models.Category{}, Versioned: true,
+		Errors: []int{http.StatusConflict, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity}},
+	{ID: "archiveCategory", Method: http.MethodDelete, Path: "/api/categories/{id}", Tag: "categories", Summary: "Archive a category",
+		Response: models.Category{}, Versioned: true},
+	{ID: "listCategoryProducts", Method: http.MethodGet, Path: "/api/categories/{id}/products", Tag: "categories", Summary: "List the products of a category",
+		Response: []models.CategoryWithProducts{}},
+	{ID: "restoreCategory", Method: http.MethodPost, Path: "/api/categories/{id}/restore", Tag: "categories", Summary: "Restore an archived category",
+		Response: models.Category{}, Versioned: true,
+		Errors: []int{http.StatusConflict}},
+
+	{ID: "getSerialHistory", Method: http.MethodGet, Path: "/api/serials/{serial}", Tag: "inventory", Summary: "Get the history of a serial number",
+		Parameters: []internal.APIParameter{{Name: "serial", In: "path", Type: "string"}},
+		Response:   models.SerialHistory{}},
+
+	{ID: "listPriceLists", Method: http.MethodGet, Path: "/api/price-lists", Tag: "pricing", Summary: "List price lists",
+		Response: []models.PriceList{}},
+	{ID: "createPriceList", Method: http.MethodPost, Path: "/api/price-lists", Tag: "pricing", Summary: "Create a price list",
+		Request: models.PriceList{}, Status: http.StatusCreated, Response: models.PriceList{},
+		Errors: []int{http.StatusConflict}},
+	{ID: "getPriceList", Method: http.MethodGet, Path: "/api/price-lists/{id}", Tag: "pricing", Summary: "Get a price list",
+		Response: models.PriceList{}},
+	{ID: "replacePriceList", Method: http.MethodPut, Path: "/api/price-lists/{id}", Tag: "pricing", Summary: "Replace a price list",
+		Request: models.PriceList{}, Response: models.PriceList{},
+		Errors: []int{http.StatusConflict}},
+	{ID: "deletePriceList", Method: http.MethodDelete, Path: "/api/price-lists/{id}", Tag: "pricing", Summary: "Delete a price list",
+		Response: models.PriceList{}},
+	{ID: "listPriceListItems", Method: http.MethodGet, Path: "/api/price-lists/{id}/items", Tag: "pricing", Summary: "List the items of a price list",
+		Response: []models.PriceListItem{}},
+	{ID: "setPriceListItem", Method: http.MethodPut, Path: "/api/price-lists/{id}/items", Tag: "pricing", Summary: "Set the price of a product in a price list",
+		Request: models.PriceListItem{}, Response: models.PriceListItem{}},
+	{ID: "deletePriceListItem", Method: http.MethodDelete, Path: "/api/price-lists/{id}/items", Tag: "pricing", Summary: "Remove the price of a product from a price list",
+		Request: models.PriceListItemKey{}, Response: models.PriceListItem{}},
+
+	{ID: "listCustomerGroups", Method: http.MethodGet, Path: "/api/customer-groups", Tag: "customers", Summary: "List customer groups",
+		Response: []models.CustomerGroup{}},
+	{ID: "createCustomerGroup", Method: http.MethodPost, Path: "/api/customer-groups", Tag: "customers", Summary: "Create a customer group",
+		Request: models.CustomerGroup{}, Status: http.StatusCreated, Response: models.CustomerGroup{},
+		Errors: []int{http.StatusConflict}},
+	{ID: "getCustomerGroup", Method: http.MethodGet, Path: "/api/customer-groups/{id}", Tag: "customers", Summary: "Get a customer group",
+		Response: models.CustomerGroup{}},
+	{ID: "replaceCustomerGroup", Method: http.MethodPut, Path: "/api/customer-groups/{id}", Tag: "customers", Summary: "Replace a customer group",
+		Request: models.CustomerGroup{}, Response: models.CustomerGroup{},
+		Errors: []int{http.StatusConflict}},
+	{ID: "deleteCustomerGroup", Method: http.MethodDelete, Path: "/api/customer-groups/{id}", Tag: "customers", Summary: "Delete a customer group",
+		Response: models.CustomerGroup{}},
+	{ID: "listCustomers", Method: http.MethodGet, Path: "/api/customers", Tag: "customers", Summary: "List customers",
+		Parameters: []internal.APIParameter{queryParam("name", "string", "Only customers whose name contains this text")},
+		Response:   []models.Customer{}},
+	{ID: "createCustomer", Method: http.MethodPost, Path: "/api/customers", Tag: "customers", Summary: "Create a customer",
+		Request: models.Customer{}, Status: http.StatusCreated, Response: models.Customer{}},
+	{ID: "getCustomer", Method: http.MethodGet, Path: "/api/customers/{id}", Tag: "customers", Summary: "Get a customer",
+		Response: models.Customer{}},
+	{ID: "replaceCustomer", Method: http.MethodPut, Path: "/api/customers/{id}", Tag: "customers", Summary: "Replace a customer",
+		Request: models.Customer{}, Response: models.Customer{}},
+	{ID: "deleteCustomer", Method: http.MethodDelete, Path: "/api/customers/{id}", Tag: "customers", Summary: "Delete a customer",
+		Response: models.Customer{}},
+
+	{ID: "listForecasts", Method: http.MethodGet, Path: "/api/forecasts", Tag: "forecasts", Summary: "List the demand forecasts of all products",
+		Parameters: forecastListParams, Response: []models.DemandForecast{}},
+	{ID: "generateForecasts", Method: http.MethodPost, Path: "/api/forecasts", Tag: "forecasts", Summary: "Regenerate the demand forecasts now and list them",
+		Parameters: forecastListParams, Response: []models.DemandForecast{}},
+
+	{ID: "checkout", Method: http.MethodPost, Path: "/api/checkout", Tag: "transactions", Summary: "Sell products; a bare array of items is accepted as well",
+		Request: models.CheckoutRequest{}, Response: models.Transaction{},
+		Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+	{ID: "listTransactions", Method: http.MethodGet, Path: "/api/transactions", Tag: "transactions", Summary: "List transactions, all of them without a date range",
+		Parameters: dateRangeParams, Response: []models.Transaction{}},
+
+	{ID: "streamEvents", Method: http.MethodGet, Path: "/api/events", Tag: "events", Summary: "Stream checkout, low stock and daily total events as server-sent events",
+		Parameters: []internal.APIParameter{
+			queryParam("types", "string", "Comma separated event types to receive, all by default"),
+			queryParam("last_event_id", "integer", "Resume after this event, the Last-Event-ID header takes precedence"),
+			{Name: "Last-Event-ID", In: "header", Type: "string", Description: "Resume after this event"},
+		},
+		ResponseTypes: []string{"text/event-stream"}},
+
+	{ID: "listWebhooks", Method: http.MethodGet, Path: "/api/webhooks", Tag: "webhooks", Summary: "List webhook subscriptions",
+		Response: []models.WebhookSubscription{}},
+	{ID: "createWebhook", Method: http.MethodPost, Path: "/api/webhooks", Tag: "webhooks", Summary: "Subscribe to outbox events, the response is the only one carrying the secret",
+		Request: models.WebhookRequest{}, Status: http.StatusCreated, Response: models.WebhookSubscription{}},
+	{ID: "listWebhookDeliveries", Method: http.MethodGet, Path: "/api/webhooks/deliveries", Tag: "webhooks", Summary: "List webhook deliveries",
+		Parameters: []internal.APIParameter{
+			enumParam("status", "Only deliveries in this status, dead by default", models.DeliveryPending, models.DeliveryDelivered, models.DeliveryDead),
+			queryParam("limit", "integer", "Maximum number of deliveries, "+strconv.Itoa(defaultDeliveryLimit)+" by default"),
+		},
+		Response: []models.WebhookDelivery{}},
+	{ID: "retryWebhookDelivery", Method: http.MethodPost, Path: "/api/webhooks/deliveries/{id}/retry", Tag: "webhooks", Summary: "Send a delivery again",
+		Parameters: []internal.APIParameter{{Name: "id", In: "path", Type: "integer", Format: "int64"}},
+		Status:     http.StatusAccepted, Response: map[string]string{}},
+	{ID: "getWebhook", Method: http.MethodGet, Path: "/api/webhooks/{id}", Tag: "webhooks", Summary: "Get a webhook subscription",
+		Response: models.WebhookSubscription{}},
+	{ID: "replaceWebhook", Method: http.MethodPut, Path: "/api/webhooks/{id}", Tag: "webhooks", Summary: "Replace a webhook subscription",
+		Request: models.WebhookRequest{}, Response: models.WebhookSubscription{}},
+	{ID: "deleteWebhook", Method: http.MethodDelete, Path: "/api/webhooks/{id}", Tag: "webhooks", Summary: "Delete a webhook subscription",
+		Response: models.WebhookSubscription{}},
+
+	{ID: "listAuditLog", Method: http.MethodGet, Path: "/api/audit", Tag: "audit", Summary: "Search the audit log, newest first. claimed_actor is the unauthenticated X-Actor header of the change, source_ip the address of its connection",
+		Parameters: append([]internal.APIParameter{
+			queryParam("claimed_actor", "string", "Only changes made under this claimed X-Actor"),
+			enumParam("action", "Only changes of this kind", "insert", "update", "delete"),
+			queryParam("entity_type", "string", "Only changes to this table"),
+			queryParam("entity_id", "string", "Only changes to this row"),
+			queryParam("request_id", "string", "Only changes made by this request"),
+			queryParam("before_id", "integer", "Only entries older than this one, for paging"),
+			queryParam("limit", "integer", "Maximum number of entries, "+strconv.Itoa(defaultAuditLimit)+" by default"),
+		}, dateRangeParams...),
+		Response: []models.AuditEntry{}},
+	{ID: "verifyAuditLog", Method: http.MethodGet, Path: "/api/audit/verify", Tag: "audit", Summary: "Check the hash chain of the audit log",
+		Response: models.AuditVerification{}},
+
+	{ID: "exportProducts", Method: http.MethodGet, Path: "/api/export/products", Tag: "exports", Summary: "Export products",
+		Parameters: exportParams, ResponseTypes: exportTypes},
+	{ID: "exportTransactions", Method: http.MethodGet, Path: "/api/export/transactions", Tag: "exports", Summary: "Export transactions",
+		Parameters: exportParams, ResponseTypes: exportTypes},
+	{ID: "exportTransactionItems", Method: http.MethodGet, Path: "/api/export/transaction-items", Tag: "exports", Summary: "Export transaction items",
+		Parameters: exportParams, ResponseTypes: exportTypes},
+
+	{ID: "getReport", Method: http.MethodGet, Path: "/api/reports", Tag: "reports", Summary: "Summarise sales over a date range",
+		Parameters: dateRangeParams, Response: models.Report{}},
+	{ID: "getReportToday", Method: http.MethodGet, Path: "/api/reports/today", Tag: "reports", Summary: "Summarise today's sales",
+		Response: models.Report{}},
+	{ID: "getSalesReport", Method: http.MethodGet, Path: "/api/reports/sales", Tag: "reports", Summary: "Group sales by period, product or category",
+		Parameters: append([]internal.APIParameter{
+			enumParam("group_by", "Grouping, day by default", models.GroupByHour, models.GroupByDay, models.GroupByWeek, models.GroupByMonth, models.GroupByProduct, models.GroupByCategory),
+		}, dateRangeParams...),
+		Response: models.SalesReport{}},
+	{ID: "getProductRanking", Method: http.MethodGet, Path: "/api/reports/products", Tag: "reports", Summary: "Rank the best and worst selling products",
+		Parameters: append([]internal.APIParameter{
+			enumParam("metric", "Ranking metric, quantity by default", models.RankByQuantity, models.RankByRevenue, models.RankByMargin),
+			queryParam("top", "integer", "Number of best products, "+strconv.Itoa(defaultRankingLimit)+" by default"),
+			queryParam("bottom", "integer", "Number of worst products, "+strconv.Itoa(defaultRankingLimit)+" by default"),
+		}, dateRangeParams...),
+		Response: models.ProductRankingReport{}},
+	{ID: "getDeadStock", Method: http.MethodGet, Path: "/api/reports/dead-stock", Tag: "reports", Summary: "List stocked products that have not sold lately",
+		Parameters: []internal.APIParameter{
+			queryParam("days", "integer", "Days without a sale, "+strconv.Itoa(defaultDeadStockDays)+" by default"),
+		},
+		Response: models.DeadStockReport{}},
+	{ID: "getABCReport", Method: http.MethodGet, Path: "/api/reports/abc", Tag: "reports", Summary: "Classify products by their share of revenue",
+		Parameters: append([]internal.APIParameter{
+			queryParam("a", "number", "Cumulative revenue percentage of class A, "+strconv.Itoa(defaultABCThresholdA)+" by default"),
+			queryParam("b", "number", "Cumulative revenue percentage of class B, "+strconv.Itoa(defaultABCThresholdB)+" by default"),
+		}, dateRangeParams...),
+		Response: models.ABCReport{}},
+	{ID: "getBasketReport", Method: http.MethodGet, Path: "/api/reports/basket", Tag: "reports", Summary: "List the product pairs most often bought together",
+		Parameters: basketParams, Response: models.BasketReport{}},
+	{ID: "listExpiringLots", Method: http.MethodGet, Path: "/api/reports/expiring", Tag: "inventory", Summary: "List lots expiring soon",
+		Parameters: []internal.APIParameter{
+			queryParam("days", "integer", "Days ahead, "+strconv.Itoa(defaultExpiringDays)+" by default"),
+		},
+		Response: []models.ExpiringLot{}},
+}
+
+var (
+	includeArchivedParam = queryParam("include_archived", "boolean", "Include archived rows")
+
+	dateRangeParams = []internal.APIParameter{
+		{Name: "from", In: "query", Type: "string", Format: "date", Description: "First day, inclusive"},
+		{Name: "to", In: "query", Type: "string", Format: "date", Description: "Last day, inclusive"},
+	}
+
+	basketParams = append([]internal.APIParameter{
+		queryParam("min_count", "integer", "Minimum number of shared transactions, "+strconv.Itoa(defaultBasketMinCount)+" by default"),
+		queryParam("limit", "integer", "Maximum number of rows, "+strconv.Itoa(defaultRankingLimit)+" by default"),
+	}, dateRangeParams...)
+
+	forecastListParams = []internal.APIParameter{
+		queryParam("cover_days", "integer", "Days of stock to cover, "+strconv.Itoa(defaultCoverDays)+" by default"),
+		queryParam("reorder_only", "boolean", "Only products that need reordering"),
+	}
+
+	exportParams = append([]internal.APIParameter{
+		enumParam("format", "File format, csv by default", internal.FormatCSV, internal.FormatXLSX, internal.FormatJSONL),
+	}, dateRangeParams...)
+
+	exportTypes = []string{"text/csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/x-ndjson"}
+)
+
+func queryParam(name, schemaType, description string) internal.APIParameter {
+	return internal.APIParameter{Name: name, In: "query", Type: schemaType, Description: description}
+}
+
+func enumParam(name, description string, values ...string) internal.APIParameter {
+	return internal.APIParameter{Name: name, In: "query", Type: "string", Description: description, Enum: values}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"fmt"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type AuditHandler struct {
+	service *services.AuditService
+}
+
+func NewAuditHandler(service *services.AuditService) AuditHandler {
+	return AuditHandler{service: service}
+}
+
+// GetAuditLog lists audit entries newest first. Pass the last id of a page as
+// before_id to get the next one.
+func (h *AuditHandler) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	dateRange, err := internal.ParseDateRange(query.Get("from"), query.Get("to"))
+	if err != nil {
+		internal.HandleError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit, ok := queryInt(r, "limit", defaultAuditLimit, maxAuditLimit)
+	if !ok {
+		internal.HandleError(w, http.StatusBadRequest, fmt.Sprintf("Invalid limit, expected 0 to %d", maxAuditLimit))
+		return
+	}
+
+	filter := models.AuditFilter{
+		ClaimedActor: query.Get("claimed_actor"),
+		Action:       query.Get("action"),
+		EntityType:   query.Get("entity_type"),
+		EntityID:     query.Get("entity_id"),
+		RequestID:    query.Get("request_id"),
+		DateRange:    dateRange,
+		Limit:        limit,
+	}
+	if beforeID := query.Get("before_id"); beforeID != "" {
+		filter.BeforeID, err = strconv.ParseInt(beforeID, 10, 64)
+		if err != nil || filter.BeforeID <= 0 {
+			internal.HandleError(w, http.StatusBadRequest, "Invalid before_id")
+			return
+		}
+	}
+	if filter.Action != "" && filter.Action != "insert" && filter.Action != "update" && filter.Action != "delete" {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid action, expected insert, update or delete")
+		return
+	}
+
+	entries, err := h.service.GetAuditLog(filter)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, entries)
+}
+
+func (h *AuditHandler) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	verification, err := h.service.VerifyAuditLog()
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, verification)
+}
+
+func (h *AuditHandler) HandleAuditLog(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetAuditLog(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *AuditHandler) HandleVerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.VerifyAuditLog(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -1,13 +1,13 @@
 package handlers
 
 import (
-	"encoding/json"
-	"fmt"
+	"io"
 	"kasir-api/internal"
 	"kasir-api/models"
 	"kasir-api/services"
 	"log"
 	"net/http"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -22,30 +22,28 @@ func NewCategoryHandler(service *services.CategoryService) CategoryHandler {
 }
 
 func (h *CategoryHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
-	categories, err := h.service.GetCategories()
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+	categories, err := h.service.GetCategories(includeArchived)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		internal.WriteError(w, err)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(categories)
+	internal.HandleResponse(w, http.StatusOK, categories)
 }
 
 func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
 	var category models.Category
-	err := json.NewDecoder(r.Body).Decode(&category)
+	err := internal.DecodeJSON(r, &category)
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err))
+		internal.WriteError(w, err)
 		return
 	}
-	newCategory, err := h.service.CreateCategory(category)
+	newCategory, err := h.service.CreateCategory(internal.ActorFromRequest(r), category)
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusInternalServerError, fmt.Sprintf("Internal server error: %s", err))
+		internal.WriteError(w, err)
 		return
 	}
+	internal.SetETag(w, newCategory.Version)
 	internal.HandleResponse(w, http.StatusCreated, newCategory)
 }
 
@@ -59,8 +57,7 @@ func (h *CategoryHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request
 
 	category, err := h.service.GetCategoryByID(id.String())
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusInternalServerError, "Internal server error")
+		internal.WriteError(w, err)
 		return
 	}
 
@@ -69,6 +66,7 @@ func (h *CategoryHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	internal.SetETag(w, category.Version)
 	internal.HandleResponse(w, http.StatusOK, category)
 }
 
@@ -81,25 +79,82 @@ func (h *CategoryHandler) UpdateCategoryByID(w http.ResponseWriter, r *http.Requ
 	}
 
 	var category models.Category
-	err = json.NewDecoder(r.Body).Decode(&category)
+	err = internal.DecodeJSON(r, &category)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	category, err = h.service.UpdateCategoryByID(internal.ActorFromRequest(r), id.String(), category, internal.IfMatch(r))
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if category.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Category not found")
+		return
+	}
+
+	internal.SetETag(w, category.Version)
+	internal.HandleResponse(w, http.StatusOK, category)
+}
+
+// PatchCategoryByID applies a JSON Merge Patch to the category, made against
+// the version that was patched like PatchProductByID.
+func (h *CategoryHandler) PatchCategoryByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+	if !isMergePatch(r) {
+		internal.HandleError(w, http.StatusUnsupportedMediaType, mergePatchTypeMessage)
+		return
+	}
+	patch, err := io.ReadAll(r.Body)
 	if err != nil {
 		log.Println(err)
 		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	category, err = h.service.UpdateCategoryByID(id.String(), category)
+	current, err := h.service.GetCategoryByID(id.String())
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusInternalServerError, "Internal server error")
+		internal.WriteError(w, err)
+		return
+	}
+	if current.ID == "" || current.DeletedAt != nil {
+		internal.HandleError(w, http.StatusNotFound, "Category not found")
+		return
+	}
+	ifMatch := internal.IfMatch(r)
+	if ifMatch != nil && !slices.Contains(ifMatch, current.Version) {
+		internal.HandleError(w, http.StatusPreconditionFailed, "Category has changed, fetch it again and retry")
 		return
 	}
 
+	category, err := internal.MergePatch(current, patch)
+	if err == nil {
+		err = internal.Validate(category)
+	}
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	category, err = h.service.UpdateCategoryByID(internal.ActorFromRequest(r), id.String(), category, []int64{current.Version})
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
 	if category.ID == "" {
 		internal.HandleError(w, http.StatusNotFound, "Category not found")
 		return
 	}
 
+	internal.SetETag(w, category.Version)
 	internal.HandleResponse(w, http.StatusOK, category)
 }
 
@@ -111,10 +166,9 @@ func (h *CategoryHandler) DeleteCategoryByID(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	deletedCategory, err := h.service.DeleteCategoryByID(id.String())
+	deletedCategory, err := h.service.DeleteCategoryByID(internal.ActorFromRequest(r), id.String(), internal.IfMatch(r))
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusInternalServerError, "Internal server error")
+		internal.WriteError(w, err)
 		return
 	}
 
@@ -123,9 +177,42 @@ func (h *CategoryHandler) DeleteCategoryByID(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	internal.SetETag(w, deletedCategory.Version)
 	internal.HandleResponse(w, http.StatusOK, deletedCategory)
 }
 
+func (h *CategoryHandler) RestoreCategoryByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	restoredCategory, err := h.service.RestoreCategoryByID(internal.ActorFromRequest(r), id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if restoredCategory.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Archived category not found")
+		return
+	}
+
+	internal.SetETag(w, restoredCategory.Version)
+	internal.HandleResponse(w, http.StatusOK, restoredCategory)
+}
+
+func (h *CategoryHandler) HandleRestoreCategory(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.RestoreCategoryByID(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
 func (h *CategoryHandler) HandleCategory(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
@@ -143,6 +230,8 @@ func (h *CategoryHandler) HandleCategoryByID(w http.ResponseWriter, r *http.Requ
 		h.GetCategoryByID(w, r)
 	case http.MethodPut:
 		h.UpdateCategoryByID(w, r)
+	case http.MethodPatch:
+		h.PatchCategoryByID(w, r)
 	case http.MethodDelete:
 		h.DeleteCategoryByID(w, r)
 	default:
@@ -160,8 +249,7 @@ func (h *CategoryHandler) GetProductsByCategory(w http.ResponseWriter, r *http.R
 
 	products, err := h.service.GetProductsByCategoryID(id.String())
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusInternalServerError, "Internal server error")
+		internal.WriteError(w, err)
 		return
 	}
 
@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/services"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type CustomerHandler struct {
+	service *services.CustomerService
+}
+
+func NewCustomerHandler(service *services.CustomerService) CustomerHandler {
+	return CustomerHandler{service: service}
+}
+
+func (h *CustomerHandler) GetCustomerGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.service.GetCustomerGroups()
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, groups)
+}
+
+func (h *CustomerHandler) CreateCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	var group models.CustomerGroup
+	err := json.NewDecoder(r.Body).Decode(&group)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !isValidCustomerGroup(w, group) {
+		return
+	}
+
+	newGroup, err := h.service.CreateCustomerGroup(internal.ActorFromRequest(r), group)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusCreated, newGroup)
+}
+
+func (h *CustomerHandler) GetCustomerGroupByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	group, err := h.service.GetCustomerGroupByID(id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if group.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Customer group not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, group)
+}
+
+func (h *CustomerHandler) UpdateCustomerGroupByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var group models.CustomerGroup
+	err = json.NewDecoder(r.Body).Decode(&group)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !isValidCustomerGroup(w, group) {
+		return
+	}
+
+	group, err = h.service.UpdateCustomerGroupByID(internal.ActorFromRequest(r), id.String(), group)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if group.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Customer group not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, group)
+}
+
+func (h *CustomerHandler) DeleteCustomerGroupByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	deletedGroup, err := h.service.DeleteCustomerGroupByID(internal.ActorFromRequest(r), id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if deletedGroup.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Customer group not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, deletedGroup)
+}
+
+func (h *CustomerHandler) GetCustomers(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	customers, err := h.service.GetCustomers(name)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, customers)
+}
+
+func (h *CustomerHandler) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	var customer models.Customer
+	err := json.NewDecoder(r.Body).Decode(&customer)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !isValidCustomer(w, customer) {
+		return
+	}
+
+	newCustomer, err := h.service.CreateCustomer(internal.ActorFromRequest(r), customer)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusCreated, newCustomer)
+}
+
+func (h *CustomerHandler) GetCustomerByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	customer, err := h.service.GetCustomerByID(id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if customer.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Customer not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, customer)
+}
+
+func (h *CustomerHandler) UpdateCustomerByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var customer models.Customer
+	err = json.NewDecoder(r.Body).Decode(&customer)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !isValidCustomer(w, customer) {
+		return
+	}
+
+	customer, err = h.service.UpdateCustomerByID(internal.ActorFromRequest(r), id.String(), customer)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if customer.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Customer not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, customer)
+}
+
+func (h *CustomerHandler) DeleteCustomerByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	deletedCustomer, err := h.service.DeleteCustomerByID(internal.ActorFromRequest(r), id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if deletedCustomer.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Customer not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, deletedCustomer)
+}
+
+func isValidCustomerGroup(w http.ResponseWriter, group models.CustomerGroup) bool {
+	if group.Name == "" {
+		internal.HandleError(w, http.StatusBadRequest, "Name is required")
+		return false
+	}
+	if group.PriceListID != "" {
+		if _, err := uuid.Parse(group.PriceListID); err != nil {
+			internal.HandleError(w, http.StatusBadRequest, "Invalid price list uuid")
+			return false
+		}
+	}
+	return true
+}
+
+func isValidCustomer(w http.ResponseWriter, customer models.Customer) bool {
+	if customer.Name == "" {
+		internal.HandleError(w, http.StatusBadRequest, "Name is required")
+		return false
+	}
+	if customer.CustomerGroupID != "" {
+		if _, err := uuid.Parse(customer.CustomerGroupID); err != nil {
+			internal.HandleError(w, http.StatusBadRequest, "Invalid customer group uuid")
+			return false
+		}
+	}
+	return true
+}
+
+func (h *CustomerHandler) HandleCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetCustomerGroups(w, r)
+	case http.MethodPost:
+		h.CreateCustomerGroup(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *CustomerHandler) HandleCustomerGroupByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetCustomerGroupByID(w, r)
+	case http.MethodPut:
+		h.UpdateCustomerGroupByID(w, r)
+	case http.MethodDelete:
+		h.DeleteCustomerGroupByID(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *CustomerHandler) HandleCustomer(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetCustomers(w, r)
+	case http.MethodPost:
+		h.CreateCustomer(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *CustomerHandler) HandleCustomerByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetCustomerByID(w, r)
+	case http.MethodPut:
+		h.UpdateCustomerByID(w, r)
+	case http.MethodDelete:
+		h.DeleteCustomerByID(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"kasir-api/internal"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// swaggerUI holds Swagger UI 5.18.2 from swagger-ui-dist, vendored so the docs
+// page loads no script from a third party. Update both files together.
+//
+//go:embed swaggerui/swagger-ui.css swaggerui/swagger-ui-bundle.js
+var swaggerUI embed.FS
+
+// DocsAssets are the Swagger UI files served under /docs.
+var DocsAssets = []string{"swagger-ui.css", "swagger-ui-bundle.js"}
+
+// docsPage loads the vendored Swagger UI and points it at /openapi.json, both
+// are built into the binary.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Kasir API</title>
+<link rel="stylesheet" href="/docs/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="/docs/swagger-ui-bundle.js"></script>
+<script>
+window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+</script>
+</body>
+</html>
+`
+
+type DocsHandler struct {
+	document []byte
+}
+
+func NewDocsHandler(operations []internal.APIOperation) (*DocsHandler, error) {
+	document, err := json.Marshal(internal.BuildOpenAPI("Kasir API", "1.0.0", operations))
+	if err != nil {
+		return nil, err
+	}
+	return &DocsHandler{document: document}, nil
+}
+
+func (h *DocsHandler) HandleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(h.document)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *DocsHandler) HandleDocs(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(docsPage))
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *DocsHandler) HandleDocsAsset(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		name := mux.Vars(r)["file"]
+		content, err := swaggerUI.ReadFile("swaggerui/" + name)
+		if err != nil {
+			internal.HandleError(w, http.StatusNotFound, "File not found")
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(content))
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
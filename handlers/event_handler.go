@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const eventHeartbeatInterval = 15 * time.Second
+
+type EventHandler struct {
+	events             *internal.EventBroker
+	transactionService *services.TransactionService
+}
+
+func NewEventHandler(events *internal.EventBroker, transactionService *services.TransactionService) EventHandler {
+	return EventHandler{events: events, transactionService: transactionService}
+}
+
+// StreamEvents sends events as Server-Sent Events. It starts with today's
+// running totals, then replays anything after the Last-Event-ID header (or
+// last_event_id parameter) before following live events. The optional types
+// parameter is a comma separated list of event types to receive.
+func (h *EventHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		internal.HandleError(w, http.StatusInternalServerError, "Streaming is not supported")
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	var lastID int64
+	if lastEventID != "" {
+		var err error
+		lastID, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			internal.HandleError(w, http.StatusBadRequest, "Invalid last event id")
+			return
+		}
+	}
+
+	var types map[string]bool
+	if value := r.URL.Query().Get("types"); value != "" {
+		types = make(map[string]bool)
+		for _, eventType := range strings.Split(value, ",") {
+			types[strings.TrimSpace(eventType)] = true
+		}
+	}
+
+	today, err := h.transactionService.GetTodayTotals()
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	missed, live, cancel := h.events.Subscribe(lastID)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event models.Event) bool {
+		if types != nil && !types[event.Type] {
+			return true
+		}
+		return writeEvent(w, event) == nil
+	}
+
+	if types == nil || types[models.EventTotals] {
+		writeEvent(w, models.Event{Type: models.EventTotals, Data: today, CreatedAt: time.Now()})
+	}
+	for _, event := range missed {
+		if !send(event) {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			_, err := fmt.Fprint(w, ": heartbeat\n\n")
+			if err != nil {
+				return
+			}
+		case event, ok := <-live:
+			// A closed channel means this client fell behind; it reconnects
+			// with its last event id and catches up from the history.
+			if !ok || !send(event) {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes one event in SSE framing. Events without an id, such as
+// the initial totals, are not resumable.
+func writeEvent(w http.ResponseWriter, event models.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if event.ID != 0 {
+		_, err = fmt.Fprintf(w, "id: %d\n", event.ID)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+func (h *EventHandler) HandleEvents(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.StreamEvents(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
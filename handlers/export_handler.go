@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/services"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type ExportHandler struct {
+	productService     *services.ProductService
+	transactionService *services.TransactionService
+}
+
+func NewExportHandler(productService *services.ProductService, transactionService *services.TransactionService) ExportHandler {
+	return ExportHandler{productService: productService, transactionService: transactionService}
+}
+
+// parseExportQuery validates the shared format, from and to parameters and
+// writes the error response itself when they are invalid.
+func parseExportQuery(w http.ResponseWriter, r *http.Request) (string, models.DateRange, bool) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = internal.FormatCSV
+	}
+	if !internal.IsExportFormatValid(format) {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid format, expected csv, xlsx or jsonl")
+		return "", models.DateRange{}, false
+	}
+
+	dateRange, err := internal.ParseDateRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		internal.HandleError(w, http.StatusBadRequest, err.Error())
+		return "", models.DateRange{}, false
+	}
+	return format, dateRange, true
+}
+
+func (h *ExportHandler) ExportProducts(w http.ResponseWriter, r *http.Request) {
+	format, dateRange, ok := parseExportQuery(w, r)
+	if !ok {
+		return
+	}
+
+	columns := []string{"id", "name", "sku", "barcode", "price", "cost", "stock", "categories", "created_at"}
+	exporter, err := internal.NewExporter(w, format, "products", columns)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	err = h.productService.ExportProducts(dateRange, func(product models.Product) error {
+		names := make([]string, len(product.Categories))
+		for i, category := range product.Categories {
+			names[i] = category.Name
+		}
+		return exporter.Write(product, []any{
+			product.ID, product.Name, product.SKU, product.Barcode, product.Price, product.Cost, product.Stock,
+			strings.Join(names, "|"), product.CreatedAt.In(internal.StoreLocation()).Format(time.RFC3339),
+		})
+	})
+	finishExport(exporter, err)
+}
+
+func (h *ExportHandler) ExportTransactions(w http.ResponseWriter, r *http.Request) {
+	format, dateRange, ok := parseExportQuery(w, r)
+	if !ok {
+		return
+	}
+
+	columns := []string{"id", "total_amount", "customer_id", "created_at"}
+	exporter, err := internal.NewExporter(w, format, "transactions", columns)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	err = h.transactionService.ExportTransactions(dateRange, func(transaction models.Transaction) error {
+		return exporter.Write(transaction, []any{
+			transaction.ID, transaction.TotalAmount, transaction.CustomerID, transaction.CreatedAt.In(internal.StoreLocation()).Format(time.RFC3339),
+		})
+	})
+	finishExport(exporter, err)
+}
+
+func (h *ExportHandler) ExportTransactionDetails(w http.ResponseWriter, r *http.Request) {
+	format, dateRange, ok := parseExportQuery(w, r)
+	if !ok {
+		return
+	}
+
+	columns := []string{"id", "transaction_id", "product_id", "product_name", "quantity", "price", "subtotal",
+		"lot_id", "serial_number", "price_list_id", "created_at"}
+	exporter, err := internal.NewExporter(w, format, "transaction_items", columns)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	err = h.transactionService.ExportTransactionDetails(dateRange, func(detail models.TransactionDetail) error {
+		return exporter.Write(detail, []any{
+			detail.ID, detail.TransactionID, detail.ProductID, detail.ProductName, detail.Quantity, detail.Price, detail.Subtotal,
+			detail.LotID, detail.SerialNumber, detail.PriceListID, detail.CreatedAt.In(internal.StoreLocation()).Format(time.RFC3339),
+		})
+	})
+	finishExport(exporter, err)
+}
+
+// finishExport closes the exporter. The status line may already have been sent
+// by the time rows are streamed, so a failure is logged and the connection
+// aborted, letting the client see the export was cut short instead of
+// receiving what looks like a complete file.
+func finishExport(exporter *internal.Exporter, err error) {
+	if err == nil {
+		err = exporter.Close()
+	}
+	if err != nil {
+		log.Println(err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func (h *ExportHandler) HandleExportProducts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.ExportProducts(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ExportHandler) HandleExportTransactions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.ExportTransactions(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ExportHandler) HandleExportTransactionDetails(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.ExportTransactionDetails(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/services"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultCoverDays    = 7
+	defaultForecastDays = 14
+	maxForecastDays     = 365
+)
+
+type ForecastHandler struct {
+	service *services.ForecastService
+}
+
+func NewForecastHandler(service *services.ForecastService) ForecastHandler {
+	return ForecastHandler{service: service}
+}
+
+func (h *ForecastHandler) GetForecasts(w http.ResponseWriter, r *http.Request) {
+	coverDays, ok := queryInt(r, "cover_days", defaultCoverDays, maxForecastDays)
+	if !ok {
+		internal.HandleError(w, http.StatusBadRequest, fmt.Sprintf("Invalid cover_days, expected 0 to %d", maxForecastDays))
+		return
+	}
+	reorderOnly := r.URL.Query().Get("reorder_only") == "true"
+
+	forecasts, err := h.service.GetForecasts(coverDays, reorderOnly)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, forecasts)
+}
+
+// GenerateForecasts runs the forecast job now instead of waiting for the
+// schedule and returns the fresh forecasts.
+func (h *ForecastHandler) GenerateForecasts(w http.ResponseWriter, r *http.Request) {
+	err := h.service.GenerateForecasts()
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	h.GetForecasts(w, r)
+}
+
+func (h *ForecastHandler) GetProductForecast(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid product uuid")
+		return
+	}
+	coverDays, ok := queryInt(r, "cover_days", defaultCoverDays, maxForecastDays)
+	if !ok {
+		internal.HandleError(w, http.StatusBadRequest, fmt.Sprintf("Invalid cover_days, expected 0 to %d", maxForecastDays))
+		return
+	}
+	days, ok := queryInt(r, "days", defaultForecastDays, maxForecastDays)
+	if !ok {
+		internal.HandleError(w, http.StatusBadRequest, fmt.Sprintf("Invalid days, expected 0 to %d", maxForecastDays))
+		return
+	}
+
+	forecast, err := h.service.GetForecastByProductID(id.String(), coverDays, days)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	if forecast.ProductID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, forecast)
+}
+
+func (h *ForecastHandler) SetLeadTime(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid product uuid")
+		return
+	}
+
+	var request models.LeadTimeRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if request.LeadTimeDays < 0 || request.LeadTimeDays > maxForecastDays {
+		internal.HandleError(w, http.StatusBadRequest, fmt.Sprintf("Lead time days must be 0 to %d", maxForecastDays))
+		return
+	}
+
+	found, err := h.service.SetLeadTime(internal.ActorFromRequest(r), id.String(), request.LeadTimeDays)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	if !found {
+		internal.HandleError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, request)
+}
+
+func (h *ForecastHandler) HandleForecasts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetForecasts(w, r)
+	case http.MethodPost:
+		h.GenerateForecasts(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ForecastHandler) HandleProductForecast(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetProductForecast(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ForecastHandler) HandleLeadTime(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		h.SetLeadTime(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"kasir-api/internal"
+	apperrors "kasir-api/internal/errors"
+	"kasir-api/services"
+	"log"
+	"net/http"
+)
+
+const maxIdempotencyKeyLength = 255
+
+type IdempotencyHandler struct {
+	service *services.IdempotencyService
+}
+
+func NewIdempotencyHandler(service *services.IdempotencyService) *IdempotencyHandler {
+	return &IdempotencyHandler{service: service}
+}
+
+// Middleware makes POST and PATCH requests carrying an Idempotency-Key safe to
+// retry: the first response is stored and replayed for every retry with the
+// same key from the same X-Actor, marked with Idempotent-Replayed. Server
+// errors and panics are not stored so the retry runs again. A key reused for
+// another request, or retried while the first attempt is still running,
+// answers 409. The running attempt keeps its key leased, and a retry takes the
+// key over only once the lease lapses.
+func (h *IdempotencyHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" || (r.Method != http.MethodPost && r.Method != http.MethodPatch) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			internal.HandleError(w, http.StatusBadRequest, "Idempotency key is too long")
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportSize))
+		if err != nil {
+			log.Println(err)
+			internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.New()
+		io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+		hash.Write(body)
+		requestHash := hex.EncodeToString(hash.Sum(nil))
+
+		actor := internal.ActorFromRequest(r).ClaimedName
+		stored, reserved, err := h.service.ReserveKey(actor, key, r.Method, r.URL.Path, requestHash)
+		if err != nil {
+			internal.WriteError(w, err)
+			return
+		}
+		if !reserved {
+			switch {
+			case stored.RequestHash != requestHash:
+				internal.WriteError(w, apperrors.NewConflictError("idempotency_key_reused", "Idempotency key was already used for a different request"))
+			case stored.StatusCode == 0:
+				internal.WriteError(w, apperrors.NewConflictError("idempotency_key_in_progress", "A request with this idempotency key is still running"))
+			default:
+				for name, values := range stored.Header {
+					w.Header()[name] = values
+				}
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(stored.StatusCode)
+				w.Write(stored.Body)
+			}
+			return
+		}
+
+		stopHolding := h.service.HoldKey(stored)
+		defer func() {
+			stopHolding()
+			if recovered := recover(); recovered != nil {
+				if err := h.service.ReleaseKey(stored); err != nil {
+					log.Println(err)
+				}
+				panic(recovered)
+			}
+		}()
+
+		recorder := &recordingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		if recorder.statusCode >= http.StatusInternalServerError {
+			err = h.service.ReleaseKey(stored)
+		} else {
+			header := w.Header().Clone()
+			header.Del("X-Request-ID")
+			err = h.service.SaveResponse(stored, recorder.statusCode, header, recorder.body.Bytes())
+		}
+		if err != nil {
+			log.Println(err)
+		}
+	})
+}
+
+// recordingResponseWriter passes the response through and keeps a copy of
+// its status and body.
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *recordingResponseWriter) WriteHeader(code int) {
+	w.statusCode = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingResponseWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
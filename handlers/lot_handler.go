@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/services"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const defaultExpiringDays = 30
+
+type LotHandler struct {
+	service *services.LotService
+}
+
+func NewLotHandler(service *services.LotService) LotHandler {
+	return LotHandler{service: service}
+}
+
+func (h *LotHandler) GetProductLots(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid product uuid")
+		return
+	}
+
+	lots, err := h.service.GetLotsByProductID(id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, lots)
+}
+
+func (h *LotHandler) ReceiveLot(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid product uuid")
+		return
+	}
+
+	var lot models.ProductLot
+	err = json.NewDecoder(r.Body).Decode(&lot)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if lot.LotNumber == "" {
+		internal.HandleError(w, http.StatusBadRequest, "Lot number is required")
+		return
+	}
+	if lot.Quantity <= 0 {
+		internal.HandleError(w, http.StatusBadRequest, "Quantity must be greater than zero")
+		return
+	}
+	if lot.ExpiryDate != "" && !internal.IsDateValid(lot.ExpiryDate) {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid expiry date")
+		return
+	}
+
+	lot.ProductID = id.String()
+	newLot, err := h.service.ReceiveLot(internal.ActorFromRequest(r), lot)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if newLot.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusCreated, newLot)
+}
+
+func (h *LotHandler) GetExpiringLots(w http.ResponseWriter, r *http.Request) {
+	days := defaultExpiringDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			internal.HandleError(w, http.StatusBadRequest, "Invalid days")
+			return
+		}
+		days = parsed
+	}
+
+	lots, err := h.service.GetExpiringLots(days)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, lots)
+}
+
+func (h *LotHandler) HandleProductLots(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetProductLots(w, r)
+	case http.MethodPost:
+		h.ReceiveLot(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *LotHandler) HandleExpiringLots(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetExpiringLots(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
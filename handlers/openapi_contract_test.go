@@ -0,0 +1,718 @@
+package handlers_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"kasir-api/database"
+	"kasir-api/graphqlserver"
+	"kasir-api/handlers"
+	"kasir-api/internal"
+	"kasir-api/repositories"
+	"kasir-api/services"
+	"log"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// The contract tests run the real handlers, wired as in main.go, and check
+// what they answer against the OpenAPI document built from APIOperations.
+// Without a database handlers only get as far as rejecting input, so every
+// documented operation is probed with input it must refuse and the document
+// and docs routes answer in full. TestContractAgainstDatabase runs the
+// successful paths too when TEST_DB_CONN points at a disposable database.
+
+func TestMain(m *testing.M) {
+	// The handlers log every input they refuse.
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// newContractRouter registers the handlers with RegisterRoutes, as main.go
+// does, behind the request id and idempotency middleware. With a nil db a
+// handler that goes on to query the database panics, and the probe reports it.
+func newContractRouter(t *testing.T, db *sql.DB) *mux.Router {
+	t.Helper()
+	events := internal.NewEventBroker()
+	productService := services.NewProductService(repositories.NewProductRepository(db), events)
+	categoryService := services.NewCategoryService(repositories.NewCategoryRepository(db))
+	transactionService := services.NewTransactionService(repositories.NewTransactionRepository(db), events)
+	reportService := services.NewReportService(repositories.NewReportRepository(db))
+	auditService := services.NewAuditService(repositories.NewAuditRepository(db))
+
+	idempotencyHandler := handlers.NewIdempotencyHandler(services.NewIdempotencyService(repositories.NewIdempotencyRepository(db)))
+	docsHandler, err := handlers.NewDocsHandler(handlers.APIOperations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := mux.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, internal.WithRequestID(w, r))
+		})
+	})
+	r.Use(idempotencyHandler.Middleware)
+
+	handlers.RegisterRoutes(r, handlers.Routes{
+		Health: handlers.NewHealthHandler(db),
+		Docs:   docsHandler,
+		GraphQL: graphqlserver.New(graphqlserver.Services{
+			Products:     productService,
+			Categories:   categoryService,
+			Transactions: transactionService,
+			Reports:      reportService,
+			Audit:        auditService,
+		}),
+		Product:     handlers.NewProductHandler(productService),
+		Category:    handlers.NewCategoryHandler(categoryService),
+		Lot:         handlers.NewLotHandler(services.NewLotService(repositories.NewLotRepository(db))),
+		Serial:      handlers.NewSerialHandler(services.NewSerialService(repositories.NewSerialRepository(db))),
+		Price:       handlers.NewPriceHandler(services.NewPriceService(repositories.NewPriceRepository(db))),
+		PriceList:   handlers.NewPriceListHandler(services.NewPriceListService(repositories.NewPriceListRepository(db))),
+		Customer:    handlers.NewCustomerHandler(services.NewCustomerService(repositories.NewCustomerRepository(db))),
+		Forecast:    handlers.NewForecastHandler(services.NewForecastService(repositories.NewForecastRepository(db))),
+		Transaction: handlers.NewTransactionHandler(transactionService),
+		Event:       handlers.NewEventHandler(events, transactionService),
+		Webhook:     handlers.NewWebhookHandler(services.NewWebhookService(repositories.NewWebhookRepository(db))),
+		Audit:       handlers.NewAuditHandler(auditService),
+		Export:      handlers.NewExportHandler(productService, transactionService),
+		Report:      handlers.NewReportHandler(reportService),
+	})
+	return r
+}
+
+// buildDocument round trips the document through JSON, so the test sees it
+// the way a client does.
+func buildDocument(t *testing.T) map[string]any {
+	t.Helper()
+	raw, err := json.Marshal(internal.BuildOpenAPI("Kasir API", "1.0.0", handlers.APIOperations))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var document map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		t.Fatal(err)
+	}
+	return document
+}
+
+// contract checks requests and responses against one OpenAPI document.
+type contract struct {
+	document map[string]any
+}
+
+func (c contract) operation(method, path string) map[string]any {
+	item, _ := c.document["paths"].(map[string]any)[path].(map[string]any)
+	operation, _ := item[strings.ToLower(method)].(map[string]any)
+	return operation
+}
+
+// resolve follows a local $ref such as #/components/schemas/Product.
+func (c contract) resolve(node map[string]any) map[string]any {
+	for {
+		ref, ok := node["$ref"].(string)
+		if !ok {
+			return node
+		}
+		var target any = c.document
+		for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			target = target.(map[string]any)[part]
+		}
+		node = target.(map[string]any)
+	}
+}
+
+// mediaSchema finds the schema documented for a media type of a request body
+// or response, with the parameters of the Content-Type ignored.
+func (c contract) mediaSchema(node map[string]any, contentType string) (map[string]any, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Type %q", contentType)
+	}
+	content, _ := c.resolve(node)["content"].(map[string]any)
+	media, ok := content[mediaType].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("media type %s is not documented, have %v", mediaType, sortedKeys(content))
+	}
+	schema, _ := media["schema"].(map[string]any)
+	return schema, nil
+}
+
+// checkResponse validates a recorded response against the operation.
+func (c contract) checkResponse(operation map[string]any, response *httptest.ResponseRecorder) error {
+	responses := operation["responses"].(map[string]any)
+	documented, ok := responses[strconv.Itoa(response.Code)].(map[string]any)
+	if !ok {
+		return fmt.Errorf("status %d is not documented, have %v: %s", response.Code, sortedKeys(responses), response.Body)
+	}
+	if _, hasContent := c.resolve(documented)["content"]; !hasContent {
+		if response.Body.Len() > 0 {
+			return fmt.Errorf("status %d is documented without a body, got %s", response.Code, response.Body)
+		}
+		return nil
+	}
+	schema, err := c.mediaSchema(documented, response.Header().Get("Content-Type"))
+	if err != nil {
+		return fmt.Errorf("status %d: %w", response.Code, err)
+	}
+	if !isJSON(response.Header().Get("Content-Type")) {
+		return nil
+	}
+	body, err := decodeJSON(response.Body.Bytes())
+	if err != nil {
+		return fmt.Errorf("status %d: %w", response.Code, err)
+	}
+	return c.validate(schema, body, "body")
+}
+
+// validate checks value against schema: types, formats, required and enum
+// values, nullability and, unlike plain OpenAPI, no properties beyond the
+// documented ones. Length and range limits constrain input only and are not
+// checked.
+func (c contract) validate(schema map[string]any, value any, at string) error {
+	schema = c.resolve(schema)
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable || len(schema) == 0 {
+			return nil
+		}
+		return fmt.Errorf("%s: null is not allowed", at)
+	}
+	if allOf, ok := schema["allOf"].([]any); ok {
+		for _, part := range allOf {
+			if err := c.validate(part.(map[string]any), value, at); err != nil {
+				return err
+			}
+		}
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, allowed := range enum {
+			found = found || allowed == value
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", at, value, enum)
+		}
+	}
+
+	switch schema["type"] {
+	case nil:
+		return nil
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: want an object, got %T", at, value)
+		}
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := object[name.(string)]; !ok {
+				return fmt.Errorf("%s: required property %q is missing", at, name)
+			}
+		}
+		properties, hasProperties := schema["properties"].(map[string]any)
+		additional, _ := schema["additionalProperties"].(map[string]any)
+		for _, name := range sortedKeys(object) {
+			property, ok := properties[name].(map[string]any)
+			switch {
+			case ok:
+			case additional != nil:
+				property = additional
+			case !hasProperties:
+				continue
+			default:
+				return fmt.Errorf("%s: property %q is not documented", at, name)
+			}
+			if err := c.validate(property, object[name], at+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: want an array, got %T", at, value)
+		}
+		items, _ := schema["items"].(map[string]any)
+		for i, item := range array {
+			if err := c.validate(items, item, at+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: want a string, got %T", at, value)
+		}
+		switch schema["format"] {
+		case "uuid":
+			if _, err := uuid.Parse(text); err != nil {
+				return fmt.Errorf("%s: %q is not a uuid", at, text)
+			}
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339Nano, text); err != nil {
+				return fmt.Errorf("%s: %q is not a date-time", at, text)
+			}
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if _, err := number.Int64(); !ok || err != nil {
+			return fmt.Errorf("%s: want an integer, got %v", at, value)
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return fmt.Errorf("%s: want a number, got %T", at, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: want a boolean, got %T", at, value)
+		}
+	default:
+		return fmt.Errorf("%s: unknown schema type %v", at, schema["type"])
+	}
+	return nil
+}
+
+func decodeJSON(raw []byte) (any, error) {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON %q: %w", raw, err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("trailing data after the JSON value %q", raw)
+	}
+	return value, nil
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// serve runs one request through the router. A panic means the handler went
+// on to the missing database, it is returned as an error.
+func serve(router http.Handler, request *http.Request) (response *httptest.ResponseRecorder, err error) {
+	response = httptest.NewRecorder()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("handler reached the database: %v", recovered)
+		}
+	}()
+	router.ServeHTTP(response, request)
+	return response, nil
+}
+
+func TestRoutesMatchDocument(t *testing.T) {
+	routed := map[string]bool{}
+	newContractRouter(t, nil).Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err == nil {
+			routed[path] = true
+		}
+		return nil
+	})
+	documented := map[string]bool{}
+	for _, path := range internal.RoutePaths(handlers.APIOperations) {
+		documented[path] = true
+		if !routed[path] {
+			t.Errorf("%s is documented but not routed", path)
+		}
+	}
+	for path := range routed {
+		if !documented[path] {
+			t.Errorf("%s is routed but not documented", path)
+		}
+	}
+}
+
+var pathParameter = regexp.MustCompile(`\{(\w+)\}`)
+
+// invalidRequest builds a request the operation has to refuse before it
+// touches the database: a malformed uuid in the path, or else a truncated
+// JSON body. It returns nil when the operation takes neither.
+func invalidRequest(operation internal.APIOperation) *http.Request {
+	invalidPath := false
+	path := pathParameter.ReplaceAllStringFunc(operation.Path, func(match string) string {
+		name := pathParameter.FindStringSubmatch(match)[1]
+		for _, parameter := range operation.Parameters {
+			if parameter.In == "path" && parameter.Name == name {
+				if len(parameter.Enum) > 0 {
+					return parameter.Enum[0]
+				}
+				return "serial"
+			}
+		}
+		invalidPath = true
+		return "not-a-uuid"
+	})
+	if invalidPath {
+		return httptest.NewRequest(operation.Method, path, nil)
+	}
+
+	if operation.Request == nil {
+		return nil
+	}
+	contentType := "application/json"
+	if len(operation.RequestTypes) > 0 {
+		contentType = operation.RequestTypes[0]
+	}
+	request := httptest.NewRequest(operation.Method, path, strings.NewReader(`{"name":`))
+	request.Header.Set("Content-Type", contentType)
+	return request
+}
+
+func TestHandlersRefuseInvalidInputAsDocumented(t *testing.T) {
+	router := newContractRouter(t, nil)
+	document := contract{buildDocument(t)}
+	probed := 0
+	for _, operation := range handlers.APIOperations {
+		request := invalidRequest(operation)
+		if request == nil {
+			continue
+		}
+		probed++
+		t.Run(operation.ID, func(t *testing.T) {
+			response, err := serve(router, request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.Code < 400 || response.Code >= 500 {
+				t.Fatalf("%s %s answered %d, want a client error: %s", request.Method, request.URL, response.Code, response.Body)
+			}
+			if err := document.checkResponse(document.operation(operation.Method, operation.Path), response); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	if probed == 0 {
+		t.Fatal("no operation was probed")
+	}
+}
+
+func TestDocumentedMethodsAreHandled(t *testing.T) {
+	router := newContractRouter(t, nil)
+	for _, operation := range handlers.APIOperations {
+		request := invalidRequest(operation)
+		if request == nil {
+			continue
+		}
+		t.Run(operation.ID, func(t *testing.T) {
+			response, err := serve(router, request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.Code == http.StatusMethodNotAllowed {
+				t.Errorf("%s %s is documented but answers 405", operation.Method, operation.Path)
+			}
+		})
+	}
+}
+
+func TestDatabaseFreeResponsesMatchDocument(t *testing.T) {
+	router := newContractRouter(t, nil)
+	document := contract{buildDocument(t)}
+	postQuery := func(body string) *http.Request {
+		request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+	tests := []struct {
+		name       string
+		request    *http.Request
+		path       string
+		wantStatus int
+	}{
+		{"openapi", httptest.NewRequest(http.MethodGet, "/openapi.json", nil), "/openapi.json", http.StatusOK},
+		{"docs", httptest.NewRequest(http.MethodGet, "/docs", nil), "/docs", http.StatusOK},
+		{"docs asset", httptest.NewRequest(http.MethodGet, "/docs/swagger-ui.css", nil), "/docs/{file}", http.StatusOK},
+		{"docs script", httptest.NewRequest(http.MethodGet, "/docs/swagger-ui-bundle.js", nil), "/docs/{file}", http.StatusOK},
+		{"missing docs asset", httptest.NewRequest(http.MethodGet, "/docs/index.html", nil), "/docs/{file}", http.StatusNotFound},
+		{"graphql get", httptest.NewRequest(http.MethodGet, "/graphql?query=%7B__typename%7D", nil), "/graphql", http.StatusOK},
+		{"graphql post", postQuery(`{"query":"{ __typename }"}`), "/graphql", http.StatusOK},
+		{"graphql invalid query", postQuery(`{"query":"{ nope }"}`), "/graphql", http.StatusUnprocessableEntity},
+		{"graphql malformed body", postQuery(`{"query":`), "/graphql", http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response, err := serve(router, test.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.Code != test.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", response.Code, test.wantStatus, response.Body)
+			}
+			if err := document.checkResponse(document.operation(test.request.Method, test.path), response); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// TestServedDocumentIsBuiltDocument checks that /openapi.json serves the
+// document the other tests validate against.
+func TestServedDocumentIsBuiltDocument(t *testing.T) {
+	response, err := serve(newContractRouter(t, nil), httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	served, err := decodeJSON(response.Body.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(served, any(buildDocument(t))) {
+		t.Error("/openapi.json differs from the document built from APIOperations")
+	}
+}
+
+// sampleUUID fills every string of a sample, so fields documented as uuids
+// hold one.
+const sampleUUID = "6f1c2a8e-4b7d-4c1e-9a3f-2d5e8b7c9a10"
+
+// sample builds a value of t with every field set, so each one shows up in
+// its JSON encoding.
+func sample(t reflect.Type, depth int) reflect.Value {
+	value := reflect.New(t).Elem()
+	if depth > 4 {
+		return value
+	}
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		value.Set(reflect.ValueOf(time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)))
+		return value
+	case t == reflect.TypeOf(json.RawMessage{}):
+		value.SetBytes([]byte(`{"key":"value"}`))
+		return value
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		value.Set(sample(t.Elem(), depth+1).Addr())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				value.Field(i).Set(sample(t.Field(i).Type, depth+1))
+			}
+		}
+	case reflect.Slice:
+		value.Set(reflect.Append(reflect.MakeSlice(t, 0, 1), sample(t.Elem(), depth+1)))
+	case reflect.Map:
+		value.Set(reflect.MakeMap(t))
+		value.SetMapIndex(sample(t.Key(), depth+1), sample(t.Elem(), depth+1))
+	case reflect.String:
+		value.SetString(sampleUUID)
+	case reflect.Bool:
+		value.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(1.5)
+	}
+	return value
+}
+
+// TestModelsEncodeAsDocumented encodes a filled in value of every request and
+// response model and validates it against its documented schema, so a model
+// whose JSON encoding no longer matches the generated schema fails here.
+func TestModelsEncodeAsDocumented(t *testing.T) {
+	document := contract{buildDocument(t)}
+	for _, operation := range handlers.APIOperations {
+		t.Run(operation.ID, func(t *testing.T) {
+			documented := document.operation(operation.Method, operation.Path)
+			if documented == nil {
+				t.Fatalf("%s %s is missing from the document", operation.Method, operation.Path)
+			}
+			if operation.Request != nil {
+				body, _ := documented["requestBody"].(map[string]any)
+				schema, err := document.mediaSchema(body, "application/json")
+				if err != nil {
+					t.Fatalf("request: %v", err)
+				}
+				if err := document.validateSample(schema, operation.Request); err != nil {
+					t.Errorf("request %T: %v", operation.Request, err)
+				}
+			}
+			if operation.Response != nil {
+				status := operation.Status
+				if status == 0 {
+					status = http.StatusOK
+				}
+				response, _ := documented["responses"].(map[string]any)[strconv.Itoa(status)].(map[string]any)
+				mediaType := "application/json"
+				if len(operation.ResponseTypes) > 0 {
+					mediaType = operation.ResponseTypes[0]
+				}
+				schema, err := document.mediaSchema(response, mediaType)
+				if err != nil {
+					t.Fatalf("response: %v", err)
+				}
+				if err := document.validateSample(schema, operation.Response); err != nil {
+					t.Errorf("response %T: %v", operation.Response, err)
+				}
+			}
+		})
+	}
+}
+
+func (c contract) validateSample(schema map[string]any, model any) error {
+	raw, err := json.Marshal(sample(reflect.TypeOf(model), 0).Interface())
+	if err != nil {
+		return err
+	}
+	value, err := decodeJSON(raw)
+	if err != nil {
+		return err
+	}
+	return c.validate(schema, value, "body")
+}
+
+// TestValidatorCatchesDrift makes sure the checks above can fail: a body with
+// an undocumented field, a wrong type or a missing required field is refused.
+func TestValidatorCatchesDrift(t *testing.T) {
+	document := contract{buildDocument(t)}
+	problem := map[string]any{"$ref": "#/components/schemas/Problem"}
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"undocumented field", `{"type":"about:blank","title":"Bad Request","status":400,"code":"bad_request","message":"x"}`},
+		{"wrong type", `{"type":"about:blank","title":"Bad Request","status":"400","code":"bad_request"}`},
+		{"not an object", `[]`},
+		{"null", `null`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := decodeJSON([]byte(test.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if document.validate(problem, value, "body") == nil {
+				t.Errorf("%s passed validation", test.body)
+			}
+		})
+	}
+
+	response := httptest.NewRecorder()
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(http.StatusTeapot)
+	io.WriteString(response, `{}`)
+	if document.checkResponse(document.operation(http.MethodGet, "/api/products"), response) == nil {
+		t.Error("an undocumented status passed validation")
+	}
+}
+
+// TestContractAgainstDatabase walks through a sale on a migrated database and
+// validates every request and response against the document. It writes rows,
+// so TEST_DB_CONN must not point at a database anyone relies on.
+func TestContractAgainstDatabase(t *testing.T) {
+	conn := os.Getenv("TEST_DB_CONN")
+	if conn == "" {
+		t.Skip("TEST_DB_CONN is not set")
+	}
+	db, err := database.InitDB(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := database.Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+
+	router := newContractRouter(t, db)
+	document := contract{buildDocument(t)}
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	// call sends body to path, validating both against the operation of
+	// method and template, and returns the decoded response.
+	call := func(method, template, path string, body any, wantStatus int) map[string]any {
+		t.Helper()
+		operation := document.operation(method, template)
+		if operation == nil {
+			t.Fatalf("%s %s is not documented", method, template)
+		}
+		var reader io.Reader
+		if body != nil {
+			raw, err := json.Marshal(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			requestBody, _ := operation["requestBody"].(map[string]any)
+			schema, err := document.mediaSchema(requestBody, "application/json")
+			if err != nil {
+				t.Fatalf("%s %s request: %v", method, path, err)
+			}
+			value, _ := decodeJSON(raw)
+			if err := document.validate(schema, value, "request"); err != nil {
+				t.Fatalf("%s %s request: %v", method, path, err)
+			}
+			reader = bytes.NewReader(raw)
+		}
+		request := httptest.NewRequest(method, path, reader)
+		if body != nil {
+			request.Header.Set("Content-Type", "application/json")
+		}
+		response, err := serve(router, request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if response.Code != wantStatus {
+			t.Fatalf("%s %s answered %d, want %d: %s", method, path, response.Code, wantStatus, response.Body)
+		}
+		if err := document.checkResponse(operation, response); err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		value, _ := decodeJSON(response.Body.Bytes())
+		object, _ := value.(map[string]any)
+		return object
+	}
+
+	category := call(http.MethodPost, "/api/categories", "/api/categories",
+		map[string]any{"name": "Contract " + suffix, "description": "Created by the contract test"}, http.StatusCreated)
+	categoryID, _ := category["id"].(string)
+	product := call(http.MethodPost, "/api/products", "/api/products",
+		map[string]any{"name": "Contract " + suffix, "sku": "CT-" + suffix, "price": 1500, "cost": 1000, "stock": 10}, http.StatusCreated)
+	productID, _ := product["id"].(string)
+
+	call(http.MethodPost, "/api/products/{id}/categories", "/api/products/"+productID+"/categories",
+		map[string]any{"category_id": categoryID}, http.StatusCreated)
+	call(http.MethodGet, "/api/products/{id}", "/api/products/"+productID, nil, http.StatusOK)
+	call(http.MethodGet, "/api/products", "/api/products?name="+suffix, nil, http.StatusOK)
+	call(http.MethodGet, "/api/products/{id}/categories", "/api/products/"+productID+"/categories", nil, http.StatusOK)
+	call(http.MethodGet, "/api/categories/{id}", "/api/categories/"+categoryID, nil, http.StatusOK)
+	call(http.MethodGet, "/api/categories/{id}/products", "/api/categories/"+categoryID+"/products", nil, http.StatusOK)
+
+	call(http.MethodPost, "/api/checkout", "/api/checkout",
+		map[string]any{"items": []any{map[string]any{"product_id": productID, "quantity": 2}}}, http.StatusOK)
+	today := internal.StoreDate(time.Now())
+	call(http.MethodGet, "/api/transactions", "/api/transactions?from="+today+"&to="+today, nil, http.StatusOK)
+	call(http.MethodGet, "/api/reports/today", "/api/reports/today", nil, http.StatusOK)
+	call(http.MethodGet, "/healthz", "/healthz", nil, http.StatusOK)
+
+	call(http.MethodDelete, "/api/products/{id}", "/api/products/"+productID, nil, http.StatusOK)
+	call(http.MethodDelete, "/api/categories/{id}", "/api/categories/"+categoryID, nil, http.StatusOK)
+}
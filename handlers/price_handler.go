@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/services"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type PriceHandler struct {
+	service *services.PriceService
+}
+
+func NewPriceHandler(service *services.PriceService) PriceHandler {
+	return PriceHandler{service: service}
+}
+
+func (h *PriceHandler) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid product uuid")
+		return
+	}
+
+	prices, err := h.service.GetPriceHistory(id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, prices)
+}
+
+func (h *PriceHandler) SchedulePriceChange(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid product uuid")
+		return
+	}
+
+	var req models.SchedulePriceRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Price < 0 {
+		internal.HandleError(w, http.StatusBadRequest, "Price must not be negative")
+		return
+	}
+	if !req.EffectiveAt.After(time.Now()) {
+		internal.HandleError(w, http.StatusBadRequest, "Effective time must be in the future")
+		return
+	}
+
+	price, err := h.service.SchedulePriceChange(internal.ActorFromRequest(r), id.String(), req.Price, req.EffectiveAt)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if price.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusCreated, price)
+}
+
+func (h *PriceHandler) CancelScheduledPrice(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid product uuid")
+		return
+	}
+
+	priceID, err := uuid.Parse(mux.Vars(r)["price_id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid price uuid")
+		return
+	}
+
+	price, err := h.service.CancelScheduledPrice(internal.ActorFromRequest(r), id.String(), priceID.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if price.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Scheduled price not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, price)
+}
+
+func (h *PriceHandler) HandlePriceHistory(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetPriceHistory(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PriceHandler) HandleProductPrices(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetPriceHistory(w, r)
+	case http.MethodPost:
+		h.SchedulePriceChange(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PriceHandler) HandleProductPriceByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodDelete:
+		h.CancelScheduledPrice(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/services"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type PriceListHandler struct {
+	service *services.PriceListService
+}
+
+func NewPriceListHandler(service *services.PriceListService) PriceListHandler {
+	return PriceListHandler{service: service}
+}
+
+func (h *PriceListHandler) GetPriceLists(w http.ResponseWriter, r *http.Request) {
+	priceLists, err := h.service.GetPriceLists()
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, priceLists)
+}
+
+func (h *PriceListHandler) CreatePriceList(w http.ResponseWriter, r *http.Request) {
+	var priceList models.PriceList
+	err := json.NewDecoder(r.Body).Decode(&priceList)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if priceList.Name == "" {
+		internal.HandleError(w, http.StatusBadRequest, "Name is required")
+		return
+	}
+
+	newPriceList, err := h.service.CreatePriceList(internal.ActorFromRequest(r), priceList)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusCreated, newPriceList)
+}
+
+func (h *PriceListHandler) GetPriceListByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	priceList, err := h.service.GetPriceListByID(id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if priceList.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Price list not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, priceList)
+}
+
+func (h *PriceListHandler) UpdatePriceListByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var priceList models.PriceList
+	err = json.NewDecoder(r.Body).Decode(&priceList)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if priceList.Name == "" {
+		internal.HandleError(w, http.StatusBadRequest, "Name is required")
+		return
+	}
+
+	priceList, err = h.service.UpdatePriceListByID(internal.ActorFromRequest(r), id.String(), priceList)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if priceList.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Price list not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, priceList)
+}
+
+func (h *PriceListHandler) DeletePriceListByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	deletedPriceList, err := h.service.DeletePriceListByID(internal.ActorFromRequest(r), id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if deletedPriceList.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Price list not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, deletedPriceList)
+}
+
+func (h *PriceListHandler) GetPriceListItems(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	items, err := h.service.GetPriceListItems(id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, items)
+}
+
+func (h *PriceListHandler) SetPriceListItem(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var item models.PriceListItem
+	err = json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if _, err := uuid.Parse(item.ProductID); err != nil {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid product uuid")
+		return
+	}
+	if item.MinQuantity == 0 {
+		item.MinQuantity = 1
+	}
+	if item.MinQuantity < 1 {
+		internal.HandleError(w, http.StatusBadRequest, "Minimum quantity must be at least 1")
+		return
+	}
+	if item.Price < 0 {
+		internal.HandleError(w, http.StatusBadRequest, "Price must not be negative")
+		return
+	}
+
+	item.PriceListID = id.String()
+	newItem, err := h.service.SetPriceListItem(internal.ActorFromRequest(r), item)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, newItem)
+}
+
+func (h *PriceListHandler) DeletePriceListItem(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var req models.PriceListItemKey
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if _, err := uuid.Parse(req.ProductID); err != nil {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid product uuid")
+		return
+	}
+	if req.MinQuantity == 0 {
+		req.MinQuantity = 1
+	}
+
+	deletedItem, err := h.service.DeletePriceListItem(internal.ActorFromRequest(r), id.String(), req.ProductID, req.MinQuantity)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if deletedItem.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Price list item not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, deletedItem)
+}
+
+func (h *PriceListHandler) GetPriceQuote(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid product uuid")
+		return
+	}
+
+	customerID := r.URL.Query().Get("customer_id")
+	if customerID != "" {
+		if _, err := uuid.Parse(customerID); err != nil {
+			internal.HandleError(w, http.StatusBadRequest, "Invalid customer uuid")
+			return
+		}
+	}
+
+	quantity := 1
+	if value := r.URL.Query().Get("quantity"); value != "" {
+		quantity, err = strconv.Atoi(value)
+		if err != nil || quantity < 1 {
+			internal.HandleError(w, http.StatusBadRequest, "Invalid quantity")
+			return
+		}
+	}
+
+	quote, err := h.service.QuotePrice(id.String(), customerID, quantity)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if quote.ProductID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, quote)
+}
+
+func (h *PriceListHandler) HandlePriceList(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetPriceLists(w, r)
+	case http.MethodPost:
+		h.CreatePriceList(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PriceListHandler) HandlePriceListByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetPriceListByID(w, r)
+	case http.MethodPut:
+		h.UpdatePriceListByID(w, r)
+	case http.MethodDelete:
+		h.DeletePriceListByID(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PriceListHandler) HandlePriceListItems(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetPriceListItems(w, r)
+	case http.MethodPut:
+		h.SetPriceListItem(w, r)
+	case http.MethodDelete:
+		h.DeletePriceListItem(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PriceListHandler) HandlePriceQuote(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetPriceQuote(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -1,15 +1,17 @@
 package handlers
 
 import (
-	"database/sql"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
 	"kasir-api/internal"
 	"kasir-api/models"
 	"kasir-api/services"
 	"log"
+	"mime"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -25,30 +27,28 @@ func NewProductHandler(service *services.ProductService) ProductHandler {
 
 func (h *ProductHandler) GetProducts(w http.ResponseWriter, r *http.Request) {
 	name := r.URL.Query().Get("name")
-	products, err := h.service.GetProducts(name)
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+	products, err := h.service.GetProducts(name, includeArchived)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		internal.WriteError(w, err)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(products)
+	internal.HandleResponse(w, http.StatusOK, products)
 }
 
 func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	var product models.Product
-	err := json.NewDecoder(r.Body).Decode(&product)
+	err := internal.DecodeJSON(r, &product)
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err))
+		internal.WriteError(w, err)
 		return
 	}
-	newProduct, err := h.service.CreateProduct(product)
+	newProduct, err := h.service.CreateProduct(internal.ActorFromRequest(r), product)
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusInternalServerError, fmt.Sprintf("Internal server error: %s", err))
+		internal.WriteError(w, err)
 		return
 	}
+	internal.SetETag(w, newProduct.Version)
 	internal.HandleResponse(w, http.StatusCreated, newProduct)
 }
 
@@ -62,8 +62,7 @@ func (h *ProductHandler) GetProductByID(w http.ResponseWriter, r *http.Request)
 
 	product, err := h.service.GetProductByID(id.String())
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusInternalServerError, "Internal server error")
+		internal.WriteError(w, err)
 		return
 	}
 
@@ -72,9 +71,12 @@ func (h *ProductHandler) GetProductByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	internal.SetETag(w, product.Version)
 	internal.HandleResponse(w, http.StatusOK, product)
 }
 
+// UpdateProductByID replaces the product. When If-Match is sent it must name
+// the current version, otherwise nothing is changed and 412 is returned.
 func (h *ProductHandler) UpdateProductByID(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(mux.Vars(r)["id"])
 	if err != nil {
@@ -84,25 +86,97 @@ func (h *ProductHandler) UpdateProductByID(w http.ResponseWriter, r *http.Reques
 	}
 
 	var product models.Product
-	err = json.NewDecoder(r.Body).Decode(&product)
+	err = internal.DecodeJSON(r, &product)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	product, err = h.service.UpdateProductByID(internal.ActorFromRequest(r), id.String(), product, internal.IfMatch(r))
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if product.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	internal.SetETag(w, product.Version)
+	internal.HandleResponse(w, http.StatusOK, product)
+}
+
+// mergePatchTypes are the content types a JSON Merge Patch may be sent as.
+// Plain application/json is accepted too, as most clients send it by default.
+var mergePatchTypes = []string{"application/merge-patch+json", "application/json"}
+
+// mergePatchTypeMessage answers a patch sent as any other content type.
+var mergePatchTypeMessage = "Content-Type must be " + strings.Join(mergePatchTypes, " or ")
+
+// isMergePatch reports whether the body is declared as one of mergePatchTypes.
+func isMergePatch(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && slices.Contains(mergePatchTypes, mediaType)
+}
+
+// PatchProductByID applies a JSON Merge Patch to the product, so fields left
+// out of the body keep their value. The update is made against the version
+// that was patched, so a concurrent change makes it fail with 412 rather than
+// be overwritten.
+func (h *ProductHandler) PatchProductByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+	if !isMergePatch(r) {
+		internal.HandleError(w, http.StatusUnsupportedMediaType, mergePatchTypeMessage)
+		return
+	}
+	patch, err := io.ReadAll(r.Body)
 	if err != nil {
 		log.Println(err)
 		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	product, err = h.service.UpdateProductByID(id.String(), product)
+	current, err := h.service.GetProductByID(id.String())
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusInternalServerError, "Internal server error")
+		internal.WriteError(w, err)
+		return
+	}
+	if current.ID == "" || current.DeletedAt != nil {
+		internal.HandleError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+	ifMatch := internal.IfMatch(r)
+	if ifMatch != nil && !slices.Contains(ifMatch, current.Version) {
+		internal.HandleError(w, http.StatusPreconditionFailed, "Product has changed, fetch it again and retry")
+		return
+	}
+
+	product, err := internal.MergePatch(current, patch)
+	if err == nil {
+		err = internal.Validate(product)
+	}
+	if err != nil {
+		internal.WriteError(w, err)
 		return
 	}
 
+	product, err = h.service.UpdateProductByID(internal.ActorFromRequest(r), id.String(), product, []int64{current.Version})
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
 	if product.ID == "" {
 		internal.HandleError(w, http.StatusNotFound, "Product not found")
 		return
 	}
 
+	internal.SetETag(w, product.Version)
 	internal.HandleResponse(w, http.StatusOK, product)
 }
 
@@ -114,10 +188,9 @@ func (h *ProductHandler) DeleteProductByID(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	deletedProduct, err := h.service.DeleteProductByID(id.String())
+	deletedProduct, err := h.service.DeleteProductByID(internal.ActorFromRequest(r), id.String(), internal.IfMatch(r))
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusInternalServerError, "Internal server error")
+		internal.WriteError(w, err)
 		return
 	}
 
@@ -126,9 +199,103 @@ func (h *ProductHandler) DeleteProductByID(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	internal.SetETag(w, deletedProduct.Version)
 	internal.HandleResponse(w, http.StatusOK, deletedProduct)
 }
 
+func (h *ProductHandler) RestoreProductByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	restoredProduct, err := h.service.RestoreProductByID(internal.ActorFromRequest(r), id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if restoredProduct.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Archived product not found")
+		return
+	}
+
+	internal.SetETag(w, restoredProduct.Version)
+	internal.HandleResponse(w, http.StatusOK, restoredProduct)
+}
+
+func (h *ProductHandler) HandleRestoreProduct(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.RestoreProductByID(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+const maxImportSize = 20 << 20
+
+// ImportProducts accepts a CSV or XLSX file either as a multipart "file" field
+// or as the raw request body.
+func (h *ProductHandler) ImportProducts(w http.ResponseWriter, r *http.Request) {
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	var records [][]string
+	var err error
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, header, formErr := r.FormFile("file")
+		if formErr != nil {
+			log.Println(formErr)
+			internal.HandleError(w, http.StatusBadRequest, "Missing file")
+			return
+		}
+		defer file.Close()
+
+		format := internal.SpreadsheetFormat(header.Filename, header.Header.Get("Content-Type"))
+		if format == "" {
+			internal.HandleError(w, http.StatusBadRequest, "Unsupported file type, expected csv or xlsx")
+			return
+		}
+		records, err = internal.ReadSpreadsheet(file, format)
+	} else {
+		format := internal.SpreadsheetFormat("", r.Header.Get("Content-Type"))
+		if format == "" {
+			internal.HandleError(w, http.StatusUnsupportedMediaType, "Unsupported file type, expected csv or xlsx")
+			return
+		}
+		records, err = internal.ReadSpreadsheet(r.Body, format)
+	}
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, fmt.Sprintf("Invalid file: %s", err))
+		return
+	}
+
+	result, err := h.service.ImportProducts(internal.ActorFromRequest(r), records, dryRun)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if !dryRun && len(result.Errors) > 0 {
+		internal.HandleResponse(w, http.StatusUnprocessableEntity, result)
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, result)
+}
+
+func (h *ProductHandler) HandleProductImport(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.ImportProducts(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
 func (h *ProductHandler) HandleProduct(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
@@ -146,6 +313,8 @@ func (h *ProductHandler) HandleProductByID(w http.ResponseWriter, r *http.Reques
 		h.GetProductByID(w, r)
 	case http.MethodPut:
 		h.UpdateProductByID(w, r)
+	case http.MethodPatch:
+		h.PatchProductByID(w, r)
 	case http.MethodDelete:
 		h.DeleteProductByID(w, r)
 	default:
@@ -161,9 +330,7 @@ func (h *ProductHandler) AddCategoryToProduct(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	var req struct {
-		CategoryID string `json:"category_id"`
-	}
+	var req models.ProductCategoryRequest
 	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		log.Println(err)
@@ -178,14 +345,9 @@ func (h *ProductHandler) AddCategoryToProduct(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	err = h.service.AddCategoryToProduct(id.String(), req.CategoryID)
+	err = h.service.AddCategoryToProduct(internal.ActorFromRequest(r), id.String(), req.CategoryID)
 	if err != nil {
-		log.Println(err)
-		if errors.Is(err, sql.ErrNoRows) {
-			internal.HandleError(w, http.StatusNotFound, "Product or category not found")
-			return
-		}
-		internal.HandleError(w, http.StatusInternalServerError, "Internal server error")
+		internal.WriteError(w, err)
 		return
 	}
 
@@ -201,9 +363,7 @@ func (h *ProductHandler) RemoveCategoryFromProduct(w http.ResponseWriter, r *htt
 		return
 	}
 
-	var req struct {
-		CategoryID string `json:"category_id"`
-	}
+	var req models.ProductCategoryRequest
 	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		log.Println(err)
@@ -218,10 +378,9 @@ func (h *ProductHandler) RemoveCategoryFromProduct(w http.ResponseWriter, r *htt
 		return
 	}
 
-	err = h.service.RemoveCategoryFromProduct(id.String(), req.CategoryID)
+	err = h.service.RemoveCategoryFromProduct(internal.ActorFromRequest(r), id.String(), req.CategoryID)
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusInternalServerError, "Internal server error")
+		internal.WriteError(w, err)
 		return
 	}
 
@@ -239,8 +398,7 @@ func (h *ProductHandler) GetProductCategories(w http.ResponseWriter, r *http.Req
 	// Check if product exists
 	product, err := h.service.GetProductByID(id.String())
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusInternalServerError, "Internal server error")
+		internal.WriteError(w, err)
 		return
 	}
 
@@ -251,8 +409,7 @@ func (h *ProductHandler) GetProductCategories(w http.ResponseWriter, r *http.Req
 
 	categories, err := h.service.GetCategoriesByProductID(id.String())
 	if err != nil {
-		log.Println(err)
-		internal.HandleError(w, http.StatusInternalServerError, "Internal server error")
+		internal.WriteError(w, err)
 		return
 	}
 
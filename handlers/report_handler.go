@@ -1,9 +1,16 @@
 package handlers
 
 import (
+	"fmt"
 	"kasir-api/internal"
+	"kasir-api/models"
 	"kasir-api/services"
+	"math"
 	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 )
 
 type ReportHandler struct {
@@ -15,37 +22,293 @@ func NewReportHandler(service *services.ReportService) *ReportHandler {
 }
 
 func (h *ReportHandler) GetReports(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := internal.ParseDateRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		internal.HandleError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.service.GetReportsRange(dateRange)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, report)
+
+}
+
+func (h *ReportHandler) GetReportToday(w http.ResponseWriter, r *http.Request) {
+	report, err := h.service.GetReportToday()
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, report)
+}
+
+func (h *ReportHandler) GetSalesReport(w http.ResponseWriter, r *http.Request) {
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy == "" {
+		groupBy = models.GroupByDay
+	}
+	switch groupBy {
+	case models.GroupByHour, models.GroupByDay, models.GroupByWeek, models.GroupByMonth, models.GroupByProduct, models.GroupByCategory:
+	case models.GroupByCashier:
+		internal.HandleError(w, http.StatusBadRequest, "Grouping by cashier is not available, transactions do not record a cashier yet")
+		return
+	default:
+		internal.HandleError(w, http.StatusBadRequest, "Invalid group_by, expected hour, day, week, month, product or category")
+		return
+	}
+
 	from := r.URL.Query().Get("from")
 	to := r.URL.Query().Get("to")
+	dateRange, err := internal.ParseDateRange(from, to)
+	if err != nil {
+		internal.HandleError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	if from != "" && !internal.IsDateValid(from) {
-		internal.HandleError(w, http.StatusBadRequest, "Invalid from date")
+	report, err := h.service.GetSalesReport(groupBy, dateRange)
+	if err != nil {
+		internal.WriteError(w, err)
 		return
 	}
+	report.From = from
+	report.To = to
+	internal.HandleResponse(w, http.StatusOK, report)
+}
 
-	if to != "" && !internal.IsDateValid(to) {
-		internal.HandleError(w, http.StatusBadRequest, "Invalid to date")
+func (h *ReportHandler) HandleSalesReport(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetSalesReport(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+const (
+	defaultRankingLimit   = 10
+	maxRankingLimit       = 100
+	defaultDeadStockDays  = 90
+	defaultABCThresholdA  = 80
+	defaultABCThresholdB  = 95
+	defaultBasketMinCount = 2
+)
+
+// queryInt reads a non-negative integer query parameter, falling back to def
+// when it is absent.
+func queryInt(r *http.Request, name string, def int, max int) (int, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, true
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 || parsed > max {
+		return 0, false
+	}
+	return parsed, true
+}
+
+func (h *ReportHandler) GetProductRanking(w http.ResponseWriter, r *http.Request) {
+	metric := r.URL.Query().Get("metric")
+	if metric == "" {
+		metric = models.RankByQuantity
+	}
+	if metric != models.RankByQuantity && metric != models.RankByRevenue && metric != models.RankByMargin {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid metric, expected quantity, revenue or margin")
 		return
 	}
 
-	report, err := h.service.GetReportsRange(from, to)
+	top, ok := queryInt(r, "top", defaultRankingLimit, maxRankingLimit)
+	if !ok {
+		internal.HandleError(w, http.StatusBadRequest, fmt.Sprintf("Invalid top, expected 0 to %d", maxRankingLimit))
+		return
+	}
+	bottom, ok := queryInt(r, "bottom", defaultRankingLimit, maxRankingLimit)
+	if !ok {
+		internal.HandleError(w, http.StatusBadRequest, fmt.Sprintf("Invalid bottom, expected 0 to %d", maxRankingLimit))
+		return
+	}
+
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	dateRange, err := internal.ParseDateRange(from, to)
 	if err != nil {
-		internal.HandleError(w, http.StatusInternalServerError, err.Error())
+		internal.HandleError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+
+	report, err := h.service.GetProductRanking(metric, top, bottom, dateRange)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	report.From = from
+	report.To = to
 	internal.HandleResponse(w, http.StatusOK, report)
+}
 
+func (h *ReportHandler) GetDeadStock(w http.ResponseWriter, r *http.Request) {
+	days, ok := queryInt(r, "days", defaultDeadStockDays, 3650)
+	if !ok || days == 0 {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid days")
+		return
+	}
+
+	report, err := h.service.GetDeadStock(days)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, report)
 }
 
-func (h *ReportHandler) GetReportToday(w http.ResponseWriter, r *http.Request) {
-	report, err := h.service.GetReportToday()
+// queryPercent reads a percentage query parameter between 0 and 100, falling
+// back to def when it is absent.
+func queryPercent(r *http.Request, name string, def float64) (float64, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, true
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed <= 0 || parsed > 100 {
+		return 0, false
+	}
+	return parsed, true
+}
+
+func (h *ReportHandler) GetABCReport(w http.ResponseWriter, r *http.Request) {
+	thresholdA, ok := queryPercent(r, "a", defaultABCThresholdA)
+	if !ok {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid a, expected a percentage")
+		return
+	}
+	thresholdB, ok := queryPercent(r, "b", defaultABCThresholdB)
+	if !ok || thresholdB < thresholdA {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid b, expected a percentage not below a")
+		return
+	}
+
+	dateRange, err := internal.ParseDateRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		internal.HandleError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.service.GetABCReport(dateRange, thresholdA, thresholdB)
 	if err != nil {
-		internal.HandleError(w, http.StatusInternalServerError, err.Error())
+		internal.WriteError(w, err)
 		return
 	}
 	internal.HandleResponse(w, http.StatusOK, report)
 }
 
+func (h *ReportHandler) HandleABCReport(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetABCReport(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// parseBasketQuery reads the from, to, min_count and limit parameters shared
+// by the basket analysis endpoints and writes the error response itself when
+// they are invalid.
+func parseBasketQuery(w http.ResponseWriter, r *http.Request) (models.DateRange, int, int, bool) {
+	minCount, ok := queryInt(r, "min_count", defaultBasketMinCount, math.MaxInt32)
+	if !ok || minCount == 0 {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid min_count")
+		return models.DateRange{}, 0, 0, false
+	}
+	limit, ok := queryInt(r, "limit", defaultRankingLimit, maxRankingLimit)
+	if !ok {
+		internal.HandleError(w, http.StatusBadRequest, fmt.Sprintf("Invalid limit, expected 0 to %d", maxRankingLimit))
+		return models.DateRange{}, 0, 0, false
+	}
+	dateRange, err := internal.ParseDateRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		internal.HandleError(w, http.StatusBadRequest, err.Error())
+		return models.DateRange{}, 0, 0, false
+	}
+	return dateRange, minCount, limit, true
+}
+
+func (h *ReportHandler) GetBasketReport(w http.ResponseWriter, r *http.Request) {
+	dateRange, minCount, limit, ok := parseBasketQuery(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetProductPairs(dateRange, minCount, limit)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	report.From = r.URL.Query().Get("from")
+	report.To = r.URL.Query().Get("to")
+	internal.HandleResponse(w, http.StatusOK, report)
+}
+
+func (h *ReportHandler) GetFrequentlyBoughtWith(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+	dateRange, minCount, limit, ok := parseBasketQuery(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.GetFrequentlyBoughtWith(id.String(), dateRange, minCount, limit)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	result.From = r.URL.Query().Get("from")
+	result.To = r.URL.Query().Get("to")
+	internal.HandleResponse(w, http.StatusOK, result)
+}
+
+func (h *ReportHandler) HandleBasketReport(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetBasketReport(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ReportHandler) HandleFrequentlyBoughtWith(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetFrequentlyBoughtWith(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ReportHandler) HandleProductRanking(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetProductRanking(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ReportHandler) HandleDeadStock(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetDeadStock(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
 func (h *ReportHandler) HandleReport(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
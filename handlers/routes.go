@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Routes holds the handlers served over HTTP. GraphQL is the handler built by
+// graphqlserver.New.
+type Routes struct {
+	Health      *HealthHandler
+	Docs        *DocsHandler
+	GraphQL     http.Handler
+	Product     ProductHandler
+	Category    CategoryHandler
+	Lot         LotHandler
+	Serial      SerialHandler
+	Price       PriceHandler
+	PriceList   PriceListHandler
+	Customer    CustomerHandler
+	Forecast    ForecastHandler
+	Transaction TransactionHandler
+	Event       EventHandler
+	Webhook     WebhookHandler
+	Audit       AuditHandler
+	Export      ExportHandler
+	Report      *ReportHandler
+}
+
+// RegisterRoutes registers every route of the API on r. Each path has to be
+// documented in APIOperations.
+func RegisterRoutes(r *mux.Router, h Routes) {
+	r.HandleFunc("/healthz", h.Health.HandleHealth)
+	r.HandleFunc("/openapi.json", h.Docs.HandleOpenAPI)
+	r.HandleFunc("/docs", h.Docs.HandleDocs)
+	r.HandleFunc("/docs/{file}", h.Docs.HandleDocsAsset)
+	r.Handle("/graphql", h.GraphQL)
+
+	r.HandleFunc("/api/products", h.Product.HandleProduct)
+	r.HandleFunc("/api/products/import", h.Product.HandleProductImport)
+	r.HandleFunc("/api/products/{id}", h.Product.HandleProductByID)
+	r.HandleFunc("/api/products/{id}/categories", h.Product.HandleProductCategories)
+	r.HandleFunc("/api/products/{id}/restore", h.Product.HandleRestoreProduct)
+	r.HandleFunc("/api/products/{id}/lots", h.Lot.HandleProductLots)
+	r.HandleFunc("/api/products/{id}/serials", h.Serial.HandleProductSerials)
+	r.HandleFunc("/api/products/{id}/prices", h.Price.HandleProductPrices)
+	r.HandleFunc("/api/products/{id}/prices/{price_id}", h.Price.HandleProductPriceByID)
+	r.HandleFunc("/api/products/{id}/price-history", h.Price.HandlePriceHistory)
+	r.HandleFunc("/api/products/{id}/quote", h.PriceList.HandlePriceQuote)
+	r.HandleFunc("/api/products/{id}/frequently-bought-with", h.Report.HandleFrequentlyBoughtWith)
+	r.HandleFunc("/api/products/{id}/forecast", h.Forecast.HandleProductForecast)
+	r.HandleFunc("/api/products/{id}/lead-time", h.Forecast.HandleLeadTime)
+
+	r.HandleFunc("/api/categories", h.Category.HandleCategory)
+	r.HandleFunc("/api/categories/{id}", h.Category.HandleCategoryByID)
+	r.HandleFunc("/api/categories/{id}/products", h.Category.GetProductsByCategory)
+	r.HandleFunc("/api/categories/{id}/restore", h.Category.HandleRestoreCategory)
+
+	r.HandleFunc("/api/serials/{serial}", h.Serial.HandleSerial)
+
+	r.HandleFunc("/api/price-lists", h.PriceList.HandlePriceList)
+	r.HandleFunc("/api/price-lists/{id}", h.PriceList.HandlePriceListByID)
+	r.HandleFunc("/api/price-lists/{id}/items", h.PriceList.HandlePriceListItems)
+
+	r.HandleFunc("/api/customer-groups", h.Customer.HandleCustomerGroup)
+	r.HandleFunc("/api/customer-groups/{id}", h.Customer.HandleCustomerGroupByID)
+	r.HandleFunc("/api/customers", h.Customer.HandleCustomer)
+	r.HandleFunc("/api/customers/{id}", h.Customer.HandleCustomerByID)
+
+	r.HandleFunc("/api/forecasts", h.Forecast.HandleForecasts)
+
+	r.HandleFunc("/api/checkout", h.Transaction.HandleCheckout)
+	r.HandleFunc("/api/transactions", h.Transaction.GetTransactions)
+
+	r.HandleFunc("/api/events", h.Event.HandleEvents)
+
+	r.HandleFunc("/api/webhooks", h.Webhook.HandleWebhooks)
+	r.HandleFunc("/api/webhooks/deliveries", h.Webhook.HandleDeliveries)
+	r.HandleFunc("/api/webhooks/deliveries/{id}/retry", h.Webhook.HandleRetryDelivery)
+	r.HandleFunc("/api/webhooks/{id}", h.Webhook.HandleWebhookByID)
+
+	r.HandleFunc("/api/audit", h.Audit.HandleAuditLog)
+	r.HandleFunc("/api/audit/verify", h.Audit.HandleVerifyAuditLog)
+
+	r.HandleFunc("/api/export/products", h.Export.HandleExportProducts)
+	r.HandleFunc("/api/export/transactions", h.Export.HandleExportTransactions)
+	r.HandleFunc("/api/export/transaction-items", h.Export.HandleExportTransactionDetails)
+
+	r.HandleFunc("/api/reports", h.Report.HandleReport)
+	r.HandleFunc("/api/reports/today", h.Report.GetReportToday)
+	r.HandleFunc("/api/reports/sales", h.Report.HandleSalesReport)
+	r.HandleFunc("/api/reports/products", h.Report.HandleProductRanking)
+	r.HandleFunc("/api/reports/dead-stock", h.Report.HandleDeadStock)
+	r.HandleFunc("/api/reports/abc", h.Report.HandleABCReport)
+	r.HandleFunc("/api/reports/basket", h.Report.HandleBasketReport)
+	r.HandleFunc("/api/reports/expiring", h.Lot.HandleExpiringLots)
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/services"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type SerialHandler struct {
+	service *services.SerialService
+}
+
+func NewSerialHandler(service *services.SerialService) SerialHandler {
+	return SerialHandler{service: service}
+}
+
+func (h *SerialHandler) GetProductSerials(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid product uuid")
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status != "" && status != models.SerialStatusInStock && status != models.SerialStatusSold {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid status")
+		return
+	}
+
+	serials, err := h.service.GetSerialsByProductID(id.String(), status)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, serials)
+}
+
+func (h *SerialHandler) ReceiveSerials(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid product uuid")
+		return
+	}
+
+	var req models.ReceiveSerialsRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if len(req.SerialNumbers) == 0 {
+		internal.HandleError(w, http.StatusBadRequest, "Serial numbers are required")
+		return
+	}
+	seen := make(map[string]bool, len(req.SerialNumbers))
+	for i, serialNumber := range req.SerialNumbers {
+		serialNumber = strings.TrimSpace(serialNumber)
+		if serialNumber == "" {
+			internal.HandleError(w, http.StatusBadRequest, "Serial number must not be empty")
+			return
+		}
+		if seen[serialNumber] {
+			internal.HandleError(w, http.StatusBadRequest, "Duplicate serial number "+serialNumber)
+			return
+		}
+		seen[serialNumber] = true
+		req.SerialNumbers[i] = serialNumber
+	}
+
+	serials, err := h.service.ReceiveSerials(internal.ActorFromRequest(r), id.String(), req.SerialNumbers)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if serials == nil {
+		internal.HandleError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusCreated, serials)
+}
+
+func (h *SerialHandler) GetSerialHistory(w http.ResponseWriter, r *http.Request) {
+	serialNumber := mux.Vars(r)["serial"]
+
+	history, err := h.service.GetSerialHistory(serialNumber)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	if history.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Serial not found")
+		return
+	}
+
+	internal.HandleResponse(w, http.StatusOK, history)
+}
+
+func (h *SerialHandler) HandleProductSerials(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetProductSerials(w, r)
+	case http.MethodPost:
+		h.ReceiveSerials(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *SerialHandler) HandleSerial(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetSerialHistory(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
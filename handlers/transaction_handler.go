@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
 	"kasir-api/internal"
 	"kasir-api/models"
@@ -17,16 +18,31 @@ func NewTransactionHandler(service *services.TransactionService) TransactionHand
 }
 
 func (h *TransactionHandler) Checkout(w http.ResponseWriter, r *http.Request) {
-	var req []models.CheckoutItem
-	err := json.NewDecoder(r.Body).Decode(&req)
+	var body json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
 		internal.HandleError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	checkout, err := h.service.Checkout(req)
+	// A bare array of items is still accepted for walk-in sales.
+	var req models.CheckoutRequest
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = internal.StrictUnmarshal(body, &req.Items)
+	} else {
+		err = internal.StrictUnmarshal(body, &req)
+	}
+	if err == nil {
+		err = internal.Validate(req)
+	}
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+
+	checkout, err := h.service.Checkout(internal.ActorFromRequest(r), req)
 	if err != nil {
-		internal.HandleError(w, http.StatusInternalServerError, err.Error())
+		internal.WriteError(w, err)
 		return
 	}
 
@@ -45,27 +61,24 @@ func (h *TransactionHandler) HandleCheckout(w http.ResponseWriter, r *http.Reque
 func (h *TransactionHandler) GetTransactions(w http.ResponseWriter, r *http.Request) {
 	from := r.URL.Query().Get("from")
 	to := r.URL.Query().Get("to")
-	if from == "" || to == "" {
+	if from == "" && to == "" {
 		transactions, err := h.service.GetTransactions()
 		if err != nil {
-			internal.HandleError(w, http.StatusInternalServerError, err.Error())
+			internal.WriteError(w, err)
 			return
 		}
 		internal.HandleResponse(w, http.StatusOK, transactions)
 		return
 	}
-	if from != "" && !internal.IsDateValid(from) {
-		internal.HandleError(w, http.StatusBadRequest, "Invalid from date")
-		return
-	}
-	if to != "" && !internal.IsDateValid(to) {
-		internal.HandleError(w, http.StatusBadRequest, "Invalid to date")
+	dateRange, err := internal.ParseDateRange(from, to)
+	if err != nil {
+		internal.HandleError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	transactions, err := h.service.GetTransactionsRange(from, to)
+	transactions, err := h.service.GetTransactionsRange(dateRange)
 	if err != nil {
-		internal.HandleError(w, http.StatusInternalServerError, err.Error())
+		internal.WriteError(w, err)
 		return
 	}
 	internal.HandleResponse(w, http.StatusOK, transactions)
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/services"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const defaultDeliveryLimit = 100
+
+type WebhookHandler struct {
+	service *services.WebhookService
+}
+
+func NewWebhookHandler(service *services.WebhookService) WebhookHandler {
+	return WebhookHandler{service: service}
+}
+
+// parseWebhookRequest decodes and validates a subscription body and writes the
+// error response itself when it is invalid.
+func parseWebhookRequest(w http.ResponseWriter, r *http.Request) (models.WebhookSubscription, bool) {
+	var request models.WebhookRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "Invalid request body")
+		return models.WebhookSubscription{}, false
+	}
+
+	target, err := url.Parse(request.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		internal.HandleError(w, http.StatusBadRequest, "URL must be an absolute http or https URL")
+		return models.WebhookSubscription{}, false
+	}
+	err = internal.CheckWebhookHost(r.Context(), target.Hostname())
+	if errors.Is(err, internal.ErrWebhookAddressBlocked) {
+		internal.HandleError(w, http.StatusBadRequest, "URL must resolve to a public address, not a loopback, private or link-local one")
+		return models.WebhookSubscription{}, false
+	}
+	if err != nil {
+		log.Println(err)
+		internal.HandleError(w, http.StatusBadRequest, "URL host could not be resolved")
+		return models.WebhookSubscription{}, false
+	}
+
+	eventTypes := make([]string, 0, len(request.EventTypes))
+	for _, eventType := range request.EventTypes {
+		if !slices.Contains(models.OutboxEventTypes, eventType) {
+			internal.HandleError(w, http.StatusBadRequest, fmt.Sprintf("Unknown event type %q, expected one of %s", eventType, strings.Join(models.OutboxEventTypes, ", ")))
+			return models.WebhookSubscription{}, false
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	webhook := models.WebhookSubscription{URL: request.URL, Secret: request.Secret, EventTypes: eventTypes, Active: true}
+	if request.Active != nil {
+		webhook.Active = *request.Active
+	}
+	return webhook, true
+}
+
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.service.GetWebhooks()
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, webhooks)
+}
+
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := parseWebhookRequest(w, r)
+	if !ok {
+		return
+	}
+
+	newWebhook, err := h.service.CreateWebhook(internal.ActorFromRequest(r), webhook)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusCreated, newWebhook)
+}
+
+func (h *WebhookHandler) GetWebhookByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	webhook, err := h.service.GetWebhookByID(id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	if webhook.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) UpdateWebhookByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+	webhook, ok := parseWebhookRequest(w, r)
+	if !ok {
+		return
+	}
+
+	updated, err := h.service.UpdateWebhookByID(internal.ActorFromRequest(r), id.String(), webhook)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	if updated.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, updated)
+}
+
+func (h *WebhookHandler) DeleteWebhookByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	deleted, err := h.service.DeleteWebhookByID(internal.ActorFromRequest(r), id.String())
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	if deleted.ID == "" {
+		internal.HandleError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, deleted)
+}
+
+// GetDeliveries lists deliveries by status. It defaults to dead deliveries,
+// which is the dead-letter view.
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = models.DeliveryDead
+	}
+	if status != models.DeliveryPending && status != models.DeliveryDelivered && status != models.DeliveryDead {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid status, expected pending, delivered or dead")
+		return
+	}
+	limit, ok := queryInt(r, "limit", defaultDeliveryLimit, 1000)
+	if !ok {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid limit, expected 0 to 1000")
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(status, limit)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	internal.HandleResponse(w, http.StatusOK, deliveries)
+}
+
+func (h *WebhookHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		internal.HandleError(w, http.StatusBadRequest, "Invalid delivery id")
+		return
+	}
+
+	found, err := h.service.RetryDelivery(id)
+	if err != nil {
+		internal.WriteError(w, err)
+		return
+	}
+	if !found {
+		internal.HandleError(w, http.StatusNotFound, "Dead delivery not found")
+		return
+	}
+	internal.HandleResponse(w, http.StatusAccepted, map[string]string{"status": models.DeliveryPending})
+}
+
+func (h *WebhookHandler) HandleWebhooks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetWebhooks(w, r)
+	case http.MethodPost:
+		h.CreateWebhook(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *WebhookHandler) HandleWebhookByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetWebhookByID(w, r)
+	case http.MethodPut:
+		h.UpdateWebhookByID(w, r)
+	case http.MethodDelete:
+		h.DeleteWebhookByID(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *WebhookHandler) HandleDeliveries(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetDeliveries(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *WebhookHandler) HandleRetryDelivery(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.RetryDelivery(w, r)
+	default:
+		internal.HandleError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
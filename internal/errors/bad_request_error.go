@@ -0,0 +1,22 @@
+package errors
+
+import "errors"
+
+// BadRequestError reports a request body that cannot be read at all, as
+// opposed to one with invalid fields.
+type BadRequestError struct {
+	Message string
+}
+
+func (e *BadRequestError) Error() string {
+	return e.Message
+}
+
+func NewBadRequestError(message string) error {
+	return &BadRequestError{Message: message}
+}
+
+func IsBadRequestError(err error) bool {
+	var target *BadRequestError
+	return errors.As(err, &target)
+}
@@ -0,0 +1,24 @@
+package errors
+
+import "errors"
+
+// ConflictError reports that the request cannot be applied to the current
+// state, such as selling a serial number that is already sold. Code is the
+// machine readable reason.
+type ConflictError struct {
+	Code    string
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+func NewConflictError(code, message string) error {
+	return &ConflictError{Code: code, Message: message}
+}
+
+func IsConflictError(err error) bool {
+	var target *ConflictError
+	return errors.As(err, &target)
+}
@@ -1,5 +1,7 @@
 package errors
 
+import "errors"
+
 type DuplicateError struct {
 	Field string
 	Value string
@@ -14,6 +16,6 @@ func NewDuplicateError(field, value string) error {
 }
 
 func IsDuplicateError(err error) bool {
-	_, ok := err.(*DuplicateError)
-	return ok
+	var target *DuplicateError
+	return errors.As(err, &target)
 }
@@ -0,0 +1,28 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// InsufficientStockError reports that a checkout item asks for more than can
+// be sold. Field is the request field of the item's quantity.
+type InsufficientStockError struct {
+	Field     string
+	ProductID string
+	Available int
+	Requested int
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock for product %s: %d available but %d requested", e.ProductID, e.Available, e.Requested)
+}
+
+func NewInsufficientStockError(field, productID string, available, requested int) error {
+	return &InsufficientStockError{Field: field, ProductID: productID, Available: available, Requested: requested}
+}
+
+func IsInsufficientStockError(err error) bool {
+	var target *InsufficientStockError
+	return errors.As(err, &target)
+}
@@ -0,0 +1,23 @@
+package errors
+
+import "errors"
+
+// NotFoundError reports that an entity referenced by the request does not
+// exist, for example a product listed in a checkout.
+type NotFoundError struct {
+	Entity string
+	ID     string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Entity + " " + e.ID + " not found"
+}
+
+func NewNotFoundError(entity, id string) error {
+	return &NotFoundError{Entity: entity, ID: id}
+}
+
+func IsNotFoundError(err error) bool {
+	var target *NotFoundError
+	return errors.As(err, &target)
+}
@@ -0,0 +1,30 @@
+package errors
+
+import (
+	"errors"
+	"kasir-api/models"
+	"strings"
+)
+
+// ValidationError carries every invalid field of a request so clients can
+// show them all at once.
+type ValidationError struct {
+	Fields []models.FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+func NewValidationError(fields ...models.FieldError) error {
+	return &ValidationError{Fields: fields}
+}
+
+func IsValidationError(err error) bool {
+	var target *ValidationError
+	return errors.As(err, &target)
+}
@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"errors"
+	"strconv"
+)
+
+// VersionMismatchError reports that a row changed since the client read it.
+type VersionMismatchError struct {
+	Current int64
+}
+
+func (e *VersionMismatchError) Error() string {
+	return "version mismatch: current version is " + strconv.FormatInt(e.Current, 10)
+}
+
+func NewVersionMismatchError(current int64) error {
+	return &VersionMismatchError{Current: current}
+}
+
+func IsVersionMismatchError(err error) bool {
+	var target *VersionMismatchError
+	return errors.As(err, &target)
+}
@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func SetETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", `"`+strconv.FormatInt(version, 10)+`"`)
+}
+
+// IfMatch returns the versions listed in the If-Match header, or nil when the
+// header is missing or "*" and any version is accepted. Weak and malformed
+// tags never match, so a header made only of those yields an empty list.
+func IfMatch(r *http.Request) []int64 {
+	header := r.Header.Get("If-Match")
+	if header == "" || strings.TrimSpace(header) == "*" {
+		return nil
+	}
+	versions := make([]int64, 0)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	return versions
+}
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"kasir-api/models"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultLowStockThreshold = 5
+	eventHistorySize         = 1000
+	subscriberBufferSize     = 64
+)
+
+var lowStockThreshold = DefaultLowStockThreshold
+
+// SetLowStockThreshold changes the stock level at or below which a low stock
+// event is published.
+func SetLowStockThreshold(threshold int) {
+	lowStockThreshold = threshold
+}
+
+func LowStockThreshold() int {
+	return lowStockThreshold
+}
+
+// EventBroker fans published events out to subscribers and keeps the most
+// recent ones so a reconnecting client can catch up from its last event id.
+// A subscriber that falls too far behind is dropped and has to reconnect.
+type EventBroker struct {
+	mu          sync.Mutex
+	lastID      int64
+	history     []models.Event
+	subscribers map[chan models.Event]struct{}
+}
+
+func NewEventBroker() *EventBroker {
+	// Ids start at the current time so they keep increasing across restarts.
+	return &EventBroker{
+		lastID:      time.Now().UnixMicro(),
+		subscribers: make(map[chan models.Event]struct{}),
+	}
+}
+
+func (b *EventBroker) Publish(eventType string, data any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event := models.Event{ID: b.lastID, Type: eventType, Data: data, CreatedAt: time.Now()}
+	b.history = append(b.history, event)
+	if len(b.history) > eventHistorySize {
+		b.history = b.history[len(b.history)-eventHistorySize:]
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribe returns the events after lastEventID that are still in the
+// history, a channel for the ones that follow and a function to unsubscribe.
+// A lastEventID of 0 skips the history.
+func (b *EventBroker) Subscribe(lastEventID int64) ([]models.Event, <-chan models.Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var missed []models.Event
+	if lastEventID > 0 {
+		for _, event := range b.history {
+			if event.ID > lastEventID {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	ch := make(chan models.Event, subscriberBufferSize)
+	b.subscribers[ch] = struct{}{}
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+	return missed, ch, cancel
+}
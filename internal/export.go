@@ -0,0 +1,148 @@
+package internal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const FormatJSONL = "jsonl"
+
+var exportContentTypes = map[string]string{
+	FormatCSV:   "text/csv",
+	FormatXLSX:  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatJSONL: "application/x-ndjson",
+}
+
+func IsExportFormatValid(format string) bool {
+	_, ok := exportContentTypes[format]
+	return ok
+}
+
+// Exporter writes records one at a time in CSV, XLSX or JSON Lines. CSV and
+// JSON Lines go straight to the response. XLSX rows are buffered by excelize,
+// which spills to a temporary file for large sheets, and the workbook is
+// written out on Close.
+type Exporter struct {
+	format  string
+	w       io.Writer
+	rows    int
+	csv     *csv.Writer
+	json    *json.Encoder
+	file    *excelize.File
+	sheet   *excelize.StreamWriter
+	flusher http.Flusher
+}
+
+// NewExporter sets the download headers on w and writes the column header row
+// for tabular formats.
+func NewExporter(w http.ResponseWriter, format string, filename string, columns []string) (*Exporter, error) {
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+
+	e := &Exporter{format: format, w: w}
+	e.flusher, _ = w.(http.Flusher)
+
+	switch format {
+	case FormatCSV:
+		e.csv = csv.NewWriter(w)
+	case FormatJSONL:
+		e.json = json.NewEncoder(w)
+	case FormatXLSX:
+		e.file = excelize.NewFile()
+		sheet, err := e.file.NewStreamWriter("Sheet1")
+		if err != nil {
+			return nil, err
+		}
+		e.sheet = sheet
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+"."+format))
+	w.WriteHeader(http.StatusOK)
+
+	if format != FormatJSONL {
+		header := make([]any, len(columns))
+		for i, column := range columns {
+			header[i] = column
+		}
+		err := e.writeRow(header)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Write emits one record. JSON Lines encodes record as is, the tabular formats
+// use row, which must follow the column order given to NewExporter.
+func (e *Exporter) Write(record any, row []any) error {
+	if e.format == FormatJSONL {
+		err := e.json.Encode(record)
+		if err != nil {
+			return err
+		}
+		e.rows++
+		e.flush()
+		return nil
+	}
+	return e.writeRow(row)
+}
+
+func (e *Exporter) writeRow(row []any) error {
+	var err error
+	switch e.format {
+	case FormatCSV:
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = fmt.Sprint(value)
+		}
+		err = e.csv.Write(record)
+	case FormatXLSX:
+		cell, cellErr := excelize.CoordinatesToCellName(1, e.rows+1)
+		if cellErr != nil {
+			return cellErr
+		}
+		err = e.sheet.SetRow(cell, row)
+	}
+	if err != nil {
+		return err
+	}
+	e.rows++
+	e.flush()
+	return nil
+}
+
+func (e *Exporter) flush() {
+	if e.rows%500 != 0 {
+		return
+	}
+	if e.csv != nil {
+		e.csv.Flush()
+	}
+	if e.flusher != nil && e.format != FormatXLSX {
+		e.flusher.Flush()
+	}
+}
+
+func (e *Exporter) Close() error {
+	switch e.format {
+	case FormatCSV:
+		e.csv.Flush()
+		return e.csv.Error()
+	case FormatXLSX:
+		defer e.file.Close()
+		err := e.sheet.Flush()
+		if err != nil {
+			return err
+		}
+		return e.file.Write(e.w)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"encoding/json"
+	apperrors "kasir-api/internal/errors"
+)
+
+// MergePatch applies a JSON Merge Patch (RFC 7386) to current, which must
+// marshal to a JSON object, and decodes the result into a new value. Fields
+// missing from the patch keep their value and null removes a field, which for
+// a struct means its zero value. Like StrictUnmarshal, unknown fields are
+// rejected.
+func MergePatch[T any](current T, patch []byte) (T, error) {
+	var patched T
+	original, err := json.Marshal(current)
+	if err != nil {
+		return patched, err
+	}
+	var document, changes any
+	err = json.Unmarshal(original, &document)
+	if err != nil {
+		return patched, err
+	}
+	err = json.Unmarshal(patch, &changes)
+	if err != nil {
+		return patched, apperrors.NewBadRequestError("Invalid merge patch: " + err.Error())
+	}
+	merged, err := json.Marshal(mergeValue(document, changes))
+	if err != nil {
+		return patched, err
+	}
+	err = StrictUnmarshal(merged, &patched)
+	return patched, err
+}
+
+func mergeValue(document any, patch any) any {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	object, ok := document.(map[string]any)
+	if !ok {
+		object = make(map[string]any)
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(object, key)
+			continue
+		}
+		object[key] = mergeValue(object[key], value)
+	}
+	return object
+}
@@ -0,0 +1,386 @@
+package internal
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIOperation describes one method of one route for the OpenAPI document.
+// Request and Response hold a zero value of the body type, their schemas are
+// generated from the Go types so they follow the models as those change.
+// RequestTypes and ResponseTypes override the application/json media type,
+// a nil body with an override is documented as a raw file. ErrorResponse is
+// the body of an operation that answers its own errors with something other
+// than problem+json, the errors of the shared middleware stay problem+json.
+type APIOperation struct {
+	ID            string
+	Method        string
+	Path          string
+	Tag           string
+	Summary       string
+	Parameters    []APIParameter
+	Request       any
+	RequestTypes  []string
+	Status        int
+	Response      any
+	ResponseTypes []string
+	ErrorResponse any
+	Errors        []int
+	Versioned     bool
+}
+
+// APIParameter is a query, header or path parameter. Path parameters that are
+// not listed are documented as uuids.
+type APIParameter struct {
+	Name        string
+	In          string
+	Type        string
+	Format      string
+	Description string
+	Enum        []string
+	Required    bool
+}
+
+var pathParameterPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// BuildOpenAPI assembles an OpenAPI 3 document from operations. Every
+// operation answers problem+json on 500, on 400 when it takes any input, on
+// 404 when it addresses a resource by path and on 409 when it accepts an
+// Idempotency-Key; Errors adds the rest.
+func BuildOpenAPI(title, version string, operations []APIOperation) map[string]any {
+	schemas := newSchemaRegistry()
+	schemas.ref(reflect.TypeOf(models.Problem{}))
+
+	paths := map[string]map[string]any{}
+	for _, operation := range operations {
+		if paths[operation.Path] == nil {
+			paths[operation.Path] = map[string]any{}
+		}
+		paths[operation.Path][strings.ToLower(operation.Method)] = buildOperation(schemas, operation)
+	}
+
+	responses := map[string]any{}
+	for _, status := range []int{
+		http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed,
+		http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity, http.StatusInternalServerError,
+	} {
+		responses[strconv.Itoa(status)] = map[string]any{
+			"description": http.StatusText(status),
+			"content": map[string]any{
+				"application/problem+json": map[string]any{"schema": schemas.ref(reflect.TypeOf(models.Problem{}))},
+			},
+		}
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info":    map[string]any{"title": title, "version": version},
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas":   schemas.definitions,
+			"responses": responses,
+		},
+	}
+}
+
+func buildOperation(schemas *schemaRegistry, operation APIOperation) map[string]any {
+	result := map[string]any{
+		"operationId": operation.ID,
+		"summary":     operation.Summary,
+		"tags":        []string{operation.Tag},
+	}
+
+	listed := map[string]bool{}
+	var parameters []any
+	for _, parameter := range operation.Parameters {
+		listed[parameter.In+":"+parameter.Name] = true
+		parameters = append(parameters, buildParameter(parameter))
+	}
+	for _, match := range pathParameterPattern.FindAllStringSubmatch(operation.Path, -1) {
+		if !listed["path:"+match[1]] {
+			parameters = append(parameters, buildParameter(APIParameter{Name: match[1], In: "path", Type: "string", Format: "uuid"}))
+		}
+	}
+	conditional := operation.Versioned && (operation.Method == http.MethodPut || operation.Method == http.MethodPatch || operation.Method == http.MethodDelete)
+	if conditional {
+		parameters = append(parameters, buildParameter(APIParameter{
+			Name: "If-Match", In: "header", Type: "string",
+			Description: "ETag of the version being changed, the request fails with 412 when the resource has moved on",
+		}))
+	}
+	if operation.Method == http.MethodPost || operation.Method == http.MethodPatch {
+		parameters = append(parameters, buildParameter(APIParameter{
+			Name: "Idempotency-Key", In: "header", Type: "string",
+			Description: "Unique key of this request among those of its X-Actor, a retry with the same key gets the first response back",
+		}))
+	}
+	if len(parameters) > 0 {
+		result["parameters"] = parameters
+	}
+
+	if operation.Request != nil || len(operation.RequestTypes) > 0 {
+		result["requestBody"] = map[string]any{
+			"required": true,
+			"content":  buildContent(schemas, operation.Request, operation.RequestTypes),
+		}
+	}
+
+	status := operation.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := map[string]any{"description": http.StatusText(status)}
+	if operation.Response != nil || len(operation.ResponseTypes) > 0 {
+		success["content"] = buildContent(schemas, operation.Response, operation.ResponseTypes)
+	}
+	if operation.Versioned {
+		success["headers"] = map[string]any{
+			"ETag": map[string]any{
+				"description": "Current version of the resource, send it back in If-Match",
+				"schema":      map[string]any{"type": "string"},
+			},
+		}
+	}
+	responses := map[string]any{strconv.Itoa(status): success}
+
+	errors := append([]int{http.StatusInternalServerError}, operation.Errors...)
+	if len(parameters) > 0 || result["requestBody"] != nil {
+		errors = append(errors, http.StatusBadRequest)
+	}
+	if strings.Contains(operation.Path, "{") {
+		errors = append(errors, http.StatusNotFound)
+	}
+	if operation.Method == http.MethodPost || operation.Method == http.MethodPatch {
+		errors = append(errors, http.StatusConflict)
+	}
+	if conditional {
+		errors = append(errors, http.StatusPreconditionFailed)
+	}
+	for _, code := range errors {
+		if operation.ErrorResponse == nil {
+			responses[strconv.Itoa(code)] = map[string]any{"$ref": "#/components/responses/" + strconv.Itoa(code)}
+			continue
+		}
+		content := buildContent(schemas, operation.ErrorResponse, nil)
+		content["application/problem+json"] = map[string]any{"schema": schemas.ref(reflect.TypeOf(models.Problem{}))}
+		responses[strconv.Itoa(code)] = map[string]any{"description": http.StatusText(code), "content": content}
+	}
+	result["responses"] = responses
+	return result
+}
+
+func buildParameter(parameter APIParameter) map[string]any {
+	schema := map[string]any{"type": parameter.Type}
+	if parameter.Format != "" {
+		schema["format"] = parameter.Format
+	}
+	if len(parameter.Enum) > 0 {
+		schema["enum"] = parameter.Enum
+	}
+	result := map[string]any{
+		"name":     parameter.Name,
+		"in":       parameter.In,
+		"required": parameter.Required || parameter.In == "path",
+		"schema":   schema,
+	}
+	if parameter.Description != "" {
+		result["description"] = parameter.Description
+	}
+	return result
+}
+
+func buildContent(schemas *schemaRegistry, body any, mediaTypes []string) map[string]any {
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{"application/json"}
+	}
+	schema := map[string]any{"type": "string", "format": "binary"}
+	if body != nil {
+		schema = schemas.schema(reflect.TypeOf(body))
+	}
+	content := map[string]any{}
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = map[string]any{"schema": schema}
+	}
+	return content
+}
+
+// RoutePaths lists the distinct paths of operations, sorted, so the router
+// can be checked against the document.
+func RoutePaths(operations []APIOperation) []string {
+	seen := map[string]bool{}
+	var paths []string
+	for _, operation := range operations {
+		if !seen[operation.Path] {
+			seen[operation.Path] = true
+			paths = append(paths, operation.Path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRegistry collects the named struct types reached while generating
+// schemas into components/schemas and refers to them by $ref.
+type schemaRegistry struct {
+	definitions map[string]any
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{definitions: map[string]any{}}
+}
+
+func (s *schemaRegistry) ref(t reflect.Type) map[string]any {
+	name := t.Name()
+	if _, ok := s.definitions[name]; !ok {
+		// Reserve the name first so self-referencing types terminate.
+		s.definitions[name] = nil
+		s.definitions[name] = s.object(t)
+	}
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func (s *schemaRegistry) schema(t reflect.Type) map[string]any {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == rawMessageType:
+		return map[string]any{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := s.schema(t.Elem())
+		if _, isRef := schema["$ref"]; isRef {
+			return map[string]any{"allOf": []any{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.Struct:
+		if t.Name() == "" || strings.Contains(t.Name(), "[") {
+			return s.object(t)
+		}
+		return s.ref(t)
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": s.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.schema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number", "format": "double"}
+	default:
+		return map[string]any{}
+	}
+}
+
+// object lists the JSON fields of a struct the way encoding/json sees them:
+// embedded structs are flattened and shadowed by the outer fields. The
+// validate tags become required, length, range and format constraints.
+func (s *schemaRegistry) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	s.collectFields(t, properties, &required)
+
+	result := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		result["required"] = required
+	}
+	return result
+}
+
+func (s *schemaRegistry) collectFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			s.collectFields(field.Type, properties, required)
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Anonymous && field.Tag.Get("json") == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := s.schema(field.Type)
+		isRequired := applyValidateTag(schema, field.Type, field.Tag.Get("validate"))
+		properties[name] = schema
+
+		index := -1
+		for j, existing := range *required {
+			if existing == name {
+				index = j
+			}
+		}
+		switch {
+		case isRequired && index < 0:
+			*required = append(*required, name)
+		case !isRequired && index >= 0:
+			*required = append((*required)[:index], (*required)[index+1:]...)
+		}
+	}
+}
+
+func applyValidateTag(schema map[string]any, t reflect.Type, tag string) bool {
+	if tag == "" {
+		return false
+	}
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, value, _ := strings.Cut(rule, "=")
+		limit, _ := strconv.Atoi(value)
+		switch name {
+		case "required":
+			required = true
+			if t.Kind() == reflect.Slice {
+				schema["minItems"] = 1
+			}
+		case "uuid":
+			schema["format"] = "uuid"
+		case "min", "max":
+			schema[constraintName(t.Kind(), name)] = limit
+		}
+	}
+	return required
+}
+
+func constraintName(kind reflect.Kind, rule string) string {
+	switch kind {
+	case reflect.String:
+		return rule + "Length"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rule + "Items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		if rule == "min" {
+			return "minimum"
+		}
+		return "maximum"
+	}
+	return rule
+}
@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	apperrors "kasir-api/internal/errors"
+	"kasir-api/models"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WriteError maps err onto a problem+json response. The typed errors of
+// internal/errors keep their status, code and fields; anything else is logged
+// and reported as a bare 500 so database messages never reach the client.
+func WriteError(w http.ResponseWriter, err error) {
+	problem := models.Problem{Type: "about:blank", Detail: err.Error()}
+
+	var notFound *apperrors.NotFoundError
+	var validation *apperrors.ValidationError
+	var stock *apperrors.InsufficientStockError
+	var conflict *apperrors.ConflictError
+	var duplicate *apperrors.DuplicateError
+	var version *apperrors.VersionMismatchError
+	var badRequest *apperrors.BadRequestError
+	switch {
+	case errors.As(err, &notFound):
+		problem.Status = http.StatusNotFound
+	case errors.As(err, &validation):
+		problem.Status = http.StatusUnprocessableEntity
+		problem.Code = "validation_failed"
+		problem.Detail = "The request has invalid fields"
+		problem.Errors = validation.Fields
+	case errors.As(err, &stock):
+		problem.Status = http.StatusConflict
+		problem.Code = "insufficient_stock"
+		problem.Errors = []models.FieldError{{Field: stock.Field, Code: problem.Code, Message: stock.Error()}}
+	case errors.As(err, &conflict):
+		problem.Status = http.StatusConflict
+		problem.Code = conflict.Code
+	case errors.As(err, &duplicate):
+		problem.Status = http.StatusConflict
+		problem.Code = "duplicate"
+		problem.Errors = []models.FieldError{{Field: duplicate.Field, Code: problem.Code, Message: strconv.Quote(duplicate.Value) + " is already used"}}
+	case errors.As(err, &version):
+		problem.Status = http.StatusPreconditionFailed
+		problem.Code = "version_mismatch"
+	case errors.As(err, &badRequest):
+		problem.Status = http.StatusBadRequest
+	default:
+		log.Println(err)
+		problem.Status = http.StatusInternalServerError
+		problem.Detail = "Internal server error"
+	}
+
+	problem.Title = http.StatusText(problem.Status)
+	if problem.Code == "" {
+		problem.Code = problemCode(problem.Status)
+	}
+	writeProblem(w, problem)
+}
+
+func writeProblem(w http.ResponseWriter, problem models.Problem) {
+	// WithRequestID has already echoed the id on the response.
+	problem.RequestID = w.Header().Get("X-Request-ID")
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// problemCode derives the default machine readable code from the status
+// text, so 404 becomes not_found.
+func problemCode(statusCode int) string {
+	text := http.StatusText(statusCode)
+	if text == "" {
+		return "error"
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"context"
+	"kasir-api/models"
+	"net"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const requestIDKey contextKey = "request_id"
+
+// WithRequestID tags the request with the id from its X-Request-ID header, or
+// a new one, and echoes it in the response so clients can quote it.
+func WithRequestID(w http.ResponseWriter, r *http.Request) *http.Request {
+	id := r.Header.Get("X-Request-ID")
+	if id == "" || len(id) > 128 {
+		id = uuid.NewString()
+	}
+	w.Header().Set("X-Request-ID", id)
+	return r.WithContext(context.WithValue(r.Context(), requestIDKey, id))
+}
+
+func RequestID(r *http.Request) string {
+	id, _ := r.Context().Value(requestIDKey).(string)
+	return id
+}
+
+// ActorFromRequest identifies the caller for the audit log. There are no user
+// accounts yet, so the name is only what the client claims in X-Actor, while
+// the source address comes from the connection itself.
+func ActorFromRequest(r *http.Request) models.Actor {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	name := r.Header.Get("X-Actor")
+	if name == "" {
+		name = "anonymous"
+	}
+	return models.Actor{ClaimedName: name, RequestID: RequestID(r), SourceIP: ip}
+}
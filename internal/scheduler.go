@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"log"
+	"time"
+)
+
+// RunEvery calls job on a fixed interval in the background. Failures are
+// logged and the job keeps being scheduled.
+func RunEvery(name string, interval time.Duration, job func() error) {
+	go runEvery(name, interval, job)
+}
+
+// RunNowAndEvery is RunEvery for jobs whose output is needed right after a
+// restart: job also runs once in the background as soon as it is scheduled.
+func RunNowAndEvery(name string, interval time.Duration, job func() error) {
+	go func() {
+		runJob(name, job)
+		runEvery(name, interval, job)
+	}()
+}
+
+func runEvery(name string, interval time.Duration, job func() error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		runJob(name, job)
+	}
+}
+
+func runJob(name string, job func() error) {
+	err := job()
+	if err != nil {
+		log.Printf("[JOB] %s failed: %s", name, err)
+	}
+}
@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// SpreadsheetFormat guesses the format of an uploaded file from its name or
+// content type. It returns an empty string when neither is recognised.
+func SpreadsheetFormat(filename string, contentType string) string {
+	filename = strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(filename, ".csv"):
+		return FormatCSV
+	case strings.HasSuffix(filename, ".xlsx"):
+		return FormatXLSX
+	}
+
+	switch {
+	case strings.HasPrefix(contentType, "text/csv"):
+		return FormatCSV
+	case strings.HasPrefix(contentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"):
+		return FormatXLSX
+	}
+	return ""
+}
+
+// ReadSpreadsheet returns the rows of a CSV file or of the first sheet of an
+// XLSX workbook, header row included.
+func ReadSpreadsheet(r io.Reader, format string) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return reader.ReadAll()
+	case FormatXLSX:
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open workbook: %w", err)
+		}
+		defer file.Close()
+
+		sheets := file.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, fmt.Errorf("workbook has no sheets")
+		}
+		return file.GetRows(sheets[0])
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
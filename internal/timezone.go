@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"errors"
+	"kasir-api/models"
+	"time"
+	_ "time/tzdata"
+)
+
+const DefaultStoreTimezone = "Asia/Jakarta"
+
+var (
+	ErrInvalidFromDate   = errors.New("Invalid from date")
+	ErrInvalidToDate     = errors.New("Invalid to date")
+	ErrDateRangeReversed = errors.New("From date must not be after to date")
+)
+
+var storeLocation = mustLoadLocation(DefaultStoreTimezone)
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
+// SetStoreTimezone changes the timezone that calendar dates in requests and
+// reports are interpreted in. An empty name keeps the default.
+func SetStoreTimezone(name string) error {
+	if name == "" {
+		return nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+	storeLocation = location
+	return nil
+}
+
+func StoreLocation() *time.Location {
+	return storeLocation
+}
+
+// StoreDate returns the calendar date of t in the store timezone as YYYY-MM-DD.
+func StoreDate(t time.Time) string {
+	return t.In(storeLocation).Format("2006-01-02")
+}
+
+// ParseDateRange turns inclusive YYYY-MM-DD dates into a half-open timestamp
+// range in the store timezone: from is taken at 00:00 and to is extended to
+// 00:00 of the following day, so the whole "to" day is covered. Either date
+// may be empty to leave that side open.
+func ParseDateRange(from string, to string) (models.DateRange, error) {
+	var dateRange models.DateRange
+	if from != "" {
+		start, err := time.ParseInLocation("2006-01-02", from, storeLocation)
+		if err != nil {
+			return models.DateRange{}, ErrInvalidFromDate
+		}
+		dateRange.From = start
+	}
+	if to != "" {
+		end, err := time.ParseInLocation("2006-01-02", to, storeLocation)
+		if err != nil {
+			return models.DateRange{}, ErrInvalidToDate
+		}
+		dateRange.To = end.AddDate(0, 0, 1)
+	}
+	if !dateRange.From.IsZero() && !dateRange.To.IsZero() && !dateRange.From.Before(dateRange.To) {
+		return models.DateRange{}, ErrDateRangeReversed
+	}
+	return dateRange, nil
+}
+
+// DayRange returns the range covering the store calendar day that contains t.
+func DayRange(t time.Time) models.DateRange {
+	local := t.In(storeLocation)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, storeLocation)
+	return models.DateRange{From: start, To: start.AddDate(0, 0, 1)}
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// utc builds an instant in UTC. Asia/Jakarta is UTC+7 all year, so a store
+// day starts at 17:00 UTC on the previous calendar day.
+func utc(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  error
+	}{
+		{name: "no bounds"},
+		{
+			name:     "to day is inclusive",
+			from:     "2024-03-01",
+			to:       "2024-03-31",
+			wantFrom: utc(2024, 2, 29, 17, 0),
+			wantTo:   utc(2024, 3, 31, 17, 0),
+		},
+		{
+			name:     "single day",
+			from:     "2024-03-10",
+			to:       "2024-03-10",
+			wantFrom: utc(2024, 3, 9, 17, 0),
+			wantTo:   utc(2024, 3, 10, 17, 0),
+		},
+		{name: "from only", from: "2024-03-10", wantFrom: utc(2024, 3, 9, 17, 0)},
+		{name: "to only", to: "2024-03-10", wantTo: utc(2024, 3, 10, 17, 0)},
+		{name: "to crosses the year", to: "2024-12-31", wantTo: utc(2024, 12, 31, 17, 0)},
+		{name: "reversed", from: "2024-03-11", to: "2024-03-10", wantErr: ErrDateRangeReversed},
+		{name: "malformed from", from: "10-03-2024", wantErr: ErrInvalidFromDate},
+		{name: "from with a time", from: "2024-03-10T00:00:00Z", wantErr: ErrInvalidFromDate},
+		{name: "impossible from", from: "2024-02-30", wantErr: ErrInvalidFromDate},
+		{name: "malformed to", to: "2024/03/10", wantErr: ErrInvalidToDate},
+		{name: "malformed to with valid from", from: "2024-03-10", to: "tomorrow", wantErr: ErrInvalidToDate},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dateRange, err := ParseDateRange(test.from, test.to)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("error = %v, want %v", err, test.wantErr)
+			}
+			if !dateRange.From.Equal(test.wantFrom) {
+				t.Errorf("From = %v, want %v", dateRange.From, test.wantFrom)
+			}
+			if !dateRange.To.Equal(test.wantTo) {
+				t.Errorf("To = %v, want %v", dateRange.To, test.wantTo)
+			}
+		})
+	}
+}
+
+func TestDayRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		at       time.Time
+		wantFrom time.Time
+	}{
+		{name: "store midnight", at: utc(2024, 3, 9, 17, 0), wantFrom: utc(2024, 3, 9, 17, 0)},
+		{name: "just before store midnight", at: utc(2024, 3, 9, 16, 59), wantFrom: utc(2024, 3, 8, 17, 0)},
+		{name: "UTC midnight is 07:00 in the store", at: utc(2024, 3, 10, 0, 0), wantFrom: utc(2024, 3, 9, 17, 0)},
+		{name: "last minute of the store day", at: utc(2024, 3, 10, 16, 59), wantFrom: utc(2024, 3, 9, 17, 0)},
+		{name: "other location", at: time.Date(2024, 3, 10, 1, 0, 0, 0, time.FixedZone("UTC+9", 9*60*60)), wantFrom: utc(2024, 3, 8, 17, 0)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dateRange := DayRange(test.at)
+			if !dateRange.From.Equal(test.wantFrom) {
+				t.Errorf("From = %v, want %v", dateRange.From, test.wantFrom)
+			}
+			if wantTo := test.wantFrom.Add(24 * time.Hour); !dateRange.To.Equal(wantTo) {
+				t.Errorf("To = %v, want %v", dateRange.To, wantTo)
+			}
+			if dateRange.From.After(test.at) || !dateRange.To.After(test.at) {
+				t.Errorf("range %v to %v does not contain %v", dateRange.From, dateRange.To, test.at)
+			}
+		})
+	}
+}
+
+// TestDayRangeMatchesParseDateRange checks that the day of an instant and the
+// parsed range of its store date agree, including on both sides of midnight.
+func TestDayRangeMatchesParseDateRange(t *testing.T) {
+	for _, at := range []time.Time{utc(2024, 3, 9, 16, 59), utc(2024, 3, 9, 17, 0), utc(2024, 12, 31, 17, 30)} {
+		date := StoreDate(at)
+		parsed, err := ParseDateRange(date, date)
+		if err != nil {
+			t.Fatalf("ParseDateRange(%q): %v", date, err)
+		}
+		day := DayRange(at)
+		if !parsed.From.Equal(day.From) || !parsed.To.Equal(day.To) {
+			t.Errorf("%v: ParseDateRange(%q) = %v to %v, DayRange = %v to %v", at, date, parsed.From, parsed.To, day.From, day.To)
+		}
+	}
+}
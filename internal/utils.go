@@ -2,23 +2,27 @@ package internal
 
 import (
 	"encoding/json"
+	"kasir-api/models"
 	"net/http"
-	"strconv"
 	"time"
 )
 
 func HandleResponse(w http.ResponseWriter, statusCode int, data interface{}) {
-	w.WriteHeader(statusCode)
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(data)
 }
 
+// HandleError writes a problem+json body for statusCode with message as the
+// detail. Errors coming from the services should go through WriteError so
+// they keep their specific code.
 func HandleError(w http.ResponseWriter, statusCode int, message string) {
-	HandleResponse(w, statusCode, map[string]string{
-		"status":  "error",
-		"type":    http.StatusText(statusCode),
-		"code":    strconv.Itoa(statusCode),
-		"message": message,
+	writeProblem(w, models.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(statusCode),
+		Status: statusCode,
+		Detail: message,
+		Code:   problemCode(statusCode),
 	})
 }
 
@@ -0,0 +1,225 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	apperrors "kasir-api/internal/errors"
+	"kasir-api/models"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// DecodeJSON decodes the request body into v, rejecting unknown fields, and
+// validates the result. See StrictUnmarshal and Validate for the errors.
+func DecodeJSON(r *http.Request, v any) error {
+	err := decodeStrict(r.Body, v)
+	if err != nil {
+		return err
+	}
+	return Validate(v)
+}
+
+// StrictUnmarshal decodes data into v like json.Unmarshal but rejects unknown
+// fields. Unknown fields and wrong types come back as a ValidationError and
+// unreadable JSON as a BadRequestError.
+func StrictUnmarshal(data []byte, v any) error {
+	return decodeStrict(bytes.NewReader(data), v)
+}
+
+func decodeStrict(reader io.Reader, v any) error {
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(v)
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return apperrors.NewBadRequestError("Request body is empty")
+	case errors.As(err, &typeErr):
+		return apperrors.NewValidationError(models.FieldError{Field: fieldPath(typeErr.Field), Code: "type", Message: "must be " + kindName(typeErr.Type)})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+		return apperrors.NewValidationError(models.FieldError{Field: field, Code: "unknown_field", Message: "is not a known field"})
+	default:
+		return apperrors.NewBadRequestError("Invalid request body: " + err.Error())
+	}
+}
+
+// fieldPath rewrites the dotted path of encoding/json, such as items.0.quantity,
+// into the items[0].quantity form used by Validate.
+func fieldPath(path string) string {
+	var builder strings.Builder
+	for i, segment := range strings.Split(path, ".") {
+		if _, err := strconv.Atoi(segment); err == nil {
+			builder.WriteString("[" + segment + "]")
+			continue
+		}
+		if i > 0 {
+			builder.WriteString(".")
+		}
+		builder.WriteString(segment)
+	}
+	return builder.String()
+}
+
+func kindName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// Validate checks v, a struct or pointer to one, against the comma separated
+// rules in the validate tags of its fields and returns a ValidationError
+// listing every failure, or nil:
+//
+//	required  not zero; strings must not be blank and slices not empty
+//	min=N     numbers at least N, strings and slices at least N long
+//	max=N     numbers at most N, strings and slices at most N long
+//	uuid      strings, when not empty, are UUIDs
+//	dive      structs and slices of structs are validated field by field
+//
+// Fields are reported by their JSON names, with indexes for slice elements.
+func Validate(v any) error {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	fields := make([]models.FieldError, 0)
+	validateStruct(value, "", &fields)
+	if len(fields) > 0 {
+		return apperrors.NewValidationError(fields...)
+	}
+	return nil
+}
+
+func validateStruct(value reflect.Value, prefix string, fields *[]models.FieldError) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		rules := field.Tag.Get("validate")
+		if rules == "" || !field.IsExported() {
+			continue
+		}
+		name := jsonName(field)
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		for _, rule := range strings.Split(rules, ",") {
+			if rule == "dive" {
+				validateNested(value.Field(i), name, fields)
+				continue
+			}
+			fieldError, ok := checkRule(value.Field(i), rule)
+			if !ok {
+				fieldError.Field = name
+				*fields = append(*fields, fieldError)
+				// Later rules would only repeat the same problem.
+				break
+			}
+		}
+	}
+}
+
+func validateNested(value reflect.Value, name string, fields *[]models.FieldError) {
+	value = reflect.Indirect(value)
+	switch value.Kind() {
+	case reflect.Struct:
+		validateStruct(value, name, fields)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			validateNested(value.Index(i), fmt.Sprintf("%s[%d]", name, i), fields)
+		}
+	}
+}
+
+func checkRule(value reflect.Value, rule string) (models.FieldError, bool) {
+	name, param, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		empty := value.IsZero()
+		switch value.Kind() {
+		case reflect.String:
+			empty = strings.TrimSpace(value.String()) == ""
+		case reflect.Slice, reflect.Map:
+			empty = value.Len() == 0
+		}
+		return models.FieldError{Code: "required", Message: "is required"}, !empty
+	case "min", "max":
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			panic(fmt.Sprintf("validate: invalid %s rule %q", name, rule))
+		}
+		size, unit := measure(value)
+		bound := "at most"
+		ok := size <= limit
+		if name == "min" {
+			bound = "at least"
+			ok = size >= limit
+		}
+		message := fmt.Sprintf("must be %s %s", bound, param)
+		switch unit {
+		case "characters":
+			message = fmt.Sprintf("must be %s %s characters long", bound, param)
+		case "items":
+			message = fmt.Sprintf("must have %s %s items", bound, param)
+		}
+		return models.FieldError{Code: name, Message: message}, ok
+	case "uuid":
+		if value.Kind() != reflect.String || value.String() == "" {
+			return models.FieldError{}, true
+		}
+		_, err := uuid.Parse(value.String())
+		return models.FieldError{Code: "uuid", Message: "must be a UUID"}, err == nil
+	default:
+		panic(fmt.Sprintf("validate: unknown rule %q", rule))
+	}
+}
+
+// measure returns the number compared by min and max: the value of numbers,
+// the length of strings in characters and of slices in items.
+func measure(value reflect.Value) (float64, string) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), "characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), "items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), ""
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), ""
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), ""
+	default:
+		panic(fmt.Sprintf("validate: min and max do not apply to %s", value.Kind()))
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
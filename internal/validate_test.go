@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"errors"
+	apperrors "kasir-api/internal/errors"
+	"reflect"
+	"testing"
+)
+
+type validatedLine struct {
+	ID       string `json:"id" validate:"required,uuid"`
+	Quantity int    `json:"quantity" validate:"min=1,max=100"`
+}
+
+type validatedOrder struct {
+	Name   string          `json:"name" validate:"required,min=2,max=5"`
+	Note   string          `json:"note" validate:"max=3"`
+	Price  float64         `json:"price" validate:"min=0.5"`
+	Tags   []string        `json:"tags" validate:"max=2"`
+	Lines  []validatedLine `json:"lines" validate:"required,min=1,max=3,dive"`
+	Parent *validatedLine  `json:"parent" validate:"dive"`
+	Plain  string
+}
+
+const validLineID = "6f1f7c5e-8a0b-4b52-9c55-2e9e0c3b1a10"
+
+// failures lists err as field:code pairs, nil when err is nil.
+func failures(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var validationErr *apperrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("error = %v, want a ValidationError", err)
+	}
+	pairs := make([]string, 0, len(validationErr.Fields))
+	for _, field := range validationErr.Fields {
+		pairs = append(pairs, field.Field+":"+field.Code)
+	}
+	return pairs
+}
+
+func TestValidate(t *testing.T) {
+	valid := func(change func(*validatedOrder)) validatedOrder {
+		order := validatedOrder{
+			Name:  "abc",
+			Price: 1,
+			Lines: []validatedLine{{ID: validLineID, Quantity: 1}},
+		}
+		if change != nil {
+			change(&order)
+		}
+		return order
+	}
+
+	tests := []struct {
+		name  string
+		order validatedOrder
+		want  []string
+	}{
+		{name: "valid", order: valid(nil)},
+		{name: "blank string is missing", order: valid(func(o *validatedOrder) { o.Name = "   " }), want: []string{"name:required"}},
+		{name: "empty slice is missing", order: valid(func(o *validatedOrder) { o.Lines = []validatedLine{} }), want: []string{"lines:required"}},
+		{name: "nil slice is missing", order: valid(func(o *validatedOrder) { o.Lines = nil }), want: []string{"lines:required"}},
+		{name: "string too short", order: valid(func(o *validatedOrder) { o.Name = "a" }), want: []string{"name:min"}},
+		{name: "string too long", order: valid(func(o *validatedOrder) { o.Name = "abcdef" }), want: []string{"name:max"}},
+		{name: "length counts characters", order: valid(func(o *validatedOrder) { o.Name = "ééééé" })},
+		{name: "empty optional string", order: valid(func(o *validatedOrder) { o.Note = "" })},
+		{name: "number below min", order: valid(func(o *validatedOrder) { o.Price = 0.25 }), want: []string{"price:min"}},
+		{name: "number at min", order: valid(func(o *validatedOrder) { o.Price = 0.5 })},
+		{name: "slice too long", order: valid(func(o *validatedOrder) { o.Tags = []string{"a", "b", "c"} }), want: []string{"tags:max"}},
+		{
+			name: "slice over max",
+			order: valid(func(o *validatedOrder) {
+				line := validatedLine{ID: validLineID, Quantity: 1}
+				o.Lines = []validatedLine{line, line, line, line}
+			}),
+			want: []string{"lines:max"},
+		},
+		{
+			name: "dive reports indexes",
+			order: valid(func(o *validatedOrder) {
+				o.Lines = []validatedLine{{ID: validLineID, Quantity: 1}, {ID: validLineID, Quantity: 0}, {ID: "nope", Quantity: 101}}
+			}),
+			want: []string{"lines[1].quantity:min", "lines[2].id:uuid", "lines[2].quantity:max"},
+		},
+		{
+			name:  "dive into a pointer",
+			order: valid(func(o *validatedOrder) { o.Parent = &validatedLine{Quantity: 1} }),
+			want:  []string{"parent.id:required"},
+		},
+		{name: "nil pointer is skipped", order: valid(func(o *validatedOrder) { o.Parent = nil })},
+		{
+			name: "every field is reported",
+			order: valid(func(o *validatedOrder) {
+				o.Name = ""
+				o.Note = "long"
+			}),
+			want: []string{"name:required", "note:max"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := failures(t, Validate(&test.order))
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("failures = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateIgnoresNonStructs(t *testing.T) {
+	for _, v := range []any{nil, 3, "text", []validatedLine{{}}} {
+		if err := Validate(v); err != nil {
+			t.Errorf("Validate(%#v) = %v, want nil", v, err)
+		}
+	}
+}
+
+func TestStrictUnmarshal(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		want           []string
+		wantBadRequest bool
+	}{
+		{name: "valid", body: `{"name": "abc", "lines": [{"id": "x", "quantity": 2}]}`},
+		{name: "unknown field", body: `{"name": "abc", "colour": "red"}`, want: []string{"colour:unknown_field"}},
+		{name: "string for a number", body: `{"price": "cheap"}`, want: []string{"price:type"}},
+		{name: "number for a string", body: `{"name": 5}`, want: []string{"name:type"}},
+		{name: "object for an array", body: `{"tags": {}}`, want: []string{"tags:type"}},
+		{name: "type error inside a slice", body: `{"lines": [{"quantity": 1}, {"quantity": "two"}]}`, want: []string{"lines[1].quantity:type"}},
+		{name: "empty body", body: ``, wantBadRequest: true},
+		{name: "malformed JSON", body: `{"name": `, wantBadRequest: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var order validatedOrder
+			err := StrictUnmarshal([]byte(test.body), &order)
+			if test.wantBadRequest {
+				if !apperrors.IsBadRequestError(err) {
+					t.Fatalf("error = %v, want a BadRequestError", err)
+				}
+				return
+			}
+			got := failures(t, err)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("failures = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFieldPath(t *testing.T) {
+	tests := map[string]string{
+		"name":                  "name",
+		"items.0.quantity":      "items[0].quantity",
+		"items.12.serials.3":    "items[12].serials[3]",
+		"customer.address.city": "customer.address.city",
+	}
+	for path, want := range tests {
+		if got := fieldPath(path); got != want {
+			t.Errorf("fieldPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -2,13 +2,17 @@ package main
 
 import (
 	"kasir-api/database"
+	"kasir-api/graphqlserver"
+	"kasir-api/grpcserver"
 	"kasir-api/handlers"
 	"kasir-api/internal"
 	"kasir-api/repositories"
 	"kasir-api/services"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -16,8 +20,11 @@ import (
 )
 
 type Config struct {
-	Port   string `mapstructure:"PORT"`
-	DBConn string `mapstructure:"DB_CONN"`
+	Port              string `mapstructure:"PORT"`
+	DBConn            string `mapstructure:"DB_CONN"`
+	StoreTimezone     string `mapstructure:"STORE_TIMEZONE"`
+	LowStockThreshold string `mapstructure:"LOW_STOCK_THRESHOLD"`
+	GRPCPort          string `mapstructure:"GRPC_PORT"`
 }
 
 func loggingMiddleware(next http.Handler) http.Handler {
@@ -28,10 +35,12 @@ func loggingMiddleware(next http.Handler) http.Handler {
 			statusCode:     http.StatusOK,
 		}
 
-		log.Printf("[REQUEST] %s %s %s", r.RemoteAddr, r.Method, r.URL.Path)
+		r = internal.WithRequestID(w, r)
+		log.Printf("[REQUEST] %s %s %s %s", internal.RequestID(r), r.RemoteAddr, r.Method, r.URL.Path)
 		next.ServeHTTP(lrw, r)
 		duration := time.Since(start)
-		log.Printf("[RESPONSE] %s %s %s %d %s",
+		log.Printf("[RESPONSE] %s %s %s %s %d %s",
+			internal.RequestID(r),
 			r.RemoteAddr,
 			r.Method,
 			r.URL.Path,
@@ -51,6 +60,28 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.ResponseWriter.WriteHeader(code)
 }
 
+func (lrw *loggingResponseWriter) Flush() {
+	if flusher, ok := lrw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// warnUndocumentedRoutes logs the registered routes that the OpenAPI document
+// does not cover, so a route added without documentation is noticed at startup.
+func warnUndocumentedRoutes(r *mux.Router, documented []string) {
+	known := make(map[string]bool, len(documented))
+	for _, path := range documented {
+		known[path] = true
+	}
+	r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err == nil && !known[path] {
+			log.Println("Route missing from the OpenAPI document: " + path)
+		}
+		return nil
+	})
+}
+
 func main() {
 	log.Println("Starting server...")
 	err := godotenv.Load()
@@ -58,8 +89,28 @@ func main() {
 		log.Printf("Error loading .env file: %s. Using environment variables.", err)
 	}
 	var config = Config{
-		Port:   os.Getenv("PORT"),
-		DBConn: os.Getenv("DB_CONN"),
+		Port:              os.Getenv("PORT"),
+		DBConn:            os.Getenv("DB_CONN"),
+		StoreTimezone:     os.Getenv("STORE_TIMEZONE"),
+		LowStockThreshold: os.Getenv("LOW_STOCK_THRESHOLD"),
+		GRPCPort:          os.Getenv("GRPC_PORT"),
+	}
+	if config.GRPCPort == "" {
+		config.GRPCPort = "9090"
+	}
+
+	err = internal.SetStoreTimezone(config.StoreTimezone)
+	if err != nil {
+		log.Fatal("Invalid store timezone: ", err)
+	}
+	log.Println("Store timezone is " + internal.StoreLocation().String())
+
+	if config.LowStockThreshold != "" {
+		threshold, err := strconv.Atoi(config.LowStockThreshold)
+		if err != nil || threshold < 0 {
+			log.Fatal("Invalid low stock threshold: ", config.LowStockThreshold)
+		}
+		internal.SetLowStockThreshold(threshold)
 	}
 
 	db, err := database.InitDB(config.DBConn)
@@ -74,8 +125,31 @@ func main() {
 		}
 	}()
 
+	err = database.Migrate(db)
+	if err != nil {
+		log.Fatal("Error migrating database: ", err)
+	}
+
+	rollupRepo := repositories.NewRollupRepository(db)
+	rollupService := services.NewRollupService(rollupRepo)
+	if len(os.Args) > 1 {
+		err = runCommand(rollupService, os.Args[1:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	err = rollupService.EnsureRollup()
+	if err != nil {
+		log.Fatal("Error building daily sales: ", err)
+	}
+	internal.RunEvery("rebuild recent daily sales", time.Hour, rollupService.RebuildRecent)
+
+	events := internal.NewEventBroker()
+
 	productRepo := repositories.NewProductRepository(db)
-	productService := services.NewProductService(productRepo)
+	productService := services.NewProductService(productRepo, events)
 	productHandler := handlers.NewProductHandler(productService)
 
 	categoryRepo := repositories.NewCategoryRepository(db)
@@ -83,37 +157,118 @@ func main() {
 	categoryHandler := handlers.NewCategoryHandler(categoryService)
 
 	transactionRepo := repositories.NewTransactionRepository(db)
-	transactionService := services.NewTransactionService(transactionRepo)
+	transactionService := services.NewTransactionService(transactionRepo, events)
 	transactionHandler := handlers.NewTransactionHandler(transactionService)
 
+	lotRepo := repositories.NewLotRepository(db)
+	lotService := services.NewLotService(lotRepo)
+	lotHandler := handlers.NewLotHandler(lotService)
+
+	serialRepo := repositories.NewSerialRepository(db)
+	serialService := services.NewSerialService(serialRepo)
+	serialHandler := handlers.NewSerialHandler(serialService)
+
+	priceRepo := repositories.NewPriceRepository(db)
+	priceService := services.NewPriceService(priceRepo)
+	priceHandler := handlers.NewPriceHandler(priceService)
+	internal.RunEvery("apply scheduled prices", time.Minute, priceService.ApplyDuePriceChanges)
+
+	priceListRepo := repositories.NewPriceListRepository(db)
+	priceListService := services.NewPriceListService(priceListRepo)
+	priceListHandler := handlers.NewPriceListHandler(priceListService)
+
+	customerRepo := repositories.NewCustomerRepository(db)
+	customerService := services.NewCustomerService(customerRepo)
+	customerHandler := handlers.NewCustomerHandler(customerService)
+
+	forecastRepo := repositories.NewForecastRepository(db)
+	forecastService := services.NewForecastService(forecastRepo)
+	forecastHandler := handlers.NewForecastHandler(forecastService)
+	internal.RunNowAndEvery("generate demand forecasts", 6*time.Hour, forecastService.GenerateForecasts)
+
+	webhookRepo := repositories.NewWebhookRepository(db)
+	webhookService := services.NewWebhookService(webhookRepo)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	internal.RunEvery("dispatch webhooks", 5*time.Second, webhookService.DispatchDue)
+
+	idempotencyRepo := repositories.NewIdempotencyRepository(db)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo)
+	idempotencyHandler := handlers.NewIdempotencyHandler(idempotencyService)
+	internal.RunEvery("expire idempotency keys", time.Hour, idempotencyService.DeleteExpiredKeys)
+
+	auditRepo := repositories.NewAuditRepository(db)
+	auditService := services.NewAuditService(auditRepo)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	internal.RunEvery("seal the audit log", 5*time.Second, auditService.SealAuditLog)
+
+	eventHandler := handlers.NewEventHandler(events, transactionService)
+
+	exportHandler := handlers.NewExportHandler(productService, transactionService)
+
 	reportRepo := repositories.NewReportRepository(db)
 	reportService := services.NewReportService(reportRepo)
 	reportHandler := handlers.NewReportHandler(reportService)
 	healthHandler := handlers.NewHealthHandler(db)
+	docsHandler, err := handlers.NewDocsHandler(handlers.APIOperations)
+	if err != nil {
+		log.Fatal("Error building the OpenAPI document: ", err)
+	}
 
 	r := mux.NewRouter()
 	r.Use(loggingMiddleware)
+	r.Use(idempotencyHandler.Middleware)
 
-	r.HandleFunc("/healthz", healthHandler.HandleHealth)
-
-	r.HandleFunc("/api/products", productHandler.HandleProduct)
-	r.HandleFunc("/api/products/{id}", productHandler.HandleProductByID)
-	r.HandleFunc("/api/products/{id}/categories", productHandler.HandleProductCategories)
-
-	r.HandleFunc("/api/categories", categoryHandler.HandleCategory)
-	r.HandleFunc("/api/categories/{id}", categoryHandler.HandleCategoryByID)
-	r.HandleFunc("/api/categories/{id}/products", categoryHandler.GetProductsByCategory)
-
-	r.HandleFunc("/api/checkout", transactionHandler.HandleCheckout)
-	r.HandleFunc("/api/transactions", transactionHandler.GetTransactions)
+	handlers.RegisterRoutes(r, handlers.Routes{
+		Health: healthHandler,
+		Docs:   docsHandler,
+		GraphQL: graphqlserver.New(graphqlserver.Services{
+			Products:     productService,
+			Categories:   categoryService,
+			Transactions: transactionService,
+			Reports:      reportService,
+			Audit:        auditService,
+		}),
+		Product:     productHandler,
+		Category:    categoryHandler,
+		Lot:         lotHandler,
+		Serial:      serialHandler,
+		Price:       priceHandler,
+		PriceList:   priceListHandler,
+		Customer:    customerHandler,
+		Forecast:    forecastHandler,
+		Transaction: transactionHandler,
+		Event:       eventHandler,
+		Webhook:     webhookHandler,
+		Audit:       auditHandler,
+		Export:      exportHandler,
+		Report:      reportHandler,
+	})
 
-	r.HandleFunc("/api/reports", reportHandler.HandleReport)
-	r.HandleFunc("/api/reports/today", reportHandler.GetReportToday)
+	warnUndocumentedRoutes(r, internal.RoutePaths(handlers.APIOperations))
 
 	r.HandleFunc("/{path:.*}", func(w http.ResponseWriter, r *http.Request) {
 		internal.HandleError(w, http.StatusNotFound, "Not found")
 	})
 
+	grpcServer := grpcserver.New(grpcserver.Services{
+		Products:     productService,
+		Categories:   categoryService,
+		Transactions: transactionService,
+		Reports:      reportService,
+		Events:       events,
+	})
+	listener, err := net.Listen("tcp", ":"+config.GRPCPort)
+	if err != nil {
+		log.Fatal("Error listening for gRPC: ", err)
+	}
+	go func() {
+		log.Println("Serving gRPC on port " + config.GRPCPort)
+		err := grpcServer.Serve(listener)
+		if err != nil {
+			log.Fatal("gRPC Serve: ", err)
+		}
+	}()
+
 	log.Println("Listening to port " + config.Port)
 	err = http.ListenAndServe(":"+config.Port, r)
 	if err != nil {
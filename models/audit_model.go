@@ -0,0 +1,70 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Actor identifies who made a change, for the audit log. ClaimedName is what
+// the client sent in X-Actor and is not authenticated; SourceIP is the address
+// of the connection, read by the server.
+type Actor struct {
+	ClaimedName string
+	RequestID   string
+	SourceIP    string
+}
+
+// AuditEntry is one row change. EntityType is the table name and Before and
+// After the row as stored, so either is null for inserts and deletes. Seq,
+// PrevHash and Hash are empty until the entry is sealed into the hash chain,
+// a few seconds after it is written.
+type AuditEntry struct {
+	ID           int64           `json:"id"`
+	ClaimedActor string          `json:"claimed_actor"`
+	Action       string          `json:"action"`
+	EntityType   string          `json:"entity_type"`
+	EntityID     string          `json:"entity_id"`
+	Before       json.RawMessage `json:"before"`
+	After        json.RawMessage `json:"after"`
+	RequestID    string          `json:"request_id"`
+	SourceIP     string          `json:"source_ip"`
+	CreatedAt    time.Time       `json:"created_at"`
+	Seq          *int64          `json:"seq"`
+	PrevHash     string          `json:"prev_hash"`
+	Hash         string          `json:"hash"`
+}
+
+type AuditFilter struct {
+	ClaimedActor string
+	Action       string
+	EntityType   string
+	EntityID     string
+	RequestID    string
+	DateRange    DateRange
+	BeforeID     int64
+	Limit        int
+}
+
+// AuditVerification is the result of recomputing the hash chain over the
+// sealed entries. When Valid is false, BrokenAt is the first entry whose hash
+// or link does not match. Unsealed entries are not part of the chain yet.
+type AuditVerification struct {
+	Valid    bool   `json:"valid"`
+	Entries  int64  `json:"entries"`
+	Unsealed int64  `json:"unsealed"`
+	BrokenAt *int64 `json:"broken_at,omitempty"`
+}
+
+// StockChange is an audit log entry that changed the stock of a product.
+// Before is nil when the product was created and After when it was deleted.
+type StockChange struct {
+	AuditID      int64     `json:"audit_id"`
+	ProductID    string    `json:"product_id"`
+	ClaimedActor string    `json:"claimed_actor"`
+	Action       string    `json:"action"`
+	Before       *int      `json:"before"`
+	After        *int      `json:"after"`
+	Change       int       `json:"change"`
+	RequestID    string    `json:"request_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
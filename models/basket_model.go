@@ -0,0 +1,46 @@
+package models
+
+// ProductPair is how often two products were bought in the same transaction.
+// Support is the share of all transactions containing both, confidence is the
+// chance that a basket with one also has the other, and lift compares that
+// with how often the other product is bought at all. A lift above 1 means the
+// products sell together more often than chance.
+type ProductPair struct {
+	ProductAID       string  `json:"product_a_id"`
+	ProductAName     string  `json:"product_a_name"`
+	ProductBID       string  `json:"product_b_id"`
+	ProductBName     string  `json:"product_b_name"`
+	TransactionCount int64   `json:"transaction_count"`
+	Support          float64 `json:"support"`
+	ConfidenceAToB   float64 `json:"confidence_a_to_b"`
+	ConfidenceBToA   float64 `json:"confidence_b_to_a"`
+	Lift             float64 `json:"lift"`
+}
+
+type BasketReport struct {
+	From              string        `json:"from,omitempty"`
+	To                string        `json:"to,omitempty"`
+	TotalTransactions int64         `json:"total_transactions"`
+	Pairs             []ProductPair `json:"pairs"`
+}
+
+// BoughtWith is a product found in the same baskets as the requested one.
+// Confidence is the share of the requested product's transactions that also
+// contained this product.
+type BoughtWith struct {
+	ProductID        string  `json:"product_id"`
+	ProductName      string  `json:"product_name"`
+	TransactionCount int64   `json:"transaction_count"`
+	Support          float64 `json:"support"`
+	Confidence       float64 `json:"confidence"`
+	Lift             float64 `json:"lift"`
+}
+
+type FrequentlyBoughtWith struct {
+	ProductID         string       `json:"product_id"`
+	From              string       `json:"from,omitempty"`
+	To                string       `json:"to,omitempty"`
+	TransactionCount  int64        `json:"transaction_count"`
+	TotalTransactions int64        `json:"total_transactions"`
+	Products          []BoughtWith `json:"products"`
+}
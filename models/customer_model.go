@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type CustomerGroup struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	PriceListID string    `json:"price_list_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Customer struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Phone           string    `json:"phone"`
+	Email           string    `json:"email"`
+	CustomerGroupID string    `json:"customer_group_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
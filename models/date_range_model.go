@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// DateRange is a half-open timestamp range [From, To). A zero bound means the
+// range is open on that side.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
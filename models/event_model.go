@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+const (
+	EventCheckout = "checkout"
+	EventLowStock = "low_stock"
+	EventTotals   = "totals"
+)
+
+// Event is a change pushed to /api/events subscribers. IDs increase
+// monotonically, also across restarts, so clients can resume after the last
+// one they saw.
+type Event struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	Data      any       `json:"data"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DayTotals are the running sales totals of one store day.
+type DayTotals struct {
+	Date             string `json:"date"`
+	Revenue          int64  `json:"revenue"`
+	Quantity         int64  `json:"quantity"`
+	TransactionCount int64  `json:"transaction_count"`
+}
+
+type CheckoutEvent struct {
+	Transaction Transaction `json:"transaction"`
+	Today       DayTotals   `json:"today"`
+}
+
+type LowStockEvent struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Stock       int    `json:"stock"`
+	Threshold   int    `json:"threshold"`
+}
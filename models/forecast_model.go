@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// DailySales is the quantity of a product sold on one store calendar day.
+type DailySales struct {
+	ProductID string
+	Date      string
+	Quantity  int64
+}
+
+type ForecastDay struct {
+	Date     string  `json:"date"`
+	Quantity float64 `json:"quantity"`
+}
+
+// DemandForecast is the latest forecast for a product. The demand on a given
+// day is DailyDemand multiplied by the factor of its weekday, Sunday first.
+// Products that have not been forecast yet have zero demand and a nil
+// GeneratedAt.
+type DemandForecast struct {
+	ProductID              string        `json:"product_id"`
+	ProductName            string        `json:"product_name"`
+	Stock                  int           `json:"stock"`
+	LeadTimeDays           int           `json:"lead_time_days"`
+	DailyDemand            float64       `json:"daily_demand"`
+	WeekdayFactors         []float64     `json:"weekday_factors"`
+	HistoryDays            int           `json:"history_days"`
+	GeneratedAt            *time.Time    `json:"generated_at"`
+	CoverDays              int           `json:"cover_days"`
+	ExpectedDemand         float64       `json:"expected_demand"`
+	SuggestedOrderQuantity int64         `json:"suggested_order_quantity"`
+	Days                   []ForecastDay `json:"days,omitempty"`
+}
+
+type LeadTimeRequest struct {
+	LeadTimeDays int `json:"lead_time_days"`
+}
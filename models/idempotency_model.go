@@ -0,0 +1,18 @@
+package models
+
+import "net/http"
+
+// IdempotentResponse is the stored response to a request made with an
+// Idempotency-Key, which is unique per Actor. StatusCode is zero while the
+// request is still running, and Attempt identifies the attempt running it.
+type IdempotentResponse struct {
+	Actor       string
+	Key         string
+	Attempt     string
+	Method      string
+	Path        string
+	RequestHash string
+	StatusCode  int
+	Header      http.Header
+	Body        []byte
+}
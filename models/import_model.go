@@ -0,0 +1,29 @@
+package models
+
+// ProductImportRow is one row of an import file. Cost and Stock are nil when
+// the file has no value for them, so an upsert keeps the current ones.
+type ProductImportRow struct {
+	Row        int      `json:"row"`
+	Name       string   `json:"name"`
+	SKU        string   `json:"sku"`
+	Barcode    string   `json:"barcode"`
+	Price      int64    `json:"price"`
+	Cost       *int64   `json:"cost"`
+	Stock      *int     `json:"stock"`
+	Categories []string `json:"categories"`
+}
+
+type ProductImportError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ProductImportResult struct {
+	DryRun    bool                 `json:"dry_run"`
+	Applied   bool                 `json:"applied"`
+	TotalRows int                  `json:"total_rows"`
+	Created   int                  `json:"created"`
+	Updated   int                  `json:"updated"`
+	Errors    []ProductImportError `json:"errors"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type ProductLot struct {
+	ID         string    `json:"id"`
+	ProductID  string    `json:"product_id"`
+	LotNumber  string    `json:"lot_number"`
+	ExpiryDate string    `json:"expiry_date,omitempty"`
+	Quantity   int       `json:"quantity"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ExpiringLot struct {
+	ProductLot
+	ProductName  string `json:"product_name"`
+	DaysToExpiry int    `json:"days_to_expiry"`
+	Expired      bool   `json:"expired"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+type PriceList struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	IsDefault   bool            `json:"is_default"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Items       []PriceListItem `json:"items,omitempty"`
+}
+
+// PriceListItem is one quantity tier of a product in a price list. The tier
+// with the highest MinQuantity not above the purchased quantity wins.
+type PriceListItem struct {
+	ID          string    `json:"id"`
+	PriceListID string    `json:"price_list_id"`
+	ProductID   string    `json:"product_id"`
+	ProductName string    `json:"product_name,omitempty"`
+	MinQuantity int       `json:"min_quantity"`
+	Price       int64     `json:"price"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PriceQuote struct {
+	ProductID   string `json:"product_id"`
+	CustomerID  string `json:"customer_id,omitempty"`
+	PriceListID string `json:"price_list_id,omitempty"`
+	Quantity    int    `json:"quantity"`
+	Price       int64  `json:"price"`
+	Subtotal    int64  `json:"subtotal"`
+}
+
+// PriceListItemKey identifies the price list item to remove.
+type PriceListItemKey struct {
+	ProductID   string `json:"product_id"`
+	MinQuantity int    `json:"min_quantity"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type ProductPrice struct {
+	ID          string     `json:"id"`
+	ProductID   string     `json:"product_id"`
+	Price       int64      `json:"price"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+	Scheduled   bool       `json:"scheduled"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type SchedulePriceRequest struct {
+	Price       int64     `json:"price"`
+	EffectiveAt time.Time `json:"effective_at"`
+}
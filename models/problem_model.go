@@ -0,0 +1,21 @@
+package models
+
+// Problem is an RFC 7807 problem details body. Code is a stable machine
+// readable identifier, Errors lists the fields at fault when there are any.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError points at one invalid request field, using JSON names and
+// indexes such as items[2].quantity.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
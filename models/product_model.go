@@ -4,10 +4,15 @@ import "time"
 
 type Product struct {
 	ID         string     `json:"id"`
-	Name       string     `json:"name"`
-	Price      int64      `json:"price"`
-	Stock      int        `json:"stock"`
+	Name       string     `json:"name" validate:"required,max=255"`
+	SKU        string     `json:"sku" validate:"max=64"`
+	Barcode    string     `json:"barcode" validate:"max=64"`
+	Price      int64      `json:"price" validate:"min=0"`
+	Cost       int64      `json:"cost" validate:"min=0"`
+	Stock      int        `json:"stock" validate:"min=0"`
+	Version    int64      `json:"version"`
 	CreatedAt  time.Time  `json:"created_at"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
 	Categories []Category `json:"categories"`
 }
 
@@ -15,3 +20,8 @@ type ProductWithoutCategories struct {
 	Product
 	Categories []Category `json:"categories,omitempty"`
 }
+
+// ProductCategoryRequest adds a product to a category or removes it.
+type ProductCategoryRequest struct {
+	CategoryID string `json:"category_id"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type ReportBestSeller struct {
 	ProductID   string `json:"product_id"`
 	ProductName string `json:"product_name"`
@@ -12,3 +14,111 @@ type Report struct {
 	TotalTransactions int64            `json:"total_transactions"`
 	BestSeller        ReportBestSeller `json:"best_seller"`
 }
+
+const (
+	GroupByHour     = "hour"
+	GroupByDay      = "day"
+	GroupByWeek     = "week"
+	GroupByMonth    = "month"
+	GroupByProduct  = "product"
+	GroupByCategory = "category"
+	GroupByCashier  = "cashier"
+)
+
+type SalesReportRow struct {
+	Key              string `json:"key"`
+	Label            string `json:"label"`
+	Revenue          int64  `json:"revenue"`
+	Quantity         int64  `json:"quantity"`
+	TransactionCount int64  `json:"transaction_count"`
+	AverageBasket    int64  `json:"average_basket"`
+}
+
+type SalesReport struct {
+	GroupBy string           `json:"group_by"`
+	From    string           `json:"from,omitempty"`
+	To      string           `json:"to,omitempty"`
+	Totals  SalesReportRow   `json:"totals"`
+	Rows    []SalesReportRow `json:"rows"`
+}
+
+const (
+	RankByQuantity = "quantity"
+	RankByRevenue  = "revenue"
+	RankByMargin   = "margin"
+)
+
+// ProductPerformance is a product's sales over a period. Cost and margin use
+// the product's current cost, not the cost at the time of each sale.
+type ProductPerformance struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Stock       int    `json:"stock"`
+	Quantity    int64  `json:"quantity"`
+	Revenue     int64  `json:"revenue"`
+	Cost        int64  `json:"cost"`
+	Margin      int64  `json:"margin"`
+}
+
+type ProductRankingReport struct {
+	Metric string               `json:"metric"`
+	From   string               `json:"from,omitempty"`
+	To     string               `json:"to,omitempty"`
+	Top    []ProductPerformance `json:"top"`
+	Bottom []ProductPerformance `json:"bottom"`
+}
+
+type DeadStockItem struct {
+	ProductID      string     `json:"product_id"`
+	ProductName    string     `json:"product_name"`
+	Stock          int        `json:"stock"`
+	Cost           int64      `json:"cost"`
+	Price          int64      `json:"price"`
+	InventoryCost  int64      `json:"inventory_cost"`
+	InventoryValue int64      `json:"inventory_value"`
+	LastSoldAt     *time.Time `json:"last_sold_at"`
+}
+
+type DeadStockReport struct {
+	Days                int             `json:"days"`
+	Since               time.Time       `json:"since"`
+	TotalInventoryCost  int64           `json:"total_inventory_cost"`
+	TotalInventoryValue int64           `json:"total_inventory_value"`
+	Items               []DeadStockItem `json:"items"`
+}
+
+const (
+	ClassA = "A"
+	ClassB = "B"
+	ClassC = "C"
+)
+
+// ABCItem is a product's share of revenue over the period and how fast its
+// stock moves. DaysOfCover is null for products that did not sell.
+type ABCItem struct {
+	ProductPerformance
+	RevenueShare      float64  `json:"revenue_share"`
+	CumulativeShare   float64  `json:"cumulative_share"`
+	Class             string   `json:"class"`
+	SellThroughRate   float64  `json:"sell_through_rate"`
+	AverageDailySales float64  `json:"average_daily_sales"`
+	DaysOfCover       *float64 `json:"days_of_cover"`
+}
+
+type ABCClassSummary struct {
+	Class        string  `json:"class"`
+	ProductCount int     `json:"product_count"`
+	Revenue      int64   `json:"revenue"`
+	RevenueShare float64 `json:"revenue_share"`
+}
+
+type ABCReport struct {
+	From         time.Time         `json:"from"`
+	To           time.Time         `json:"to"`
+	Days         int               `json:"days"`
+	ThresholdA   float64           `json:"threshold_a"`
+	ThresholdB   float64           `json:"threshold_b"`
+	TotalRevenue int64             `json:"total_revenue"`
+	Classes      []ABCClassSummary `json:"classes"`
+	Items        []ABCItem         `json:"items"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// RollupMismatch is a day, and product when ProductID is set, where the
+// daily sales rollup disagrees with the raw transactions.
+type RollupMismatch struct {
+	Date               string `json:"date"`
+	ProductID          string `json:"product_id,omitempty"`
+	RollupQuantity     int64  `json:"rollup_quantity"`
+	RawQuantity        int64  `json:"raw_quantity"`
+	RollupRevenue      int64  `json:"rollup_revenue"`
+	RawRevenue         int64  `json:"raw_revenue"`
+	RollupTransactions int64  `json:"rollup_transactions"`
+	RawTransactions    int64  `json:"raw_transactions"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+const (
+	SerialStatusInStock = "in_stock"
+	SerialStatusSold    = "sold"
+
+	SerialEventReceived = "received"
+	SerialEventSold     = "sold"
+)
+
+type ProductSerial struct {
+	ID            string     `json:"id"`
+	ProductID     string     `json:"product_id"`
+	SerialNumber  string     `json:"serial_number"`
+	Status        string     `json:"status"`
+	TransactionID string     `json:"transaction_id,omitempty"`
+	ReceivedAt    time.Time  `json:"received_at"`
+	SoldAt        *time.Time `json:"sold_at,omitempty"`
+}
+
+type SerialEvent struct {
+	Event               string    `json:"event"`
+	TransactionID       string    `json:"transaction_id,omitempty"`
+	TransactionDetailID string    `json:"transaction_detail_id,omitempty"`
+	Price               int64     `json:"price,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+type SerialHistory struct {
+	ProductSerial
+	ProductName string        `json:"product_name"`
+	Events      []SerialEvent `json:"events"`
+}
+
+type ReceiveSerialsRequest struct {
+	SerialNumbers []string `json:"serial_numbers"`
+}
@@ -5,9 +5,11 @@ import (
 )
 
 type Transaction struct {
-	ID          string    `json:"id"`
-	TotalAmount int64     `json:"total_amount"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          string              `json:"id"`
+	TotalAmount int64               `json:"total_amount"`
+	CustomerID  string              `json:"customer_id,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	Details     []TransactionDetail `json:"details,omitempty"`
 }
 
 type TransactionDetail struct {
@@ -18,6 +20,9 @@ type TransactionDetail struct {
 	Quantity      int       `json:"quantity"`
 	Subtotal      int64     `json:"subtotal"`
 	Price         int64     `json:"price"`
+	LotID         string    `json:"lot_id,omitempty"`
+	SerialNumber  string    `json:"serial_number,omitempty"`
+	PriceListID   string    `json:"price_list_id,omitempty"`
 	CreatedAt     time.Time `json:"created_at"`
 }
 
@@ -30,10 +35,12 @@ type ProductDetail struct {
 }
 
 type CheckoutItem struct {
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
+	ProductID     string   `json:"product_id" validate:"required,uuid"`
+	Quantity      int      `json:"quantity" validate:"min=1"`
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
 }
 
 type CheckoutRequest struct {
-	Items []CheckoutItem `json:"items"`
+	CustomerID string         `json:"customer_id,omitempty" validate:"uuid"`
+	Items      []CheckoutItem `json:"items" validate:"required,dive"`
 }
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	OutboxTransactionCreated = "transaction.created"
+	OutboxProductCreated     = "product.created"
+	OutboxProductUpdated     = "product.updated"
+	OutboxProductDeleted     = "product.deleted"
+	OutboxProductRestored    = "product.restored"
+	OutboxCategoryCreated    = "category.created"
+	OutboxCategoryUpdated    = "category.updated"
+	OutboxCategoryDeleted    = "category.deleted"
+	OutboxCategoryRestored   = "category.restored"
+)
+
+var OutboxEventTypes = []string{
+	OutboxTransactionCreated,
+	OutboxProductCreated, OutboxProductUpdated, OutboxProductDeleted, OutboxProductRestored,
+	OutboxCategoryCreated, OutboxCategoryUpdated, OutboxCategoryDeleted, OutboxCategoryRestored,
+}
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// WebhookSubscription receives the outbox events listed in EventTypes, or all
+// of them when it is empty. The secret signs each delivery and is only
+// returned when the subscription is created.
+type WebhookSubscription struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookPayload is the JSON body posted to a subscriber.
+type WebhookPayload struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	EntityID  string          `json:"entity_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type WebhookDelivery struct {
+	ID             int64          `json:"id"`
+	SubscriptionID string         `json:"subscription_id"`
+	URL            string         `json:"url"`
+	Status         string         `json:"status"`
+	Attempts       int            `json:"attempts"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	LastStatusCode *int           `json:"last_status_code"`
+	LastError      string         `json:"last_error"`
+	DeliveredAt    *time.Time     `json:"delivered_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	Event          WebhookPayload `json:"event"`
+	Secret         string         `json:"-"`
+}
+
+// WebhookRequest creates or replaces a subscription. Active defaults to true.
+type WebhookRequest struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+	Active     *bool    `json:"active"`
+}
@@ -0,0 +1,3207 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: kasir.proto
+
+// The gRPC API mirrors the REST one over the same services. Money amounts are
+// whole rupiah and ids are uuids, as in the JSON API. Dates are store days
+// written as 2006-01-02.
+
+package kasirpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Product struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Sku           string                 `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty"`
+	Barcode       string                 `protobuf:"bytes,4,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	Price         int64                  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
+	Cost          int64                  `protobuf:"varint,6,opt,name=cost,proto3" json:"cost,omitempty"`
+	Stock         int32                  `protobuf:"varint,7,opt,name=stock,proto3" json:"stock,omitempty"`
+	Version       int64                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	Categories    []*Category            `protobuf:"bytes,11,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_kasir_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Product) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *Product) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Product) GetCost() int64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *Product) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *Product) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Product) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Version       int64                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_kasir_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Category) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Category) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Category) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Category) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type ListProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name keeps the products whose name contains it.
+	Name            string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	IncludeArchived bool   `protobuf:"varint,2,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_kasir_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListProductsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_kasir_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type GetProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_kasir_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_kasir_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateProductRequest) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type UpdateProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Product       *Product               `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	IfMatch       int64                  `protobuf:"varint,3,opt,name=if_match,json=ifMatch,proto3" json:"if_match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_kasir_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetIfMatch() int64 {
+	if x != nil {
+		return x.IfMatch
+	}
+	return 0
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IfMatch       int64                  `protobuf:"varint,2,opt,name=if_match,json=ifMatch,proto3" json:"if_match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_kasir_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteProductRequest) GetIfMatch() int64 {
+	if x != nil {
+		return x.IfMatch
+	}
+	return 0
+}
+
+type RestoreProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreProductRequest) Reset() {
+	*x = RestoreProductRequest{}
+	mi := &file_kasir_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductRequest) ProtoMessage() {}
+
+func (x *RestoreProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductRequest.ProtoReflect.Descriptor instead.
+func (*RestoreProductRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RestoreProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListProductCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductCategoriesRequest) Reset() {
+	*x = ListProductCategoriesRequest{}
+	mi := &file_kasir_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductCategoriesRequest) ProtoMessage() {}
+
+func (x *ListProductCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListProductCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListProductCategoriesRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type ProductCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductCategoryRequest) Reset() {
+	*x = ProductCategoryRequest{}
+	mi := &file_kasir_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductCategoryRequest) ProtoMessage() {}
+
+func (x *ProductCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductCategoryRequest.ProtoReflect.Descriptor instead.
+func (*ProductCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ProductCategoryRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductCategoryRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+type ProductCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductCategoryResponse) Reset() {
+	*x = ProductCategoryResponse{}
+	mi := &file_kasir_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductCategoryResponse) ProtoMessage() {}
+
+func (x *ProductCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductCategoryResponse.ProtoReflect.Descriptor instead.
+func (*ProductCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{11}
+}
+
+type ListCategoriesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeArchived bool                   `protobuf:"varint,1,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_kasir_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListCategoriesRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type ListCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_kasir_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type GetCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryRequest) Reset() {
+	*x = GetCategoryRequest{}
+	mi := &file_kasir_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryRequest) ProtoMessage() {}
+
+func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetCategoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_kasir_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateCategoryRequest) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type UpdateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category      *Category              `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	IfMatch       int64                  `protobuf:"varint,3,opt,name=if_match,json=ifMatch,proto3" json:"if_match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryRequest) Reset() {
+	*x = UpdateCategoryRequest{}
+	mi := &file_kasir_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryRequest) ProtoMessage() {}
+
+func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateCategoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCategoryRequest) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *UpdateCategoryRequest) GetIfMatch() int64 {
+	if x != nil {
+		return x.IfMatch
+	}
+	return 0
+}
+
+type DeleteCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IfMatch       int64                  `protobuf:"varint,2,opt,name=if_match,json=ifMatch,proto3" json:"if_match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryRequest) Reset() {
+	*x = DeleteCategoryRequest{}
+	mi := &file_kasir_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryRequest) ProtoMessage() {}
+
+func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteCategoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteCategoryRequest) GetIfMatch() int64 {
+	if x != nil {
+		return x.IfMatch
+	}
+	return 0
+}
+
+type RestoreCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreCategoryRequest) Reset() {
+	*x = RestoreCategoryRequest{}
+	mi := &file_kasir_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCategoryRequest) ProtoMessage() {}
+
+func (x *RestoreCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCategoryRequest.ProtoReflect.Descriptor instead.
+func (*RestoreCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RestoreCategoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListCategoryProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    string                 `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoryProductsRequest) Reset() {
+	*x = ListCategoryProductsRequest{}
+	mi := &file_kasir_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoryProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoryProductsRequest) ProtoMessage() {}
+
+func (x *ListCategoryProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoryProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoryProductsRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListCategoryProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+type CheckoutItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	SerialNumbers []string               `protobuf:"bytes,3,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutItem) Reset() {
+	*x = CheckoutItem{}
+	mi := &file_kasir_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutItem) ProtoMessage() {}
+
+func (x *CheckoutItem) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutItem.ProtoReflect.Descriptor instead.
+func (*CheckoutItem) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CheckoutItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CheckoutItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CheckoutItem) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+type CheckoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Items         []*CheckoutItem        `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutRequest) Reset() {
+	*x = CheckoutRequest{}
+	mi := &file_kasir_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutRequest) ProtoMessage() {}
+
+func (x *CheckoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CheckoutRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetItems() []*CheckoutItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Transaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TotalAmount   int64                  `protobuf:"varint,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,3,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Details       []*TransactionDetail   `protobuf:"bytes,5,rep,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_kasir_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Transaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Transaction) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *Transaction) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *Transaction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Transaction) GetDetails() []*TransactionDetail {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type TransactionDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TransactionId string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName   string                 `protobuf:"bytes,4,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Subtotal      int64                  `protobuf:"varint,6,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Price         int64                  `protobuf:"varint,7,opt,name=price,proto3" json:"price,omitempty"`
+	LotId         string                 `protobuf:"bytes,8,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	SerialNumber  string                 `protobuf:"bytes,9,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	PriceListId   string                 `protobuf:"bytes,10,opt,name=price_list_id,json=priceListId,proto3" json:"price_list_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionDetail) Reset() {
+	*x = TransactionDetail{}
+	mi := &file_kasir_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionDetail) ProtoMessage() {}
+
+func (x *TransactionDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionDetail.ProtoReflect.Descriptor instead.
+func (*TransactionDetail) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TransactionDetail) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TransactionDetail) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *TransactionDetail) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *TransactionDetail) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *TransactionDetail) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *TransactionDetail) GetSubtotal() int64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *TransactionDetail) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *TransactionDetail) GetLotId() string {
+	if x != nil {
+		return x.LotId
+	}
+	return ""
+}
+
+func (x *TransactionDetail) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *TransactionDetail) GetPriceListId() string {
+	if x != nil {
+		return x.PriceListId
+	}
+	return ""
+}
+
+func (x *TransactionDetail) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type DateRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DateRange) Reset() {
+	*x = DateRange{}
+	mi := &file_kasir_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DateRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DateRange) ProtoMessage() {}
+
+func (x *DateRange) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DateRange.ProtoReflect.Descriptor instead.
+func (*DateRange) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DateRange) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *DateRange) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type ListTransactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An empty range lists every transaction.
+	Range         *DateRange `protobuf:"bytes,1,opt,name=range,proto3" json:"range,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_kasir_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListTransactionsRequest) GetRange() *DateRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+type ListTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_kasir_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type StreamEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// types keeps only these event types, all of them when empty.
+	Types []string `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	// last_event_id resumes after that event.
+	LastEventId   int64 `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_kasir_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *StreamEventsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *StreamEventsRequest) GetLastEventId() int64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+type DayTotals struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Date             string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Revenue          int64                  `protobuf:"varint,2,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Quantity         int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TransactionCount int64                  `protobuf:"varint,4,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DayTotals) Reset() {
+	*x = DayTotals{}
+	mi := &file_kasir_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DayTotals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DayTotals) ProtoMessage() {}
+
+func (x *DayTotals) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DayTotals.ProtoReflect.Descriptor instead.
+func (*DayTotals) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DayTotals) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DayTotals) GetRevenue() int64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *DayTotals) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *DayTotals) GetTransactionCount() int64 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+type CheckoutEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Today         *DayTotals             `protobuf:"bytes,2,opt,name=today,proto3" json:"today,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutEvent) Reset() {
+	*x = CheckoutEvent{}
+	mi := &file_kasir_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutEvent) ProtoMessage() {}
+
+func (x *CheckoutEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutEvent.ProtoReflect.Descriptor instead.
+func (*CheckoutEvent) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CheckoutEvent) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *CheckoutEvent) GetToday() *DayTotals {
+	if x != nil {
+		return x.Today
+	}
+	return nil
+}
+
+type LowStockEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName   string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Stock         int32                  `protobuf:"varint,3,opt,name=stock,proto3" json:"stock,omitempty"`
+	Threshold     int32                  `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LowStockEvent) Reset() {
+	*x = LowStockEvent{}
+	mi := &file_kasir_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LowStockEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LowStockEvent) ProtoMessage() {}
+
+func (x *LowStockEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LowStockEvent.ProtoReflect.Descriptor instead.
+func (*LowStockEvent) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *LowStockEvent) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *LowStockEvent) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *LowStockEvent) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *LowStockEvent) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+// Event ids increase monotonically; the initial totals have none.
+type Event struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type      string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*Event_Checkout
+	//	*Event_LowStock
+	//	*Event_Totals
+	Data          isEvent_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_kasir_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Event) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Event) GetData() isEvent_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Event) GetCheckout() *CheckoutEvent {
+	if x != nil {
+		if x, ok := x.Data.(*Event_Checkout); ok {
+			return x.Checkout
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetLowStock() *LowStockEvent {
+	if x != nil {
+		if x, ok := x.Data.(*Event_LowStock); ok {
+			return x.LowStock
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTotals() *DayTotals {
+	if x != nil {
+		if x, ok := x.Data.(*Event_Totals); ok {
+			return x.Totals
+		}
+	}
+	return nil
+}
+
+type isEvent_Data interface {
+	isEvent_Data()
+}
+
+type Event_Checkout struct {
+	Checkout *CheckoutEvent `protobuf:"bytes,4,opt,name=checkout,proto3,oneof"`
+}
+
+type Event_LowStock struct {
+	LowStock *LowStockEvent `protobuf:"bytes,5,opt,name=low_stock,json=lowStock,proto3,oneof"`
+}
+
+type Event_Totals struct {
+	Totals *DayTotals `protobuf:"bytes,6,opt,name=totals,proto3,oneof"`
+}
+
+func (*Event_Checkout) isEvent_Data() {}
+
+func (*Event_LowStock) isEvent_Data() {}
+
+func (*Event_Totals) isEvent_Data() {}
+
+type GetReportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An empty range covers every sale.
+	Range         *DateRange `protobuf:"bytes,1,opt,name=range,proto3" json:"range,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportRequest) Reset() {
+	*x = GetReportRequest{}
+	mi := &file_kasir_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportRequest) ProtoMessage() {}
+
+func (x *GetReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportRequest.ProtoReflect.Descriptor instead.
+func (*GetReportRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetReportRequest) GetRange() *DateRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+type GetReportTodayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportTodayRequest) Reset() {
+	*x = GetReportTodayRequest{}
+	mi := &file_kasir_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportTodayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportTodayRequest) ProtoMessage() {}
+
+func (x *GetReportTodayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportTodayRequest.ProtoReflect.Descriptor instead.
+func (*GetReportTodayRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{33}
+}
+
+type ReportBestSeller struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName   string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Quantity      int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TotalAmount   int64                  `protobuf:"varint,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportBestSeller) Reset() {
+	*x = ReportBestSeller{}
+	mi := &file_kasir_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportBestSeller) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportBestSeller) ProtoMessage() {}
+
+func (x *ReportBestSeller) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportBestSeller.ProtoReflect.Descriptor instead.
+func (*ReportBestSeller) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReportBestSeller) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReportBestSeller) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *ReportBestSeller) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReportBestSeller) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+type Report struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TotalRevenue      int64                  `protobuf:"varint,1,opt,name=total_revenue,json=totalRevenue,proto3" json:"total_revenue,omitempty"`
+	TotalTransactions int64                  `protobuf:"varint,2,opt,name=total_transactions,json=totalTransactions,proto3" json:"total_transactions,omitempty"`
+	BestSeller        *ReportBestSeller      `protobuf:"bytes,3,opt,name=best_seller,json=bestSeller,proto3" json:"best_seller,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Report) Reset() {
+	*x = Report{}
+	mi := &file_kasir_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Report) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report) ProtoMessage() {}
+
+func (x *Report) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report.ProtoReflect.Descriptor instead.
+func (*Report) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Report) GetTotalRevenue() int64 {
+	if x != nil {
+		return x.TotalRevenue
+	}
+	return 0
+}
+
+func (x *Report) GetTotalTransactions() int64 {
+	if x != nil {
+		return x.TotalTransactions
+	}
+	return 0
+}
+
+func (x *Report) GetBestSeller() *ReportBestSeller {
+	if x != nil {
+		return x.BestSeller
+	}
+	return nil
+}
+
+type GetSalesReportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// group_by is hour, day, week, month, product or category, day when empty.
+	GroupBy       string     `protobuf:"bytes,1,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	Range         *DateRange `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSalesReportRequest) Reset() {
+	*x = GetSalesReportRequest{}
+	mi := &file_kasir_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSalesReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSalesReportRequest) ProtoMessage() {}
+
+func (x *GetSalesReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSalesReportRequest.ProtoReflect.Descriptor instead.
+func (*GetSalesReportRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetSalesReportRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+func (x *GetSalesReportRequest) GetRange() *DateRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+type SalesReportRow struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Key              string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Label            string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Revenue          int64                  `protobuf:"varint,3,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Quantity         int64                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TransactionCount int64                  `protobuf:"varint,5,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	AverageBasket    int64                  `protobuf:"varint,6,opt,name=average_basket,json=averageBasket,proto3" json:"average_basket,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SalesReportRow) Reset() {
+	*x = SalesReportRow{}
+	mi := &file_kasir_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SalesReportRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SalesReportRow) ProtoMessage() {}
+
+func (x *SalesReportRow) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SalesReportRow.ProtoReflect.Descriptor instead.
+func (*SalesReportRow) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SalesReportRow) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SalesReportRow) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *SalesReportRow) GetRevenue() int64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *SalesReportRow) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SalesReportRow) GetTransactionCount() int64 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *SalesReportRow) GetAverageBasket() int64 {
+	if x != nil {
+		return x.AverageBasket
+	}
+	return 0
+}
+
+type SalesReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupBy       string                 `protobuf:"bytes,1,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Totals        *SalesReportRow        `protobuf:"bytes,4,opt,name=totals,proto3" json:"totals,omitempty"`
+	Rows          []*SalesReportRow      `protobuf:"bytes,5,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SalesReport) Reset() {
+	*x = SalesReport{}
+	mi := &file_kasir_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SalesReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SalesReport) ProtoMessage() {}
+
+func (x *SalesReport) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SalesReport.ProtoReflect.Descriptor instead.
+func (*SalesReport) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SalesReport) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+func (x *SalesReport) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SalesReport) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SalesReport) GetTotals() *SalesReportRow {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *SalesReport) GetRows() []*SalesReportRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type GetProductRankingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// metric is quantity, revenue or margin, quantity when empty. top and
+	// bottom default to 10 when zero.
+	Metric        string     `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"`
+	Top           int32      `protobuf:"varint,2,opt,name=top,proto3" json:"top,omitempty"`
+	Bottom        int32      `protobuf:"varint,3,opt,name=bottom,proto3" json:"bottom,omitempty"`
+	Range         *DateRange `protobuf:"bytes,4,opt,name=range,proto3" json:"range,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRankingRequest) Reset() {
+	*x = GetProductRankingRequest{}
+	mi := &file_kasir_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRankingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRankingRequest) ProtoMessage() {}
+
+func (x *GetProductRankingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRankingRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRankingRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetProductRankingRequest) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *GetProductRankingRequest) GetTop() int32 {
+	if x != nil {
+		return x.Top
+	}
+	return 0
+}
+
+func (x *GetProductRankingRequest) GetBottom() int32 {
+	if x != nil {
+		return x.Bottom
+	}
+	return 0
+}
+
+func (x *GetProductRankingRequest) GetRange() *DateRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+type ProductPerformance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName   string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Stock         int32                  `protobuf:"varint,3,opt,name=stock,proto3" json:"stock,omitempty"`
+	Quantity      int64                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Revenue       int64                  `protobuf:"varint,5,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Cost          int64                  `protobuf:"varint,6,opt,name=cost,proto3" json:"cost,omitempty"`
+	Margin        int64                  `protobuf:"varint,7,opt,name=margin,proto3" json:"margin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductPerformance) Reset() {
+	*x = ProductPerformance{}
+	mi := &file_kasir_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductPerformance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductPerformance) ProtoMessage() {}
+
+func (x *ProductPerformance) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductPerformance.ProtoReflect.Descriptor instead.
+func (*ProductPerformance) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ProductPerformance) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductPerformance) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *ProductPerformance) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *ProductPerformance) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ProductPerformance) GetRevenue() int64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *ProductPerformance) GetCost() int64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *ProductPerformance) GetMargin() int64 {
+	if x != nil {
+		return x.Margin
+	}
+	return 0
+}
+
+type ProductRankingReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metric        string                 `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Top           []*ProductPerformance  `protobuf:"bytes,4,rep,name=top,proto3" json:"top,omitempty"`
+	Bottom        []*ProductPerformance  `protobuf:"bytes,5,rep,name=bottom,proto3" json:"bottom,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductRankingReport) Reset() {
+	*x = ProductRankingReport{}
+	mi := &file_kasir_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductRankingReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductRankingReport) ProtoMessage() {}
+
+func (x *ProductRankingReport) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductRankingReport.ProtoReflect.Descriptor instead.
+func (*ProductRankingReport) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ProductRankingReport) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *ProductRankingReport) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ProductRankingReport) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ProductRankingReport) GetTop() []*ProductPerformance {
+	if x != nil {
+		return x.Top
+	}
+	return nil
+}
+
+func (x *ProductRankingReport) GetBottom() []*ProductPerformance {
+	if x != nil {
+		return x.Bottom
+	}
+	return nil
+}
+
+type GetDeadStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// days without a sale, 90 when zero.
+	Days          int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeadStockRequest) Reset() {
+	*x = GetDeadStockRequest{}
+	mi := &file_kasir_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeadStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeadStockRequest) ProtoMessage() {}
+
+func (x *GetDeadStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeadStockRequest.ProtoReflect.Descriptor instead.
+func (*GetDeadStockRequest) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetDeadStockRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type DeadStockItem struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProductId      string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName    string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Stock          int32                  `protobuf:"varint,3,opt,name=stock,proto3" json:"stock,omitempty"`
+	Cost           int64                  `protobuf:"varint,4,opt,name=cost,proto3" json:"cost,omitempty"`
+	Price          int64                  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
+	InventoryCost  int64                  `protobuf:"varint,6,opt,name=inventory_cost,json=inventoryCost,proto3" json:"inventory_cost,omitempty"`
+	InventoryValue int64                  `protobuf:"varint,7,opt,name=inventory_value,json=inventoryValue,proto3" json:"inventory_value,omitempty"`
+	LastSoldAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_sold_at,json=lastSoldAt,proto3" json:"last_sold_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeadStockItem) Reset() {
+	*x = DeadStockItem{}
+	mi := &file_kasir_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadStockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadStockItem) ProtoMessage() {}
+
+func (x *DeadStockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadStockItem.ProtoReflect.Descriptor instead.
+func (*DeadStockItem) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DeadStockItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DeadStockItem) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *DeadStockItem) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *DeadStockItem) GetCost() int64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *DeadStockItem) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *DeadStockItem) GetInventoryCost() int64 {
+	if x != nil {
+		return x.InventoryCost
+	}
+	return 0
+}
+
+func (x *DeadStockItem) GetInventoryValue() int64 {
+	if x != nil {
+		return x.InventoryValue
+	}
+	return 0
+}
+
+func (x *DeadStockItem) GetLastSoldAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSoldAt
+	}
+	return nil
+}
+
+type DeadStockReport struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Days                int32                  `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	Since               *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	TotalInventoryCost  int64                  `protobuf:"varint,3,opt,name=total_inventory_cost,json=totalInventoryCost,proto3" json:"total_inventory_cost,omitempty"`
+	TotalInventoryValue int64                  `protobuf:"varint,4,opt,name=total_inventory_value,json=totalInventoryValue,proto3" json:"total_inventory_value,omitempty"`
+	Items               []*DeadStockItem       `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DeadStockReport) Reset() {
+	*x = DeadStockReport{}
+	mi := &file_kasir_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadStockReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadStockReport) ProtoMessage() {}
+
+func (x *DeadStockReport) ProtoReflect() protoreflect.Message {
+	mi := &file_kasir_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadStockReport.ProtoReflect.Descriptor instead.
+func (*DeadStockReport) Descriptor() ([]byte, []int) {
+	return file_kasir_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DeadStockReport) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *DeadStockReport) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *DeadStockReport) GetTotalInventoryCost() int64 {
+	if x != nil {
+		return x.TotalInventoryCost
+	}
+	return 0
+}
+
+func (x *DeadStockReport) GetTotalInventoryValue() int64 {
+	if x != nil {
+		return x.TotalInventoryValue
+	}
+	return 0
+}
+
+func (x *DeadStockReport) GetItems() []*DeadStockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_kasir_proto protoreflect.FileDescriptor
+
+const file_kasir_proto_rawDesc = "" +
+	"\n" +
+	"\vkasir.proto\x12\bkasir.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdd\x02\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03sku\x18\x03 \x01(\tR\x03sku\x12\x18\n" +
+	"\abarcode\x18\x04 \x01(\tR\abarcode\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x03R\x05price\x12\x12\n" +
+	"\x04cost\x18\x06 \x01(\x03R\x04cost\x12\x14\n" +
+	"\x05stock\x18\a \x01(\x05R\x05stock\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x122\n" +
+	"\n" +
+	"categories\x18\v \x03(\v2\x12.kasir.v1.CategoryR\n" +
+	"categories\"\xe0\x01\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"T\n" +
+	"\x13ListProductsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12)\n" +
+	"\x10include_archived\x18\x02 \x01(\bR\x0fincludeArchived\"E\n" +
+	"\x14ListProductsResponse\x12-\n" +
+	"\bproducts\x18\x01 \x03(\v2\x11.kasir.v1.ProductR\bproducts\"#\n" +
+	"\x11GetProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"C\n" +
+	"\x14CreateProductRequest\x12+\n" +
+	"\aproduct\x18\x01 \x01(\v2\x11.kasir.v1.ProductR\aproduct\"n\n" +
+	"\x14UpdateProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12+\n" +
+	"\aproduct\x18\x02 \x01(\v2\x11.kasir.v1.ProductR\aproduct\x12\x19\n" +
+	"\bif_match\x18\x03 \x01(\x03R\aifMatch\"A\n" +
+	"\x14DeleteProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bif_match\x18\x02 \x01(\x03R\aifMatch\"'\n" +
+	"\x15RestoreProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"=\n" +
+	"\x1cListProductCategoriesRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"X\n" +
+	"\x16ProductCategoryRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\"\x19\n" +
+	"\x17ProductCategoryResponse\"B\n" +
+	"\x15ListCategoriesRequest\x12)\n" +
+	"\x10include_archived\x18\x01 \x01(\bR\x0fincludeArchived\"L\n" +
+	"\x16ListCategoriesResponse\x122\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x12.kasir.v1.CategoryR\n" +
+	"categories\"$\n" +
+	"\x12GetCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"G\n" +
+	"\x15CreateCategoryRequest\x12.\n" +
+	"\bcategory\x18\x01 \x01(\v2\x12.kasir.v1.CategoryR\bcategory\"r\n" +
+	"\x15UpdateCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\bcategory\x18\x02 \x01(\v2\x12.kasir.v1.CategoryR\bcategory\x12\x19\n" +
+	"\bif_match\x18\x03 \x01(\x03R\aifMatch\"B\n" +
+	"\x15DeleteCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bif_match\x18\x02 \x01(\x03R\aifMatch\"(\n" +
+	"\x16RestoreCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x1bListCategoryProductsRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\"p\n" +
+	"\fCheckoutItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12%\n" +
+	"\x0eserial_numbers\x18\x03 \x03(\tR\rserialNumbers\"`\n" +
+	"\x0fCheckoutRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12,\n" +
+	"\x05items\x18\x02 \x03(\v2\x16.kasir.v1.CheckoutItemR\x05items\"\xd3\x01\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\ftotal_amount\x18\x02 \x01(\x03R\vtotalAmount\x12\x1f\n" +
+	"\vcustomer_id\x18\x03 \x01(\tR\n" +
+	"customerId\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x125\n" +
+	"\adetails\x18\x05 \x03(\v2\x1b.kasir.v1.TransactionDetailR\adetails\"\xf5\x02\n" +
+	"\x11TransactionDetail\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_name\x18\x04 \x01(\tR\vproductName\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\bsubtotal\x18\x06 \x01(\x03R\bsubtotal\x12\x14\n" +
+	"\x05price\x18\a \x01(\x03R\x05price\x12\x15\n" +
+	"\x06lot_id\x18\b \x01(\tR\x05lotId\x12#\n" +
+	"\rserial_number\x18\t \x01(\tR\fserialNumber\x12\"\n" +
+	"\rprice_list_id\x18\n" +
+	" \x01(\tR\vpriceListId\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"/\n" +
+	"\tDateRange\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"D\n" +
+	"\x17ListTransactionsRequest\x12)\n" +
+	"\x05range\x18\x01 \x01(\v2\x13.kasir.v1.DateRangeR\x05range\"U\n" +
+	"\x18ListTransactionsResponse\x129\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x15.kasir.v1.TransactionR\ftransactions\"O\n" +
+	"\x13StreamEventsRequest\x12\x14\n" +
+	"\x05types\x18\x01 \x03(\tR\x05types\x12\"\n" +
+	"\rlast_event_id\x18\x02 \x01(\x03R\vlastEventId\"\x82\x01\n" +
+	"\tDayTotals\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x18\n" +
+	"\arevenue\x18\x02 \x01(\x03R\arevenue\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x03R\bquantity\x12+\n" +
+	"\x11transaction_count\x18\x04 \x01(\x03R\x10transactionCount\"s\n" +
+	"\rCheckoutEvent\x127\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x15.kasir.v1.TransactionR\vtransaction\x12)\n" +
+	"\x05today\x18\x02 \x01(\v2\x13.kasir.v1.DayTotalsR\x05today\"\x85\x01\n" +
+	"\rLowStockEvent\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12\x14\n" +
+	"\x05stock\x18\x03 \x01(\x05R\x05stock\x12\x1c\n" +
+	"\tthreshold\x18\x04 \x01(\x05R\tthreshold\"\x8c\x02\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x125\n" +
+	"\bcheckout\x18\x04 \x01(\v2\x17.kasir.v1.CheckoutEventH\x00R\bcheckout\x126\n" +
+	"\tlow_stock\x18\x05 \x01(\v2\x17.kasir.v1.LowStockEventH\x00R\blowStock\x12-\n" +
+	"\x06totals\x18\x06 \x01(\v2\x13.kasir.v1.DayTotalsH\x00R\x06totalsB\x06\n" +
+	"\x04data\"=\n" +
+	"\x10GetReportRequest\x12)\n" +
+	"\x05range\x18\x01 \x01(\v2\x13.kasir.v1.DateRangeR\x05range\"\x17\n" +
+	"\x15GetReportTodayRequest\"\x93\x01\n" +
+	"\x10ReportBestSeller\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x03R\bquantity\x12!\n" +
+	"\ftotal_amount\x18\x04 \x01(\x03R\vtotalAmount\"\x99\x01\n" +
+	"\x06Report\x12#\n" +
+	"\rtotal_revenue\x18\x01 \x01(\x03R\ftotalRevenue\x12-\n" +
+	"\x12total_transactions\x18\x02 \x01(\x03R\x11totalTransactions\x12;\n" +
+	"\vbest_seller\x18\x03 \x01(\v2\x1a.kasir.v1.ReportBestSellerR\n" +
+	"bestSeller\"]\n" +
+	"\x15GetSalesReportRequest\x12\x19\n" +
+	"\bgroup_by\x18\x01 \x01(\tR\agroupBy\x12)\n" +
+	"\x05range\x18\x02 \x01(\v2\x13.kasir.v1.DateRangeR\x05range\"\xc2\x01\n" +
+	"\x0eSalesReportRow\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x18\n" +
+	"\arevenue\x18\x03 \x01(\x03R\arevenue\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x03R\bquantity\x12+\n" +
+	"\x11transaction_count\x18\x05 \x01(\x03R\x10transactionCount\x12%\n" +
+	"\x0eaverage_basket\x18\x06 \x01(\x03R\raverageBasket\"\xac\x01\n" +
+	"\vSalesReport\x12\x19\n" +
+	"\bgroup_by\x18\x01 \x01(\tR\agroupBy\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x120\n" +
+	"\x06totals\x18\x04 \x01(\v2\x18.kasir.v1.SalesReportRowR\x06totals\x12,\n" +
+	"\x04rows\x18\x05 \x03(\v2\x18.kasir.v1.SalesReportRowR\x04rows\"\x87\x01\n" +
+	"\x18GetProductRankingRequest\x12\x16\n" +
+	"\x06metric\x18\x01 \x01(\tR\x06metric\x12\x10\n" +
+	"\x03top\x18\x02 \x01(\x05R\x03top\x12\x16\n" +
+	"\x06bottom\x18\x03 \x01(\x05R\x06bottom\x12)\n" +
+	"\x05range\x18\x04 \x01(\v2\x13.kasir.v1.DateRangeR\x05range\"\xce\x01\n" +
+	"\x12ProductPerformance\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12\x14\n" +
+	"\x05stock\x18\x03 \x01(\x05R\x05stock\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x03R\bquantity\x12\x18\n" +
+	"\arevenue\x18\x05 \x01(\x03R\arevenue\x12\x12\n" +
+	"\x04cost\x18\x06 \x01(\x03R\x04cost\x12\x16\n" +
+	"\x06margin\x18\a \x01(\x03R\x06margin\"\xb8\x01\n" +
+	"\x14ProductRankingReport\x12\x16\n" +
+	"\x06metric\x18\x01 \x01(\tR\x06metric\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12.\n" +
+	"\x03top\x18\x04 \x03(\v2\x1c.kasir.v1.ProductPerformanceR\x03top\x124\n" +
+	"\x06bottom\x18\x05 \x03(\v2\x1c.kasir.v1.ProductPerformanceR\x06bottom\")\n" +
+	"\x13GetDeadStockRequest\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\x05R\x04days\"\x9f\x02\n" +
+	"\rDeadStockItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12\x14\n" +
+	"\x05stock\x18\x03 \x01(\x05R\x05stock\x12\x12\n" +
+	"\x04cost\x18\x04 \x01(\x03R\x04cost\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x03R\x05price\x12%\n" +
+	"\x0einventory_cost\x18\x06 \x01(\x03R\rinventoryCost\x12'\n" +
+	"\x0finventory_value\x18\a \x01(\x03R\x0einventoryValue\x12<\n" +
+	"\flast_sold_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSoldAt\"\xec\x01\n" +
+	"\x0fDeadStockReport\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\x05R\x04days\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x14total_inventory_cost\x18\x03 \x01(\x03R\x12totalInventoryCost\x122\n" +
+	"\x15total_inventory_value\x18\x04 \x01(\x03R\x13totalInventoryValue\x12-\n" +
+	"\x05items\x18\x05 \x03(\v2\x17.kasir.v1.DeadStockItemR\x05items2\xcb\x05\n" +
+	"\x0eProductService\x12M\n" +
+	"\fListProducts\x12\x1d.kasir.v1.ListProductsRequest\x1a\x1e.kasir.v1.ListProductsResponse\x12<\n" +
+	"\n" +
+	"GetProduct\x12\x1b.kasir.v1.GetProductRequest\x1a\x11.kasir.v1.Product\x12B\n" +
+	"\rCreateProduct\x12\x1e.kasir.v1.CreateProductRequest\x1a\x11.kasir.v1.Product\x12B\n" +
+	"\rUpdateProduct\x12\x1e.kasir.v1.UpdateProductRequest\x1a\x11.kasir.v1.Product\x12B\n" +
+	"\rDeleteProduct\x12\x1e.kasir.v1.DeleteProductRequest\x1a\x11.kasir.v1.Product\x12D\n" +
+	"\x0eRestoreProduct\x12\x1f.kasir.v1.RestoreProductRequest\x1a\x11.kasir.v1.Product\x12a\n" +
+	"\x15ListProductCategories\x12&.kasir.v1.ListProductCategoriesRequest\x1a .kasir.v1.ListCategoriesResponse\x12Y\n" +
+	"\x12AddProductCategory\x12 .kasir.v1.ProductCategoryRequest\x1a!.kasir.v1.ProductCategoryResponse\x12\\\n" +
+	"\x15RemoveProductCategory\x12 .kasir.v1.ProductCategoryRequest\x1a!.kasir.v1.ProductCategoryResponse2\xa4\x04\n" +
+	"\x0fCategoryService\x12S\n" +
+	"\x0eListCategories\x12\x1f.kasir.v1.ListCategoriesRequest\x1a .kasir.v1.ListCategoriesResponse\x12?\n" +
+	"\vGetCategory\x12\x1c.kasir.v1.GetCategoryRequest\x1a\x12.kasir.v1.Category\x12E\n" +
+	"\x0eCreateCategory\x12\x1f.kasir.v1.CreateCategoryRequest\x1a\x12.kasir.v1.Category\x12E\n" +
+	"\x0eUpdateCategory\x12\x1f.kasir.v1.UpdateCategoryRequest\x1a\x12.kasir.v1.Category\x12E\n" +
+	"\x0eDeleteCategory\x12\x1f.kasir.v1.DeleteCategoryRequest\x1a\x12.kasir.v1.Category\x12G\n" +
+	"\x0fRestoreCategory\x12 .kasir.v1.RestoreCategoryRequest\x1a\x12.kasir.v1.Category\x12]\n" +
+	"\x14ListCategoryProducts\x12%.kasir.v1.ListCategoryProductsRequest\x1a\x1e.kasir.v1.ListProductsResponse2\xef\x01\n" +
+	"\x12TransactionService\x12<\n" +
+	"\bCheckout\x12\x19.kasir.v1.CheckoutRequest\x1a\x15.kasir.v1.Transaction\x12Y\n" +
+	"\x10ListTransactions\x12!.kasir.v1.ListTransactionsRequest\x1a\".kasir.v1.ListTransactionsResponse\x12@\n" +
+	"\fStreamEvents\x12\x1d.kasir.v1.StreamEventsRequest\x1a\x0f.kasir.v1.Event0\x012\xfc\x02\n" +
+	"\rReportService\x129\n" +
+	"\tGetReport\x12\x1a.kasir.v1.GetReportRequest\x1a\x10.kasir.v1.Report\x12C\n" +
+	"\x0eGetReportToday\x12\x1f.kasir.v1.GetReportTodayRequest\x1a\x10.kasir.v1.Report\x12H\n" +
+	"\x0eGetSalesReport\x12\x1f.kasir.v1.GetSalesReportRequest\x1a\x15.kasir.v1.SalesReport\x12W\n" +
+	"\x11GetProductRanking\x12\".kasir.v1.GetProductRankingRequest\x1a\x1e.kasir.v1.ProductRankingReport\x12H\n" +
+	"\fGetDeadStock\x12\x1d.kasir.v1.GetDeadStockRequest\x1a\x19.kasir.v1.DeadStockReportB\x19Z\x17kasir-api/proto/kasirpbb\x06proto3"
+
+var (
+	file_kasir_proto_rawDescOnce sync.Once
+	file_kasir_proto_rawDescData []byte
+)
+
+func file_kasir_proto_rawDescGZIP() []byte {
+	file_kasir_proto_rawDescOnce.Do(func() {
+		file_kasir_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kasir_proto_rawDesc), len(file_kasir_proto_rawDesc)))
+	})
+	return file_kasir_proto_rawDescData
+}
+
+var file_kasir_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
+var file_kasir_proto_goTypes = []any{
+	(*Product)(nil),                      // 0: kasir.v1.Product
+	(*Category)(nil),                     // 1: kasir.v1.Category
+	(*ListProductsRequest)(nil),          // 2: kasir.v1.ListProductsRequest
+	(*ListProductsResponse)(nil),         // 3: kasir.v1.ListProductsResponse
+	(*GetProductRequest)(nil),            // 4: kasir.v1.GetProductRequest
+	(*CreateProductRequest)(nil),         // 5: kasir.v1.CreateProductRequest
+	(*UpdateProductRequest)(nil),         // 6: kasir.v1.UpdateProductRequest
+	(*DeleteProductRequest)(nil),         // 7: kasir.v1.DeleteProductRequest
+	(*RestoreProductRequest)(nil),        // 8: kasir.v1.RestoreProductRequest
+	(*ListProductCategoriesRequest)(nil), // 9: kasir.v1.ListProductCategoriesRequest
+	(*ProductCategoryRequest)(nil),       // 10: kasir.v1.ProductCategoryRequest
+	(*ProductCategoryResponse)(nil),      // 11: kasir.v1.ProductCategoryResponse
+	(*ListCategoriesRequest)(nil),        // 12: kasir.v1.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),       // 13: kasir.v1.ListCategoriesResponse
+	(*GetCategoryRequest)(nil),           // 14: kasir.v1.GetCategoryRequest
+	(*CreateCategoryRequest)(nil),        // 15: kasir.v1.CreateCategoryRequest
+	(*UpdateCategoryRequest)(nil),        // 16: kasir.v1.UpdateCategoryRequest
+	(*DeleteCategoryRequest)(nil),        // 17: kasir.v1.DeleteCategoryRequest
+	(*RestoreCategoryRequest)(nil),       // 18: kasir.v1.RestoreCategoryRequest
+	(*ListCategoryProductsRequest)(nil),  // 19: kasir.v1.ListCategoryProductsRequest
+	(*CheckoutItem)(nil),                 // 20: kasir.v1.CheckoutItem
+	(*CheckoutRequest)(nil),              // 21: kasir.v1.CheckoutRequest
+	(*Transaction)(nil),                  // 22: kasir.v1.Transaction
+	(*TransactionDetail)(nil),            // 23: kasir.v1.TransactionDetail
+	(*DateRange)(nil),                    // 24: kasir.v1.DateRange
+	(*ListTransactionsRequest)(nil),      // 25: kasir.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),     // 26: kasir.v1.ListTransactionsResponse
+	(*StreamEventsRequest)(nil),          // 27: kasir.v1.StreamEventsRequest
+	(*DayTotals)(nil),                    // 28: kasir.v1.DayTotals
+	(*CheckoutEvent)(nil),                // 29: kasir.v1.CheckoutEvent
+	(*LowStockEvent)(nil),                // 30: kasir.v1.LowStockEvent
+	(*Event)(nil),                        // 31: kasir.v1.Event
+	(*GetReportRequest)(nil),             // 32: kasir.v1.GetReportRequest
+	(*GetReportTodayRequest)(nil),        // 33: kasir.v1.GetReportTodayRequest
+	(*ReportBestSeller)(nil),             // 34: kasir.v1.ReportBestSeller
+	(*Report)(nil),                       // 35: kasir.v1.Report
+	(*GetSalesReportRequest)(nil),        // 36: kasir.v1.GetSalesReportRequest
+	(*SalesReportRow)(nil),               // 37: kasir.v1.SalesReportRow
+	(*SalesReport)(nil),                  // 38: kasir.v1.SalesReport
+	(*GetProductRankingRequest)(nil),     // 39: kasir.v1.GetProductRankingRequest
+	(*ProductPerformance)(nil),           // 40: kasir.v1.ProductPerformance
+	(*ProductRankingReport)(nil),         // 41: kasir.v1.ProductRankingReport
+	(*GetDeadStockRequest)(nil),          // 42: kasir.v1.GetDeadStockRequest
+	(*DeadStockItem)(nil),                // 43: kasir.v1.DeadStockItem
+	(*DeadStockReport)(nil),              // 44: kasir.v1.DeadStockReport
+	(*timestamppb.Timestamp)(nil),        // 45: google.protobuf.Timestamp
+}
+var file_kasir_proto_depIdxs = []int32{
+	45, // 0: kasir.v1.Product.created_at:type_name -> google.protobuf.Timestamp
+	45, // 1: kasir.v1.Product.deleted_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: kasir.v1.Product.categories:type_name -> kasir.v1.Category
+	45, // 3: kasir.v1.Category.created_at:type_name -> google.protobuf.Timestamp
+	45, // 4: kasir.v1.Category.deleted_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: kasir.v1.ListProductsResponse.products:type_name -> kasir.v1.Product
+	0,  // 6: kasir.v1.CreateProductRequest.product:type_name -> kasir.v1.Product
+	0,  // 7: kasir.v1.UpdateProductRequest.product:type_name -> kasir.v1.Product
+	1,  // 8: kasir.v1.ListCategoriesResponse.categories:type_name -> kasir.v1.Category
+	1,  // 9: kasir.v1.CreateCategoryRequest.category:type_name -> kasir.v1.Category
+	1,  // 10: kasir.v1.UpdateCategoryRequest.category:type_name -> kasir.v1.Category
+	20, // 11: kasir.v1.CheckoutRequest.items:type_name -> kasir.v1.CheckoutItem
+	45, // 12: kasir.v1.Transaction.created_at:type_name -> google.protobuf.Timestamp
+	23, // 13: kasir.v1.Transaction.details:type_name -> kasir.v1.TransactionDetail
+	45, // 14: kasir.v1.TransactionDetail.created_at:type_name -> google.protobuf.Timestamp
+	24, // 15: kasir.v1.ListTransactionsRequest.range:type_name -> kasir.v1.DateRange
+	22, // 16: kasir.v1.ListTransactionsResponse.transactions:type_name -> kasir.v1.Transaction
+	22, // 17: kasir.v1.CheckoutEvent.transaction:type_name -> kasir.v1.Transaction
+	28, // 18: kasir.v1.CheckoutEvent.today:type_name -> kasir.v1.DayTotals
+	45, // 19: kasir.v1.Event.created_at:type_name -> google.protobuf.Timestamp
+	29, // 20: kasir.v1.Event.checkout:type_name -> kasir.v1.CheckoutEvent
+	30, // 21: kasir.v1.Event.low_stock:type_name -> kasir.v1.LowStockEvent
+	28, // 22: kasir.v1.Event.totals:type_name -> kasir.v1.DayTotals
+	24, // 23: kasir.v1.GetReportRequest.range:type_name -> kasir.v1.DateRange
+	34, // 24: kasir.v1.Report.best_seller:type_name -> kasir.v1.ReportBestSeller
+	24, // 25: kasir.v1.GetSalesReportRequest.range:type_name -> kasir.v1.DateRange
+	37, // 26: kasir.v1.SalesReport.totals:type_name -> kasir.v1.SalesReportRow
+	37, // 27: kasir.v1.SalesReport.rows:type_name -> kasir.v1.SalesReportRow
+	24, // 28: kasir.v1.GetProductRankingRequest.range:type_name -> kasir.v1.DateRange
+	40, // 29: kasir.v1.ProductRankingReport.top:type_name -> kasir.v1.ProductPerformance
+	40, // 30: kasir.v1.ProductRankingReport.bottom:type_name -> kasir.v1.ProductPerformance
+	45, // 31: kasir.v1.DeadStockItem.last_sold_at:type_name -> google.protobuf.Timestamp
+	45, // 32: kasir.v1.DeadStockReport.since:type_name -> google.protobuf.Timestamp
+	43, // 33: kasir.v1.DeadStockReport.items:type_name -> kasir.v1.DeadStockItem
+	2,  // 34: kasir.v1.ProductService.ListProducts:input_type -> kasir.v1.ListProductsRequest
+	4,  // 35: kasir.v1.ProductService.GetProduct:input_type -> kasir.v1.GetProductRequest
+	5,  // 36: kasir.v1.ProductService.CreateProduct:input_type -> kasir.v1.CreateProductRequest
+	6,  // 37: kasir.v1.ProductService.UpdateProduct:input_type -> kasir.v1.UpdateProductRequest
+	7,  // 38: kasir.v1.ProductService.DeleteProduct:input_type -> kasir.v1.DeleteProductRequest
+	8,  // 39: kasir.v1.ProductService.RestoreProduct:input_type -> kasir.v1.RestoreProductRequest
+	9,  // 40: kasir.v1.ProductService.ListProductCategories:input_type -> kasir.v1.ListProductCategoriesRequest
+	10, // 41: kasir.v1.ProductService.AddProductCategory:input_type -> kasir.v1.ProductCategoryRequest
+	10, // 42: kasir.v1.ProductService.RemoveProductCategory:input_type -> kasir.v1.ProductCategoryRequest
+	12, // 43: kasir.v1.CategoryService.ListCategories:input_type -> kasir.v1.ListCategoriesRequest
+	14, // 44: kasir.v1.CategoryService.GetCategory:input_type -> kasir.v1.GetCategoryRequest
+	15, // 45: kasir.v1.CategoryService.CreateCategory:input_type -> kasir.v1.CreateCategoryRequest
+	16, // 46: kasir.v1.CategoryService.UpdateCategory:input_type -> kasir.v1.UpdateCategoryRequest
+	17, // 47: kasir.v1.CategoryService.DeleteCategory:input_type -> kasir.v1.DeleteCategoryRequest
+	18, // 48: kasir.v1.CategoryService.RestoreCategory:input_type -> kasir.v1.RestoreCategoryRequest
+	19, // 49: kasir.v1.CategoryService.ListCategoryProducts:input_type -> kasir.v1.ListCategoryProductsRequest
+	21, // 50: kasir.v1.TransactionService.Checkout:input_type -> kasir.v1.CheckoutRequest
+	25, // 51: kasir.v1.TransactionService.ListTransactions:input_type -> kasir.v1.ListTransactionsRequest
+	27, // 52: kasir.v1.TransactionService.StreamEvents:input_type -> kasir.v1.StreamEventsRequest
+	32, // 53: kasir.v1.ReportService.GetReport:input_type -> kasir.v1.GetReportRequest
+	33, // 54: kasir.v1.ReportService.GetReportToday:input_type -> kasir.v1.GetReportTodayRequest
+	36, // 55: kasir.v1.ReportService.GetSalesReport:input_type -> kasir.v1.GetSalesReportRequest
+	39, // 56: kasir.v1.ReportService.GetProductRanking:input_type -> kasir.v1.GetProductRankingRequest
+	42, // 57: kasir.v1.ReportService.GetDeadStock:input_type -> kasir.v1.GetDeadStockRequest
+	3,  // 58: kasir.v1.ProductService.ListProducts:output_type -> kasir.v1.ListProductsResponse
+	0,  // 59: kasir.v1.ProductService.GetProduct:output_type -> kasir.v1.Product
+	0,  // 60: kasir.v1.ProductService.CreateProduct:output_type -> kasir.v1.Product
+	0,  // 61: kasir.v1.ProductService.UpdateProduct:output_type -> kasir.v1.Product
+	0,  // 62: kasir.v1.ProductService.DeleteProduct:output_type -> kasir.v1.Product
+	0,  // 63: kasir.v1.ProductService.RestoreProduct:output_type -> kasir.v1.Product
+	13, // 64: kasir.v1.ProductService.ListProductCategories:output_type -> kasir.v1.ListCategoriesResponse
+	11, // 65: kasir.v1.ProductService.AddProductCategory:output_type -> kasir.v1.ProductCategoryResponse
+	11, // 66: kasir.v1.ProductService.RemoveProductCategory:output_type -> kasir.v1.ProductCategoryResponse
+	13, // 67: kasir.v1.CategoryService.ListCategories:output_type -> kasir.v1.ListCategoriesResponse
+	1,  // 68: kasir.v1.CategoryService.GetCategory:output_type -> kasir.v1.Category
+	1,  // 69: kasir.v1.CategoryService.CreateCategory:output_type -> kasir.v1.Category
+	1,  // 70: kasir.v1.CategoryService.UpdateCategory:output_type -> kasir.v1.Category
+	1,  // 71: kasir.v1.CategoryService.DeleteCategory:output_type -> kasir.v1.Category
+	1,  // 72: kasir.v1.CategoryService.RestoreCategory:output_type -> kasir.v1.Category
+	3,  // 73: kasir.v1.CategoryService.ListCategoryProducts:output_type -> kasir.v1.ListProductsResponse
+	22, // 74: kasir.v1.TransactionService.Checkout:output_type -> kasir.v1.Transaction
+	26, // 75: kasir.v1.TransactionService.ListTransactions:output_type -> kasir.v1.ListTransactionsResponse
+	31, // 76: kasir.v1.TransactionService.StreamEvents:output_type -> kasir.v1.Event
+	35, // 77: kasir.v1.ReportService.GetReport:output_type -> kasir.v1.Report
+	35, // 78: kasir.v1.ReportService.GetReportToday:output_type -> kasir.v1.Report
+	38, // 79: kasir.v1.ReportService.GetSalesReport:output_type -> kasir.v1.SalesReport
+	41, // 80: kasir.v1.ReportService.GetProductRanking:output_type -> kasir.v1.ProductRankingReport
+	44, // 81: kasir.v1.ReportService.GetDeadStock:output_type -> kasir.v1.DeadStockReport
+	58, // [58:82] is the sub-list for method output_type
+	34, // [34:58] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_kasir_proto_init() }
+func file_kasir_proto_init() {
+	if File_kasir_proto != nil {
+		return
+	}
+	file_kasir_proto_msgTypes[31].OneofWrappers = []any{
+		(*Event_Checkout)(nil),
+		(*Event_LowStock)(nil),
+		(*Event_Totals)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kasir_proto_rawDesc), len(file_kasir_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   45,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_kasir_proto_goTypes,
+		DependencyIndexes: file_kasir_proto_depIdxs,
+		MessageInfos:      file_kasir_proto_msgTypes,
+	}.Build()
+	File_kasir_proto = out.File
+	file_kasir_proto_goTypes = nil
+	file_kasir_proto_depIdxs = nil
+}
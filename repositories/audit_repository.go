@@ -0,0 +1,233 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+
+	"github.com/lib/pq"
+)
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// setAuditActor tells the audit triggers who is making the changes in this
+// transaction. It must run inside the transaction, the settings end with it.
+func setAuditActor(exec execer, actor models.Actor) error {
+	_, err := exec.Exec("SELECT set_config('audit.claimed_actor', $1, true), set_config('audit.request_id', $2, true), set_config('audit.source_ip', $3, true)",
+		actor.ClaimedName, actor.RequestID, actor.SourceIP)
+	if err != nil {
+		return fmt.Errorf("failed to set audit actor: %w", err)
+	}
+	return nil
+}
+
+// GetAuditLog returns entries matching the filter, newest first. BeforeID
+// pages backwards from a previous result.
+func (r *AuditRepository) GetAuditLog(filter models.AuditFilter) ([]models.AuditEntry, error) {
+	var args []any
+	query := `
+		SELECT id, claimed_actor, action, entity_type, entity_id, before, after, request_id, source_ip, created_at,
+		       seq, COALESCE(prev_hash, ''), COALESCE(hash, '')
+		FROM audit_log
+		WHERE 1=1
+	`
+	conditions := []struct {
+		column string
+		value  any
+		set    bool
+	}{
+		{"claimed_actor", filter.ClaimedActor, filter.ClaimedActor != ""},
+		{"action", filter.Action, filter.Action != ""},
+		{"entity_type", filter.EntityType, filter.EntityType != ""},
+		{"entity_id", filter.EntityID, filter.EntityID != ""},
+		{"request_id", filter.RequestID, filter.RequestID != ""},
+	}
+	for _, condition := range conditions {
+		if condition.set {
+			args = append(args, condition.value)
+			query += fmt.Sprintf(" AND %s = $%d", condition.column, len(args))
+		}
+	}
+	if filter.BeforeID > 0 {
+		args = append(args, filter.BeforeID)
+		query += fmt.Sprintf(" AND id < $%d", len(args))
+	}
+	query += dateRangeCondition("created_at", filter.DateRange, &args)
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]models.AuditEntry, 0)
+	for rows.Next() {
+		var entry models.AuditEntry
+		var before, after []byte
+		err := rows.Scan(&entry.ID, &entry.ClaimedActor, &entry.Action, &entry.EntityType, &entry.EntityID, &before, &after,
+			&entry.RequestID, &entry.SourceIP, &entry.CreatedAt, &entry.Seq, &entry.PrevHash, &entry.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		if before != nil {
+			entry.Before = before
+		}
+		if after != nil {
+			entry.After = after
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// SealAuditLog links up to limit unsealed entries into the hash chain, in id
+// order, and returns how many it sealed. Entries of transactions that have not
+// committed yet are invisible here and get sealed by a later run, after the
+// entries sealed now. Sealers take turns through an advisory lock; the
+// triggers writing entries never wait on it.
+func (r *AuditRepository) SealAuditLog(limit int) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("SELECT pg_advisory_xact_lock(hashtext('audit_log_seal'))")
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock audit log sealing: %w", err)
+	}
+	var seq int64
+	var prev string
+	err = tx.QueryRow("SELECT seq, hash FROM audit_log WHERE seq IS NOT NULL ORDER BY seq DESC LIMIT 1").Scan(&seq, &prev)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, fmt.Errorf("failed to get the end of the audit chain: %w", err)
+	}
+
+	rows, err := tx.Query("SELECT id FROM audit_log WHERE seq IS NULL ORDER BY id LIMIT $1", limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get unsealed audit entries: %w", err)
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan unsealed audit entry: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to get unsealed audit entries: %w", err)
+	}
+
+	for _, id := range ids {
+		seq++
+		err = tx.QueryRow(`
+			UPDATE audit_log
+			SET seq = $2, prev_hash = $3,
+			    hash = audit_entry_hash($3, claimed_actor, action, entity_type, entity_id, before, after, request_id, source_ip, created_at)
+			WHERE id = $1
+			RETURNING hash
+		`, id, seq, prev).Scan(&prev)
+		if err != nil {
+			return 0, fmt.Errorf("failed to seal audit entry %d: %w", id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// VerifyAuditLog recomputes every sealed entry's hash and checks that it links
+// to the entry before it in the chain.
+func (r *AuditRepository) VerifyAuditLog() (models.AuditVerification, error) {
+	query := `
+		WITH chain AS (
+			SELECT id, seq, hash, prev_hash,
+			       audit_entry_hash(prev_hash, claimed_actor, action, entity_type, entity_id, before, after, request_id, source_ip, created_at) AS expected,
+			       COALESCE(lag(hash) OVER (ORDER BY seq), '') AS previous
+			FROM audit_log
+			WHERE seq IS NOT NULL
+		)
+		SELECT (SELECT COUNT(*) FROM chain),
+		       (SELECT id FROM chain WHERE hash <> expected OR prev_hash <> previous ORDER BY seq LIMIT 1),
+		       (SELECT COUNT(*) FROM audit_log WHERE seq IS NULL)
+	`
+	var verification models.AuditVerification
+	err := r.db.QueryRow(query).Scan(&verification.Entries, &verification.BrokenAt, &verification.Unsealed)
+	if err != nil {
+		return models.AuditVerification{}, fmt.Errorf("failed to verify audit log: %w", err)
+	}
+	verification.Valid = verification.BrokenAt == nil
+	return verification, nil
+}
+
+// inAuditedTx runs fn in a transaction attributed to actor and commits it
+// when fn succeeds.
+func inAuditedTx(db *sql.DB, actor models.Actor, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return err
+	}
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetStockHistory returns the latest limit stock changes of each of the given
+// products in one query, newest first and keyed by product id. Checkouts,
+// edits and imports all show up since the audit log records every row change.
+func (r *AuditRepository) GetStockHistory(productIDs []string, limit int) (map[string][]models.StockChange, error) {
+	query := `
+		SELECT id, entity_id, claimed_actor, action, stock_before, stock_after, request_id, created_at
+		FROM (
+			SELECT id, entity_id, claimed_actor, action, (before->>'stock')::int AS stock_before, (after->>'stock')::int AS stock_after,
+			       request_id, created_at, ROW_NUMBER() OVER (PARTITION BY entity_id ORDER BY id DESC) AS n
+			FROM audit_log
+			WHERE entity_type = 'products' AND entity_id = ANY($1)
+			  AND (before->>'stock') IS DISTINCT FROM (after->>'stock')
+		) AS changes
+		WHERE n <= $2
+		ORDER BY id DESC
+	`
+	rows, err := r.db.Query(query, pq.Array(productIDs), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make(map[string][]models.StockChange)
+	for rows.Next() {
+		var change models.StockChange
+		err := rows.Scan(&change.AuditID, &change.ProductID, &change.ClaimedActor, &change.Action, &change.Before, &change.After, &change.RequestID, &change.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock change: %w", err)
+		}
+		if change.After != nil {
+			change.Change += *change.After
+		}
+		if change.Before != nil {
+			change.Change -= *change.Before
+		}
+		history[change.ProductID] = append(history[change.ProductID], change)
+	}
+	return history, rows.Err()
+}
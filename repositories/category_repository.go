@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"kasir-api/models"
 	"strings"
+
+	"github.com/lib/pq"
 )
 
 type CategoryRepository struct {
@@ -16,8 +18,14 @@ func NewCategoryRepository(db *sql.DB) *CategoryRepository {
 	return &CategoryRepository{db: db}
 }
 
-func (r *CategoryRepository) GetCategories() ([]models.Category, error) {
-	rows, err := r.db.Query("SELECT id, name, description, created_at FROM categories")
+// GetCategories lists categories, leaving out archived ones unless
+// includeArchived is set.
+func (r *CategoryRepository) GetCategories(includeArchived bool) ([]models.Category, error) {
+	query := "SELECT id, name, description, version, created_at, deleted_at FROM categories"
+	if !includeArchived {
+		query += " WHERE deleted_at IS NULL"
+	}
+	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +34,7 @@ func (r *CategoryRepository) GetCategories() ([]models.Category, error) {
 	categories := make([]models.Category, 0)
 	for rows.Next() {
 		var category models.Category
-		err := rows.Scan(&category.ID, &category.Name, &category.Description, &category.CreatedAt)
+		err := rows.Scan(&category.ID, &category.Name, &category.Description, &category.Version, &category.CreatedAt, &category.DeletedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -35,23 +43,45 @@ func (r *CategoryRepository) GetCategories() ([]models.Category, error) {
 	return categories, nil
 }
 
-func (r *CategoryRepository) CreateCategory(category models.Category) (models.Category, error) {
-	query := "INSERT INTO categories (name, description) VALUES ($1, $2) RETURNING id, name, description, created_at"
-	row := r.db.QueryRow(query, category.Name, category.Description)
+func (r *CategoryRepository) CreateCategory(actor models.Actor, category models.Category) (models.Category, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.Category{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	query := "INSERT INTO categories (name, description) VALUES ($1, $2) RETURNING id, name, description, version, created_at"
+	row := tx.QueryRow(query, category.Name, category.Description)
 	var newCategory models.Category
-	err := row.Scan(&newCategory.ID, &newCategory.Name, &newCategory.Description, &newCategory.CreatedAt)
+	err = row.Scan(&newCategory.ID, &newCategory.Name, &newCategory.Description, &newCategory.Version, &newCategory.CreatedAt)
 
 	if err != nil {
-		return models.Category{}, fmt.Errorf("failed to create category: %w", err)
+		return models.Category{}, fmt.Errorf("failed to create category: %w", translateDuplicate(err))
+	}
+
+	err = writeOutbox(tx, models.OutboxCategoryCreated, newCategory.ID, newCategory)
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.Category{}, err
 	}
 	return newCategory, nil
 }
 
+// GetCategoryByID also returns archived categories, with DeletedAt set.
 func (r *CategoryRepository) GetCategoryByID(id string) (models.Category, error) {
-	query := "SELECT id, name, description, created_at FROM categories WHERE id = $1"
+	query := "SELECT id, name, description, version, created_at, deleted_at FROM categories WHERE id = $1"
 	row := r.db.QueryRow(query, id)
 	var category models.Category
-	err := row.Scan(&category.ID, &category.Name, &category.Description, &category.CreatedAt)
+	err := row.Scan(&category.ID, &category.Name, &category.Description, &category.Version, &category.CreatedAt, &category.DeletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return models.Category{}, nil
@@ -61,27 +91,73 @@ func (r *CategoryRepository) GetCategoryByID(id string) (models.Category, error)
 	return category, nil
 }
 
-func (r *CategoryRepository) UpdateCategoryByID(id string, category models.Category) (models.Category, error) {
-	query := "UPDATE categories SET name = $2, description = $3 WHERE id = $1 RETURNING id, name, description, created_at"
-	row := r.db.QueryRow(query, id, category.Name, category.Description)
+// UpdateCategoryByID treats archived categories as not found. ifMatch lists
+// the versions the caller accepts, nil meaning any.
+func (r *CategoryRepository) UpdateCategoryByID(actor models.Actor, id string, category models.Category, ifMatch []int64) (models.Category, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.Category{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	found, err := lockVersion(tx, "categories", id, ifMatch)
+	if err != nil || !found {
+		return models.Category{}, err
+	}
+
+	query := "UPDATE categories SET name = $2, description = $3 WHERE id = $1 RETURNING id, name, description, version, created_at"
+	row := tx.QueryRow(query, id, category.Name, category.Description)
 	var updatedCategory models.Category
-	err := row.Scan(&updatedCategory.ID, &updatedCategory.Name, &updatedCategory.Description, &updatedCategory.CreatedAt)
+	err = row.Scan(&updatedCategory.ID, &updatedCategory.Name, &updatedCategory.Description, &updatedCategory.Version, &updatedCategory.CreatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return models.Category{}, nil
 		}
-		return models.Category{}, fmt.Errorf("failed to update category by id %s : %w", id, err)
+		return models.Category{}, fmt.Errorf("failed to update category by id %s : %w", id, translateDuplicate(err))
+	}
+
+	err = writeOutbox(tx, models.OutboxCategoryUpdated, updatedCategory.ID, updatedCategory)
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.Category{}, err
 	}
 	return updatedCategory, nil
 }
 
-func (r *CategoryRepository) DeleteCategoryByID(id string) (models.Category, error) {
-	query := "DELETE FROM categories WHERE id = $1 RETURNING id, name, description, created_at"
-	row := r.db.QueryRow(query, id)
+// DeleteCategoryByID archives the category. Its products keep the assignment,
+// which shows again once the category is restored.
+func (r *CategoryRepository) DeleteCategoryByID(actor models.Actor, id string, ifMatch []int64) (models.Category, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.Category{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	found, err := lockVersion(tx, "categories", id, ifMatch)
+	if err != nil || !found {
+		return models.Category{}, err
+	}
+
+	query := "UPDATE categories SET deleted_at = now() WHERE id = $1 RETURNING id, name, description, version, created_at, deleted_at"
+	row := tx.QueryRow(query, id)
 
 	var deletedCategory models.Category
-	err := row.Scan(&deletedCategory.ID, &deletedCategory.Name, &deletedCategory.Description, &deletedCategory.CreatedAt)
+	err = row.Scan(&deletedCategory.ID, &deletedCategory.Name, &deletedCategory.Description, &deletedCategory.Version, &deletedCategory.CreatedAt, &deletedCategory.DeletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return models.Category{}, nil
@@ -89,29 +165,63 @@ func (r *CategoryRepository) DeleteCategoryByID(id string) (models.Category, err
 		return models.Category{}, fmt.Errorf("failed to delete category by id %s : %w", id, err)
 	}
 
+	err = writeOutbox(tx, models.OutboxCategoryDeleted, deletedCategory.ID, deletedCategory)
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.Category{}, err
+	}
 	return deletedCategory, nil
 }
 
+func (r *CategoryRepository) RestoreCategoryByID(actor models.Actor, id string) (models.Category, error) {
+	var restoredCategory models.Category
+	err := inAuditedTx(r.db, actor, func(tx *sql.Tx) error {
+		query := "UPDATE categories SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL RETURNING id, name, description, version, created_at"
+		row := tx.QueryRow(query, id)
+		err := row.Scan(&restoredCategory.ID, &restoredCategory.Name, &restoredCategory.Description, &restoredCategory.Version, &restoredCategory.CreatedAt)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return fmt.Errorf("failed to restore category by id %s : %w", id, translateDuplicate(err))
+		}
+		return writeOutbox(tx, models.OutboxCategoryRestored, restoredCategory.ID, restoredCategory)
+	})
+	if err != nil {
+		return models.Category{}, err
+	}
+	return restoredCategory, nil
+}
+
 func (r *CategoryRepository) GetProductsByCategoryID(categoryID string) ([]models.CategoryWithProducts, error) {
 	query := `
 		SELECT
 			c.id,
 			c.name,
 			c.description,
+			c.version,
 			c.created_at,
 			COALESCE(
 				json_agg(json_build_object(
 					'id', p.id,
 					'name', p.name,
+					'sku', COALESCE(p.sku, ''),
+					'barcode', COALESCE(p.barcode, ''),
 					'price', p.price,
+					'cost', p.cost,
 					'stock', p.stock,
+					'version', p.version,
 					'created_at', p.created_at
 				)) FILTER (WHERE p.id IS NOT NULL),
 				'[]'::json
 			) AS products
 		FROM categories c
 		INNER JOIN product_categories pc ON c.id = pc.category_id
-		INNER JOIN products p ON pc.product_id = p.id
+		INNER JOIN products p ON pc.product_id = p.id AND p.deleted_at IS NULL
 		WHERE c.id = $1
 		GROUP BY c.id
 		ORDER BY c.name
@@ -126,7 +236,7 @@ func (r *CategoryRepository) GetProductsByCategoryID(categoryID string) ([]model
 	for rows.Next() {
 		var category models.CategoryWithProducts
 		var product string
-		err := rows.Scan(&category.ID, &category.Name, &category.Description, &category.CreatedAt, &product)
+		err := rows.Scan(&category.ID, &category.Name, &category.Description, &category.Version, &category.CreatedAt, &product)
 		if err != nil {
 			return nil, err
 		}
@@ -136,3 +246,33 @@ func (r *CategoryRepository) GetProductsByCategoryID(categoryID string) ([]model
 	}
 	return categories, nil
 }
+
+// GetProductsByCategoryIDs returns the live products of each of the given
+// categories in one query, keyed by category id. The products come without
+// their categories.
+func (r *CategoryRepository) GetProductsByCategoryIDs(categoryIDs []string) (map[string][]models.Product, error) {
+	query := `
+		SELECT pc.category_id, p.id, p.name, COALESCE(p.sku, ''), COALESCE(p.barcode, ''), p.price, p.cost, p.stock, p.version, p.created_at
+		FROM products p
+		INNER JOIN product_categories pc ON p.id = pc.product_id
+		WHERE pc.category_id = ANY($1::uuid[]) AND p.deleted_at IS NULL
+		ORDER BY p.name
+	`
+	rows, err := r.db.Query(query, pq.Array(categoryIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products by category ids: %w", err)
+	}
+	defer rows.Close()
+
+	products := make(map[string][]models.Product)
+	for rows.Next() {
+		var categoryID string
+		var product models.Product
+		err := rows.Scan(&categoryID, &product.ID, &product.Name, &product.SKU, &product.Barcode, &product.Price, &product.Cost, &product.Stock, &product.Version, &product.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products[categoryID] = append(products[categoryID], product)
+	}
+	return products, rows.Err()
+}
@@ -0,0 +1,269 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+)
+
+type CustomerRepository struct {
+	db *sql.DB
+}
+
+func NewCustomerRepository(db *sql.DB) *CustomerRepository {
+	return &CustomerRepository{db: db}
+}
+
+func (r *CustomerRepository) GetCustomerGroups() ([]models.CustomerGroup, error) {
+	rows, err := r.db.Query("SELECT id, name, COALESCE(price_list_id::text, ''), created_at FROM customer_groups ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make([]models.CustomerGroup, 0)
+	for rows.Next() {
+		var group models.CustomerGroup
+		err := rows.Scan(&group.ID, &group.Name, &group.PriceListID, &group.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func (r *CustomerRepository) CreateCustomerGroup(actor models.Actor, group models.CustomerGroup) (models.CustomerGroup, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.CustomerGroup{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.CustomerGroup{}, err
+	}
+
+	query := `
+		INSERT INTO customer_groups (name, price_list_id) VALUES ($1, NULLIF($2, '')::uuid)
+		RETURNING id, name, COALESCE(price_list_id::text, ''), created_at
+	`
+	var newGroup models.CustomerGroup
+	err = tx.QueryRow(query, group.Name, group.PriceListID).Scan(&newGroup.ID, &newGroup.Name, &newGroup.PriceListID, &newGroup.CreatedAt)
+	if err != nil {
+		return models.CustomerGroup{}, fmt.Errorf("failed to create customer group: %w", translateDuplicate(err))
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.CustomerGroup{}, err
+	}
+	return newGroup, nil
+}
+
+func (r *CustomerRepository) GetCustomerGroupByID(id string) (models.CustomerGroup, error) {
+	query := "SELECT id, name, COALESCE(price_list_id::text, ''), created_at FROM customer_groups WHERE id = $1"
+	var group models.CustomerGroup
+	err := r.db.QueryRow(query, id).Scan(&group.ID, &group.Name, &group.PriceListID, &group.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.CustomerGroup{}, nil
+		}
+		return models.CustomerGroup{}, fmt.Errorf("failed to get customer group by id %s : %w", id, err)
+	}
+	return group, nil
+}
+
+func (r *CustomerRepository) UpdateCustomerGroupByID(actor models.Actor, id string, group models.CustomerGroup) (models.CustomerGroup, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.CustomerGroup{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.CustomerGroup{}, err
+	}
+
+	query := `
+		UPDATE customer_groups SET name = $2, price_list_id = NULLIF($3, '')::uuid WHERE id = $1
+		RETURNING id, name, COALESCE(price_list_id::text, ''), created_at
+	`
+	var updatedGroup models.CustomerGroup
+	err = tx.QueryRow(query, id, group.Name, group.PriceListID).Scan(&updatedGroup.ID, &updatedGroup.Name, &updatedGroup.PriceListID, &updatedGroup.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.CustomerGroup{}, nil
+		}
+		return models.CustomerGroup{}, fmt.Errorf("failed to update customer group by id %s : %w", id, translateDuplicate(err))
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.CustomerGroup{}, err
+	}
+	return updatedGroup, nil
+}
+
+func (r *CustomerRepository) DeleteCustomerGroupByID(actor models.Actor, id string) (models.CustomerGroup, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.CustomerGroup{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.CustomerGroup{}, err
+	}
+
+	query := "DELETE FROM customer_groups WHERE id = $1 RETURNING id, name, COALESCE(price_list_id::text, ''), created_at"
+	var deletedGroup models.CustomerGroup
+	err = tx.QueryRow(query, id).Scan(&deletedGroup.ID, &deletedGroup.Name, &deletedGroup.PriceListID, &deletedGroup.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.CustomerGroup{}, nil
+		}
+		return models.CustomerGroup{}, fmt.Errorf("failed to delete customer group by id %s : %w", id, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.CustomerGroup{}, err
+	}
+	return deletedGroup, nil
+}
+
+func (r *CustomerRepository) GetCustomers(name string) ([]models.Customer, error) {
+	query := "SELECT id, name, phone, email, COALESCE(customer_group_id::text, ''), created_at FROM customers"
+	var args []any
+	if name != "" {
+		query += " WHERE name ILIKE $1"
+		args = []any{"%" + name + "%"}
+	}
+	query += " ORDER BY name"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	customers := make([]models.Customer, 0)
+	for rows.Next() {
+		var customer models.Customer
+		err := rows.Scan(&customer.ID, &customer.Name, &customer.Phone, &customer.Email, &customer.CustomerGroupID, &customer.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		customers = append(customers, customer)
+	}
+	return customers, nil
+}
+
+func (r *CustomerRepository) CreateCustomer(actor models.Actor, customer models.Customer) (models.Customer, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.Customer{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.Customer{}, err
+	}
+
+	query := `
+		INSERT INTO customers (name, phone, email, customer_group_id) VALUES ($1, $2, $3, NULLIF($4, '')::uuid)
+		RETURNING id, name, phone, email, COALESCE(customer_group_id::text, ''), created_at
+	`
+	var newCustomer models.Customer
+	err = tx.QueryRow(query, customer.Name, customer.Phone, customer.Email, customer.CustomerGroupID).
+		Scan(&newCustomer.ID, &newCustomer.Name, &newCustomer.Phone, &newCustomer.Email, &newCustomer.CustomerGroupID, &newCustomer.CreatedAt)
+	if err != nil {
+		return models.Customer{}, fmt.Errorf("failed to create customer: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.Customer{}, err
+	}
+	return newCustomer, nil
+}
+
+func (r *CustomerRepository) GetCustomerByID(id string) (models.Customer, error) {
+	query := "SELECT id, name, phone, email, COALESCE(customer_group_id::text, ''), created_at FROM customers WHERE id = $1"
+	var customer models.Customer
+	err := r.db.QueryRow(query, id).Scan(&customer.ID, &customer.Name, &customer.Phone, &customer.Email, &customer.CustomerGroupID, &customer.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Customer{}, nil
+		}
+		return models.Customer{}, fmt.Errorf("failed to get customer by id %s : %w", id, err)
+	}
+	return customer, nil
+}
+
+func (r *CustomerRepository) UpdateCustomerByID(actor models.Actor, id string, customer models.Customer) (models.Customer, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.Customer{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.Customer{}, err
+	}
+
+	query := `
+		UPDATE customers SET name = $2, phone = $3, email = $4, customer_group_id = NULLIF($5, '')::uuid WHERE id = $1
+		RETURNING id, name, phone, email, COALESCE(customer_group_id::text, ''), created_at
+	`
+	var updatedCustomer models.Customer
+	err = tx.QueryRow(query, id, customer.Name, customer.Phone, customer.Email, customer.CustomerGroupID).
+		Scan(&updatedCustomer.ID, &updatedCustomer.Name, &updatedCustomer.Phone, &updatedCustomer.Email, &updatedCustomer.CustomerGroupID, &updatedCustomer.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Customer{}, nil
+		}
+		return models.Customer{}, fmt.Errorf("failed to update customer by id %s : %w", id, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.Customer{}, err
+	}
+	return updatedCustomer, nil
+}
+
+func (r *CustomerRepository) DeleteCustomerByID(actor models.Actor, id string) (models.Customer, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.Customer{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.Customer{}, err
+	}
+
+	query := "DELETE FROM customers WHERE id = $1 RETURNING id, name, phone, email, COALESCE(customer_group_id::text, ''), created_at"
+	var deletedCustomer models.Customer
+	err = tx.QueryRow(query, id).Scan(&deletedCustomer.ID, &deletedCustomer.Name, &deletedCustomer.Phone, &deletedCustomer.Email, &deletedCustomer.CustomerGroupID, &deletedCustomer.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Customer{}, nil
+		}
+		return models.Customer{}, fmt.Errorf("failed to delete customer by id %s : %w", id, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.Customer{}, err
+	}
+	return deletedCustomer, nil
+}
@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ForecastRepository struct {
+	db *sql.DB
+}
+
+func NewForecastRepository(db *sql.DB) *ForecastRepository {
+	return &ForecastRepository{db: db}
+}
+
+// GetDailySales returns the quantity sold per product per store day from the
+// daily sales rollup, starting at the store day containing since. Days
+// without sales are not returned.
+func (r *ForecastRepository) GetDailySales(since time.Time) ([]models.DailySales, error) {
+	query := `
+		SELECT product_id, to_char(sale_date, 'YYYY-MM-DD'), quantity
+		FROM daily_sales
+		WHERE sale_date >= $1::date
+	`
+	rows, err := r.db.Query(query, internal.StoreDate(since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily sales: %w", err)
+	}
+	defer rows.Close()
+
+	sales := make([]models.DailySales, 0)
+	for rows.Next() {
+		var day models.DailySales
+		err := rows.Scan(&day.ProductID, &day.Date, &day.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan daily sales: %w", err)
+		}
+		sales = append(sales, day)
+	}
+	return sales, rows.Err()
+}
+
+// ReplaceForecasts swaps all stored forecasts for the given ones in a single
+// transaction, so readers never see a half written run.
+func (r *ForecastRepository) ReplaceForecasts(forecasts []models.DemandForecast, generatedAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM demand_forecasts")
+	if err != nil {
+		return fmt.Errorf("failed to clear forecasts: %w", err)
+	}
+
+	query := `
+		INSERT INTO demand_forecasts (product_id, daily_demand, weekday_factors, history_days, generated_at)
+		SELECT $1, $2, $3, $4, $5 WHERE EXISTS (SELECT 1 FROM products WHERE id = $1)
+	`
+	for _, forecast := range forecasts {
+		_, err = tx.Exec(query, forecast.ProductID, forecast.DailyDemand, pq.Array(forecast.WeekdayFactors), forecast.HistoryDays, generatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save forecast for product %s : %w", forecast.ProductID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+const forecastQuery = `
+	SELECT p.id, p.name, p.stock, p.lead_time_days, COALESCE(f.daily_demand, 0), f.weekday_factors, COALESCE(f.history_days, 0), f.generated_at
+	FROM products p
+	LEFT JOIN demand_forecasts f ON f.product_id = p.id
+`
+
+func (r *ForecastRepository) GetForecasts() ([]models.DemandForecast, error) {
+	rows, err := r.db.Query(forecastQuery + " WHERE p.deleted_at IS NULL ORDER BY p.name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forecasts: %w", err)
+	}
+	defer rows.Close()
+
+	forecasts := make([]models.DemandForecast, 0)
+	for rows.Next() {
+		forecast, err := scanForecast(rows)
+		if err != nil {
+			return nil, err
+		}
+		forecasts = append(forecasts, forecast)
+	}
+	return forecasts, rows.Err()
+}
+
+func (r *ForecastRepository) GetForecastByProductID(productID string) (models.DemandForecast, error) {
+	row := r.db.QueryRow(forecastQuery+" WHERE p.id = $1", productID)
+	forecast, err := scanForecast(row)
+	if err == sql.ErrNoRows {
+		return models.DemandForecast{}, nil
+	}
+	return forecast, err
+}
+
+// SetLeadTime updates how many days a product takes to arrive after ordering.
+// It returns false when the product does not exist.
+func (r *ForecastRepository) SetLeadTime(actor models.Actor, productID string, days int) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := tx.Exec("UPDATE products SET lead_time_days = $2 WHERE id = $1", productID, days)
+	if err != nil {
+		return false, fmt.Errorf("failed to set lead time: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func scanForecast(row scanner) (models.DemandForecast, error) {
+	var forecast models.DemandForecast
+	var factors pq.Float64Array
+	err := row.Scan(&forecast.ProductID, &forecast.ProductName, &forecast.Stock, &forecast.LeadTimeDays,
+		&forecast.DailyDemand, &factors, &forecast.HistoryDays, &forecast.GeneratedAt)
+	if err == sql.ErrNoRows {
+		return models.DemandForecast{}, err
+	}
+	if err != nil {
+		return models.DemandForecast{}, fmt.Errorf("failed to scan forecast: %w", err)
+	}
+	forecast.WeekdayFactors = factors
+	return forecast, nil
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"kasir-api/models"
+	"net/http"
+	"time"
+)
+
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// ReserveKey claims key of actor for a new attempt leased for lease and
+// returns true, or returns the request already stored under it and false. A
+// retry of a request whose lease has run out takes the key over, as the
+// attempt holding it has died without releasing it.
+func (r *IdempotencyRepository) ReserveKey(actor, key, method, path, requestHash string, lease time.Duration) (models.IdempotentResponse, bool, error) {
+	var attempt string
+	err := r.db.QueryRow(`
+		INSERT INTO idempotency_keys (actor, key, method, path, request_hash, lease_until)
+		VALUES ($1, $2, $3, $4, $5, now() + $6 * interval '1 second')
+		ON CONFLICT (actor, key) DO UPDATE SET attempt = gen_random_uuid(), lease_until = EXCLUDED.lease_until, created_at = now()
+		WHERE idempotency_keys.status_code IS NULL
+			AND idempotency_keys.request_hash = EXCLUDED.request_hash
+			AND (idempotency_keys.lease_until IS NULL OR idempotency_keys.lease_until < now())
+		RETURNING attempt
+	`, actor, key, method, path, requestHash, lease.Seconds()).Scan(&attempt)
+	if err == nil {
+		return models.IdempotentResponse{Actor: actor, Key: key, Attempt: attempt, Method: method, Path: path, RequestHash: requestHash}, true, nil
+	}
+	if err != sql.ErrNoRows {
+		return models.IdempotentResponse{}, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	response := models.IdempotentResponse{Actor: actor, Key: key}
+	var statusCode sql.NullInt64
+	var header []byte
+	err = r.db.QueryRow(`
+		SELECT attempt, method, path, request_hash, status_code, header, body FROM idempotency_keys WHERE actor = $1 AND key = $2
+	`, actor, key).Scan(&response.Attempt, &response.Method, &response.Path, &response.RequestHash, &statusCode, &header, &response.Body)
+	if err == sql.ErrNoRows {
+		// Expired between the insert and the select, let the caller retry.
+		return models.IdempotentResponse{}, false, fmt.Errorf("idempotency key %s disappeared", key)
+	}
+	if err != nil {
+		return models.IdempotentResponse{}, false, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	response.StatusCode = int(statusCode.Int64)
+	err = json.Unmarshal(header, &response.Header)
+	if err != nil {
+		return models.IdempotentResponse{}, false, fmt.Errorf("failed to decode idempotent response header: %w", err)
+	}
+	return response, false, nil
+}
+
+// RenewKey extends the lease of attempt on key of actor and returns false
+// when the attempt no longer holds the key.
+func (r *IdempotencyRepository) RenewKey(actor, key, attempt string, lease time.Duration) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE idempotency_keys SET lease_until = now() + $4 * interval '1 second'
+		WHERE actor = $1 AND key = $2 AND attempt = $3 AND status_code IS NULL
+	`, actor, key, attempt, lease.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("failed to renew idempotency key: %w", err)
+	}
+	renewed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to renew idempotency key: %w", err)
+	}
+	return renewed == 1, nil
+}
+
+// SaveResponse stores the response of attempt, unless another attempt has
+// taken the key over since.
+func (r *IdempotencyRepository) SaveResponse(actor, key, attempt string, statusCode int, header http.Header, body []byte) error {
+	data, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("failed to encode idempotent response header: %w", err)
+	}
+	_, err = r.db.Exec(`
+		UPDATE idempotency_keys SET status_code = $4, header = $5, body = $6, lease_until = NULL
+		WHERE actor = $1 AND key = $2 AND attempt = $3 AND status_code IS NULL
+	`, actor, key, attempt, statusCode, data, body)
+	if err != nil {
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+	return nil
+}
+
+// ReleaseKey forgets key of actor so the request can be tried again, unless
+// another attempt has taken the key over since.
+func (r *IdempotencyRepository) ReleaseKey(actor, key, attempt string) error {
+	_, err := r.db.Exec("DELETE FROM idempotency_keys WHERE actor = $1 AND key = $2 AND attempt = $3 AND status_code IS NULL", actor, key, attempt)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteKeysBefore(before time.Time) error {
+	_, err := r.db.Exec("DELETE FROM idempotency_keys WHERE created_at < $1 AND (lease_until IS NULL OR lease_until < now())", before)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return nil
+}
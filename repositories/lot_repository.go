@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/internal"
+	apperrors "kasir-api/internal/errors"
+	"kasir-api/models"
+	"time"
+)
+
+type LotRepository struct {
+	db *sql.DB
+}
+
+func NewLotRepository(db *sql.DB) *LotRepository {
+	return &LotRepository{db: db}
+}
+
+func (r *LotRepository) GetLotsByProductID(productID string) ([]models.ProductLot, error) {
+	query := `
+		SELECT id, product_id, lot_number, COALESCE(to_char(expiry_date, 'YYYY-MM-DD'), ''), quantity, created_at
+		FROM product_lots
+		WHERE product_id = $1
+		ORDER BY expiry_date ASC NULLS LAST, created_at ASC
+	`
+	rows, err := r.db.Query(query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lots by product id %s : %w", productID, err)
+	}
+	defer rows.Close()
+
+	lots := make([]models.ProductLot, 0)
+	for rows.Next() {
+		var lot models.ProductLot
+		err := rows.Scan(&lot.ID, &lot.ProductID, &lot.LotNumber, &lot.ExpiryDate, &lot.Quantity, &lot.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan lot: %w", err)
+		}
+		lots = append(lots, lot)
+	}
+	return lots, nil
+}
+
+// ReceiveLot records a received lot and adds its quantity to the product stock,
+// so products.stock stays the total on hand across all lots. Receiving a lot
+// number the product already has tops that lot up; it is a conflict when the
+// expiry date given differs from the one on record.
+func (r *LotRepository) ReceiveLot(actor models.Actor, lot models.ProductLot) (models.ProductLot, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.ProductLot{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.ProductLot{}, err
+	}
+
+	result, err := tx.Exec("UPDATE products SET stock = stock + $1 WHERE id = $2", lot.Quantity, lot.ProductID)
+	if err != nil {
+		return models.ProductLot{}, fmt.Errorf("failed to update product stock: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return models.ProductLot{}, err
+	}
+	if affected == 0 {
+		return models.ProductLot{}, nil
+	}
+
+	query := `
+		INSERT INTO product_lots (product_id, lot_number, expiry_date, quantity)
+		VALUES ($1, $2, NULLIF($3, '')::date, $4)
+		ON CONFLICT (product_id, lot_number) DO UPDATE SET quantity = product_lots.quantity + EXCLUDED.quantity
+		WHERE EXCLUDED.expiry_date IS NULL OR product_lots.expiry_date IS NOT DISTINCT FROM EXCLUDED.expiry_date
+		RETURNING id, product_id, lot_number, COALESCE(to_char(expiry_date, 'YYYY-MM-DD'), ''), quantity, created_at
+	`
+	var newLot models.ProductLot
+	err = tx.QueryRow(query, lot.ProductID, lot.LotNumber, lot.ExpiryDate, lot.Quantity).
+		Scan(&newLot.ID, &newLot.ProductID, &newLot.LotNumber, &newLot.ExpiryDate, &newLot.Quantity, &newLot.CreatedAt)
+	if err == sql.ErrNoRows {
+		return models.ProductLot{}, apperrors.NewConflictError("lot_expiry_mismatch",
+			fmt.Sprintf("lot %s was received with a different expiry date", lot.LotNumber))
+	}
+	if err != nil {
+		return models.ProductLot{}, fmt.Errorf("failed to receive lot: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.ProductLot{}, err
+	}
+	return newLot, nil
+}
+
+// GetExpiringLots returns lots with stock left that expire within the given
+// number of days, including lots that have already expired.
+func (r *LotRepository) GetExpiringLots(days int) ([]models.ExpiringLot, error) {
+	query := `
+		SELECT l.id, l.product_id, p.name, l.lot_number, to_char(l.expiry_date, 'YYYY-MM-DD'), l.quantity, l.created_at,
+		       l.expiry_date - $2::date AS days_to_expiry
+		FROM product_lots l
+		INNER JOIN products p ON p.id = l.product_id
+		WHERE l.quantity > 0
+		  AND l.expiry_date IS NOT NULL
+		  AND l.expiry_date <= $2::date + $1::int
+		ORDER BY l.expiry_date ASC, p.name ASC
+	`
+	rows, err := r.db.Query(query, days, internal.StoreDate(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring lots: %w", err)
+	}
+	defer rows.Close()
+
+	lots := make([]models.ExpiringLot, 0)
+	for rows.Next() {
+		var lot models.ExpiringLot
+		err := rows.Scan(&lot.ID, &lot.ProductID, &lot.ProductName, &lot.LotNumber, &lot.ExpiryDate, &lot.Quantity, &lot.CreatedAt, &lot.DaysToExpiry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expiring lot: %w", err)
+		}
+		lot.Expired = lot.DaysToExpiry < 0
+		lots = append(lots, lot)
+	}
+	return lots, nil
+}
@@ -0,0 +1,303 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+)
+
+type PriceListRepository struct {
+	db *sql.DB
+}
+
+func NewPriceListRepository(db *sql.DB) *PriceListRepository {
+	return &PriceListRepository{db: db}
+}
+
+func (r *PriceListRepository) GetPriceLists() ([]models.PriceList, error) {
+	rows, err := r.db.Query("SELECT id, name, description, is_default, created_at FROM price_lists ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	priceLists := make([]models.PriceList, 0)
+	for rows.Next() {
+		var priceList models.PriceList
+		err := rows.Scan(&priceList.ID, &priceList.Name, &priceList.Description, &priceList.IsDefault, &priceList.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		priceLists = append(priceLists, priceList)
+	}
+	return priceLists, nil
+}
+
+func (r *PriceListRepository) CreatePriceList(actor models.Actor, priceList models.PriceList) (models.PriceList, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.PriceList{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.PriceList{}, err
+	}
+
+	if priceList.IsDefault {
+		_, err = tx.Exec("UPDATE price_lists SET is_default = false WHERE is_default")
+		if err != nil {
+			return models.PriceList{}, fmt.Errorf("failed to clear default price list: %w", err)
+		}
+	}
+
+	query := "INSERT INTO price_lists (name, description, is_default) VALUES ($1, $2, $3) RETURNING id, name, description, is_default, created_at"
+	var newPriceList models.PriceList
+	err = tx.QueryRow(query, priceList.Name, priceList.Description, priceList.IsDefault).
+		Scan(&newPriceList.ID, &newPriceList.Name, &newPriceList.Description, &newPriceList.IsDefault, &newPriceList.CreatedAt)
+	if err != nil {
+		return models.PriceList{}, fmt.Errorf("failed to create price list: %w", translateDuplicate(err))
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.PriceList{}, err
+	}
+	return newPriceList, nil
+}
+
+func (r *PriceListRepository) GetPriceListByID(id string) (models.PriceList, error) {
+	query := "SELECT id, name, description, is_default, created_at FROM price_lists WHERE id = $1"
+	var priceList models.PriceList
+	err := r.db.QueryRow(query, id).Scan(&priceList.ID, &priceList.Name, &priceList.Description, &priceList.IsDefault, &priceList.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PriceList{}, nil
+		}
+		return models.PriceList{}, fmt.Errorf("failed to get price list by id %s : %w", id, err)
+	}
+
+	priceList.Items, err = r.GetPriceListItems(id)
+	if err != nil {
+		return models.PriceList{}, err
+	}
+	return priceList, nil
+}
+
+func (r *PriceListRepository) UpdatePriceListByID(actor models.Actor, id string, priceList models.PriceList) (models.PriceList, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.PriceList{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.PriceList{}, err
+	}
+
+	if priceList.IsDefault {
+		_, err = tx.Exec("UPDATE price_lists SET is_default = false WHERE is_default AND id <> $1", id)
+		if err != nil {
+			return models.PriceList{}, fmt.Errorf("failed to clear default price list: %w", err)
+		}
+	}
+
+	query := "UPDATE price_lists SET name = $2, description = $3, is_default = $4 WHERE id = $1 RETURNING id, name, description, is_default, created_at"
+	var updatedPriceList models.PriceList
+	err = tx.QueryRow(query, id, priceList.Name, priceList.Description, priceList.IsDefault).
+		Scan(&updatedPriceList.ID, &updatedPriceList.Name, &updatedPriceList.Description, &updatedPriceList.IsDefault, &updatedPriceList.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PriceList{}, nil
+		}
+		return models.PriceList{}, fmt.Errorf("failed to update price list by id %s : %w", id, translateDuplicate(err))
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.PriceList{}, err
+	}
+	return updatedPriceList, nil
+}
+
+func (r *PriceListRepository) DeletePriceListByID(actor models.Actor, id string) (models.PriceList, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.PriceList{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.PriceList{}, err
+	}
+
+	query := "DELETE FROM price_lists WHERE id = $1 RETURNING id, name, description, is_default, created_at"
+	var deletedPriceList models.PriceList
+	err = tx.QueryRow(query, id).Scan(&deletedPriceList.ID, &deletedPriceList.Name, &deletedPriceList.Description, &deletedPriceList.IsDefault, &deletedPriceList.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PriceList{}, nil
+		}
+		return models.PriceList{}, fmt.Errorf("failed to delete price list by id %s : %w", id, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.PriceList{}, err
+	}
+	return deletedPriceList, nil
+}
+
+func (r *PriceListRepository) GetPriceListItems(priceListID string) ([]models.PriceListItem, error) {
+	query := `
+		SELECT i.id, i.price_list_id, i.product_id, p.name, i.min_quantity, i.price, i.created_at
+		FROM price_list_items i
+		INNER JOIN products p ON p.id = i.product_id
+		WHERE i.price_list_id = $1
+		ORDER BY p.name, i.min_quantity
+	`
+	rows, err := r.db.Query(query, priceListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price list items by id %s : %w", priceListID, err)
+	}
+	defer rows.Close()
+
+	items := make([]models.PriceListItem, 0)
+	for rows.Next() {
+		var item models.PriceListItem
+		err := rows.Scan(&item.ID, &item.PriceListID, &item.ProductID, &item.ProductName, &item.MinQuantity, &item.Price, &item.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan price list item: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// SetPriceListItem creates or replaces the tier of a product starting at MinQuantity.
+func (r *PriceListRepository) SetPriceListItem(actor models.Actor, item models.PriceListItem) (models.PriceListItem, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.PriceListItem{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.PriceListItem{}, err
+	}
+
+	query := `
+		INSERT INTO price_list_items (price_list_id, product_id, min_quantity, price)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (price_list_id, product_id, min_quantity) DO UPDATE SET price = EXCLUDED.price
+		RETURNING id, price_list_id, product_id, min_quantity, price, created_at
+	`
+	var newItem models.PriceListItem
+	err = tx.QueryRow(query, item.PriceListID, item.ProductID, item.MinQuantity, item.Price).
+		Scan(&newItem.ID, &newItem.PriceListID, &newItem.ProductID, &newItem.MinQuantity, &newItem.Price, &newItem.CreatedAt)
+	if err != nil {
+		return models.PriceListItem{}, fmt.Errorf("failed to set price list item: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.PriceListItem{}, err
+	}
+	return newItem, nil
+}
+
+func (r *PriceListRepository) DeletePriceListItem(actor models.Actor, priceListID, productID string, minQuantity int) (models.PriceListItem, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.PriceListItem{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.PriceListItem{}, err
+	}
+
+	query := `
+		DELETE FROM price_list_items
+		WHERE price_list_id = $1 AND product_id = $2 AND min_quantity = $3
+		RETURNING id, price_list_id, product_id, min_quantity, price, created_at
+	`
+	var deletedItem models.PriceListItem
+	err = tx.QueryRow(query, priceListID, productID, minQuantity).
+		Scan(&deletedItem.ID, &deletedItem.PriceListID, &deletedItem.ProductID, &deletedItem.MinQuantity, &deletedItem.Price, &deletedItem.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PriceListItem{}, nil
+		}
+		return models.PriceListItem{}, fmt.Errorf("failed to delete price list item: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.PriceListItem{}, err
+	}
+	return deletedItem, nil
+}
+
+func (r *PriceListRepository) QuotePrice(productID, customerID string, quantity int) (models.PriceQuote, error) {
+	var basePrice int64
+	err := r.db.QueryRow("SELECT "+effectivePriceQuery+" FROM products p WHERE p.id = $1", productID).Scan(&basePrice)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PriceQuote{}, nil
+		}
+		return models.PriceQuote{}, fmt.Errorf("failed to get product price %s : %w", productID, err)
+	}
+
+	price, priceListID, err := resolvePrice(r.db, productID, customerID, quantity, basePrice)
+	if err != nil {
+		return models.PriceQuote{}, err
+	}
+	return models.PriceQuote{
+		ProductID:   productID,
+		CustomerID:  customerID,
+		PriceListID: priceListID,
+		Quantity:    quantity,
+		Price:       price,
+		Subtotal:    price * int64(quantity),
+	}, nil
+}
+
+// resolvePrice picks the unit price for a product bought in the given quantity.
+// The customer's group price list is used when there is one, otherwise the
+// default price list. Within the list the tier with the highest minimum
+// quantity that the purchase reaches applies. When no tier applies the base
+// price is returned with an empty price list id.
+func resolvePrice(q queryRower, productID, customerID string, quantity int, basePrice int64) (int64, string, error) {
+	query := `
+		WITH list AS (
+			SELECT COALESCE(
+				(SELECT g.price_list_id FROM customers c
+				 INNER JOIN customer_groups g ON g.id = c.customer_group_id
+				 WHERE c.id = NULLIF($2, '')::uuid),
+				(SELECT id FROM price_lists WHERE is_default)
+			) AS id
+		)
+		SELECT i.price, i.price_list_id
+		FROM price_list_items i
+		INNER JOIN list ON list.id = i.price_list_id
+		WHERE i.product_id = $1 AND i.min_quantity <= $3
+		ORDER BY i.min_quantity DESC
+		LIMIT 1
+	`
+	var price int64
+	var priceListID string
+	err := q.QueryRow(query, productID, customerID, quantity).Scan(&price, &priceListID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return basePrice, "", nil
+		}
+		return 0, "", fmt.Errorf("failed to resolve price for product %s : %w", productID, err)
+	}
+	return price, priceListID, nil
+}
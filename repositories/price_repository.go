@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+	"time"
+)
+
+// effectivePriceQuery resolves the price in force right now for the product
+// aliased as p, falling back to products.price when there is no history.
+const effectivePriceQuery = `
+	COALESCE((
+		SELECT pp.price FROM product_prices pp
+		WHERE pp.product_id = p.id AND pp.effective_at <= now()
+		ORDER BY pp.effective_at DESC, pp.created_at DESC
+		LIMIT 1
+	), p.price)
+`
+
+type PriceRepository struct {
+	db *sql.DB
+}
+
+func NewPriceRepository(db *sql.DB) *PriceRepository {
+	return &PriceRepository{db: db}
+}
+
+func (r *PriceRepository) GetPriceHistory(productID string) ([]models.ProductPrice, error) {
+	query := `
+		SELECT id, product_id, price, effective_at, applied_at, effective_at > now() AS scheduled, created_at
+		FROM product_prices
+		WHERE product_id = $1
+		ORDER BY effective_at DESC, created_at DESC
+	`
+	rows, err := r.db.Query(query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history by product id %s : %w", productID, err)
+	}
+	defer rows.Close()
+
+	prices := make([]models.ProductPrice, 0)
+	for rows.Next() {
+		var price models.ProductPrice
+		err := rows.Scan(&price.ID, &price.ProductID, &price.Price, &price.EffectiveAt, &price.AppliedAt, &price.Scheduled, &price.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan price: %w", err)
+		}
+		prices = append(prices, price)
+	}
+	return prices, nil
+}
+
+func (r *PriceRepository) SchedulePriceChange(actor models.Actor, productID string, price int64, effectiveAt time.Time) (models.ProductPrice, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.ProductPrice{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.ProductPrice{}, err
+	}
+
+	query := `
+		INSERT INTO product_prices (product_id, price, effective_at)
+		SELECT id, $2, $3 FROM products WHERE id = $1
+		RETURNING id, product_id, price, effective_at, applied_at, effective_at > now(), created_at
+	`
+	var newPrice models.ProductPrice
+	err = tx.QueryRow(query, productID, price, effectiveAt).Scan(&newPrice.ID, &newPrice.ProductID, &newPrice.Price,
+		&newPrice.EffectiveAt, &newPrice.AppliedAt, &newPrice.Scheduled, &newPrice.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ProductPrice{}, nil
+		}
+		return models.ProductPrice{}, fmt.Errorf("failed to schedule price change: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.ProductPrice{}, err
+	}
+	return newPrice, nil
+}
+
+// CancelScheduledPrice removes a price change that has not taken effect yet.
+func (r *PriceRepository) CancelScheduledPrice(actor models.Actor, productID string, priceID string) (models.ProductPrice, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.ProductPrice{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.ProductPrice{}, err
+	}
+
+	query := `
+		DELETE FROM product_prices
+		WHERE id = $1 AND product_id = $2 AND applied_at IS NULL AND effective_at > now()
+		RETURNING id, product_id, price, effective_at, applied_at, true, created_at
+	`
+	var deletedPrice models.ProductPrice
+	err = tx.QueryRow(query, priceID, productID).Scan(&deletedPrice.ID, &deletedPrice.ProductID, &deletedPrice.Price,
+		&deletedPrice.EffectiveAt, &deletedPrice.AppliedAt, &deletedPrice.Scheduled, &deletedPrice.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ProductPrice{}, nil
+		}
+		return models.ProductPrice{}, fmt.Errorf("failed to cancel scheduled price %s : %w", priceID, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.ProductPrice{}, err
+	}
+	return deletedPrice, nil
+}
+
+// ApplyDuePriceChanges copies scheduled prices that have become effective onto
+// products.price so listings show the current price. Checkout does not depend
+// on this, it resolves the effective price itself.
+func (r *PriceRepository) ApplyDuePriceChanges() (int64, error) {
+	query := `
+		WITH due AS (
+			UPDATE product_prices SET applied_at = now()
+			WHERE applied_at IS NULL AND effective_at <= now()
+			RETURNING product_id
+		), latest AS (
+			SELECT DISTINCT ON (product_id) product_id, price
+			FROM product_prices
+			WHERE effective_at <= now() AND product_id IN (SELECT product_id FROM due)
+			ORDER BY product_id, effective_at DESC, created_at DESC
+		)
+		UPDATE products p SET price = latest.price
+		FROM latest
+		WHERE p.id = latest.product_id
+	`
+	result, err := r.db.Exec(query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to apply due price changes: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func insertPriceHistory(tx *sql.Tx, productID string, price int64) error {
+	_, err := tx.Exec("INSERT INTO product_prices (product_id, price, effective_at, applied_at) VALUES ($1, $2, now(), now())", productID, price)
+	if err != nil {
+		return fmt.Errorf("failed to record price history: %w", err)
+	}
+	return nil
+}
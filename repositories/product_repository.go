@@ -3,13 +3,19 @@ package repositories
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	apperrors "kasir-api/internal/errors"
 	"kasir-api/models"
 	"log"
 	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
+const productColumns = "id, name, COALESCE(sku, ''), COALESCE(barcode, ''), price, cost, stock, version"
+
 type ProductRepository struct {
 	db *sql.DB
 }
@@ -18,32 +24,44 @@ func NewProductRepository(db *sql.DB) *ProductRepository {
 	return &ProductRepository{db: db}
 }
 
-func (r *ProductRepository) GetProducts(name string) ([]models.Product, error) {
+// GetProducts lists products whose name contains name. Archived products are
+// left out unless includeArchived is set.
+func (r *ProductRepository) GetProducts(name string, includeArchived bool) ([]models.Product, error) {
 	query := `
 		SELECT
 			p.id,
 			p.name,
+			COALESCE(p.sku, ''),
+			COALESCE(p.barcode, ''),
 			p.price,
+			p.cost,
 			p.stock,
+			p.version,
 			p.created_at,
+			p.deleted_at,
 			COALESCE(
 				json_agg(json_build_object(
 					'id', c.id,
 					'name', c.name,
 					'description', c.description,
+					'version', c.version,
 					'created_at', c.created_at
 				)) FILTER (WHERE c.id IS NOT NULL),
 				'[]'::json
 			) AS categories
 		FROM products p
 		LEFT JOIN product_categories pc ON p.id = pc.product_id
-		LEFT JOIN categories c ON pc.category_id = c.id
+		LEFT JOIN categories c ON pc.category_id = c.id AND c.deleted_at IS NULL
+		WHERE 1=1
 	`
 	var args []any
 	if name != "" {
-		query += " WHERE p.name ILIKE $1 "
+		query += " AND p.name ILIKE $1 "
 		args = []any{"%" + name + "%"}
 	}
+	if !includeArchived {
+		query += " AND p.deleted_at IS NULL "
+	}
 	query += `
 		GROUP BY p.id, p.name
 	`
@@ -58,7 +76,7 @@ func (r *ProductRepository) GetProducts(name string) ([]models.Product, error) {
 	for rows.Next() {
 		var product models.Product
 		var categories string
-		err := rows.Scan(&product.ID, &product.Name, &product.Price, &product.Stock, &product.CreatedAt, &categories)
+		err := rows.Scan(&product.ID, &product.Name, &product.SKU, &product.Barcode, &product.Price, &product.Cost, &product.Stock, &product.Version, &product.CreatedAt, &product.DeletedAt, &categories)
 		if err != nil {
 			return nil, err
 		}
@@ -69,25 +87,53 @@ func (r *ProductRepository) GetProducts(name string) ([]models.Product, error) {
 	return products, nil
 }
 
-func (r *ProductRepository) CreateProduct(product models.Product) (models.Product, error) {
-	query := "INSERT INTO products (name, price, stock) VALUES ($1, $2, $3) RETURNING id, name, price, stock"
-	row := r.db.QueryRow(query, product.Name, product.Price, product.Stock)
+func (r *ProductRepository) CreateProduct(actor models.Actor, product models.Product) (models.Product, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.Product{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	query := "INSERT INTO products (name, sku, barcode, price, cost, stock) VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6) RETURNING " + productColumns
+	row := tx.QueryRow(query, product.Name, product.SKU, product.Barcode, product.Price, product.Cost, product.Stock)
 	var newProduct models.Product
-	err := row.Scan(&newProduct.ID, &newProduct.Name, &newProduct.Price, &newProduct.Stock)
+	err = row.Scan(&newProduct.ID, &newProduct.Name, &newProduct.SKU, &newProduct.Barcode, &newProduct.Price, &newProduct.Cost, &newProduct.Stock, &newProduct.Version)
+
+	if err != nil {
+		return models.Product{}, fmt.Errorf("failed to create product: %w", translateDuplicate(err))
+	}
+
+	err = insertPriceHistory(tx, newProduct.ID, newProduct.Price)
+	if err != nil {
+		return models.Product{}, err
+	}
 
+	err = writeOutbox(tx, models.OutboxProductCreated, newProduct.ID, newProduct)
 	if err != nil {
-		return models.Product{}, fmt.Errorf("failed to create product: %w", err)
+		return models.Product{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.Product{}, err
 	}
 	return newProduct, nil
 }
 
+// GetProductByID also returns archived products, with DeletedAt set, so links
+// from past transactions keep working.
 func (r *ProductRepository) GetProductByID(id string) (models.Product, error) {
 	query := `
-		SELECT p.id, p.name, p.price, p.stock, p.created_at,
-		       c.id, c.name, c.description, c.created_at
+		SELECT p.id, p.name, COALESCE(p.sku, ''), COALESCE(p.barcode, ''), p.price, p.cost, p.stock, p.version, p.created_at, p.deleted_at,
+		       c.id, c.name, c.description, c.version, c.created_at
 		FROM products p
 		LEFT JOIN product_categories pc ON p.id = pc.product_id
-		LEFT JOIN categories c ON pc.category_id = c.id
+		LEFT JOIN categories c ON pc.category_id = c.id AND c.deleted_at IS NULL
 		WHERE p.id = $1
 	`
 	rows, err := r.db.Query(query, id)
@@ -101,10 +147,11 @@ func (r *ProductRepository) GetProductByID(id string) (models.Product, error) {
 	for rows.Next() {
 		var category models.Category
 		var categoryID, categoryName, categoryDescription *string
+		var categoryVersion *int64
 		var categoryCreatedAt *time.Time
 
-		err := rows.Scan(&product.ID, &product.Name, &product.Price, &product.Stock, &product.CreatedAt,
-			&categoryID, &categoryName, &categoryDescription, &categoryCreatedAt)
+		err := rows.Scan(&product.ID, &product.Name, &product.SKU, &product.Barcode, &product.Price, &product.Cost, &product.Stock, &product.Version, &product.CreatedAt, &product.DeletedAt,
+			&categoryID, &categoryName, &categoryDescription, &categoryVersion, &categoryCreatedAt)
 		if err != nil {
 			return models.Product{}, fmt.Errorf("failed to scan product: %w", err)
 		}
@@ -114,6 +161,7 @@ func (r *ProductRepository) GetProductByID(id string) (models.Product, error) {
 				ID:          *categoryID,
 				Name:        *categoryName,
 				Description: *categoryDescription,
+				Version:     *categoryVersion,
 				CreatedAt:   *categoryCreatedAt,
 			}
 			product.Categories = append(product.Categories, category)
@@ -126,27 +174,83 @@ func (r *ProductRepository) GetProductByID(id string) (models.Product, error) {
 	return product, nil
 }
 
-func (r *ProductRepository) UpdateProductByID(id string, product models.Product) (models.Product, error) {
-	query := "UPDATE products SET name = $2, price = $3, stock = $4 WHERE id = $1 RETURNING id, name, price, stock"
-	row := r.db.QueryRow(query, id, product.Name, product.Price, product.Stock)
+// UpdateProductByID treats archived products as not found; restore them first.
+// ifMatch lists the versions the caller accepts, nil meaning any.
+func (r *ProductRepository) UpdateProductByID(actor models.Actor, id string, product models.Product, ifMatch []int64) (models.Product, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.Product{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	found, err := lockVersion(tx, "products", id, ifMatch)
+	if err != nil || !found {
+		return models.Product{}, err
+	}
+
+	var oldPrice int64
+	err = tx.QueryRow("SELECT price FROM products WHERE id = $1", id).Scan(&oldPrice)
+	if err != nil {
+		return models.Product{}, fmt.Errorf("failed to update product by id %s : %w", id, err)
+	}
+
+	query := "UPDATE products SET name = $2, sku = NULLIF($3, ''), barcode = NULLIF($4, ''), price = $5, cost = $6, stock = $7 WHERE id = $1 RETURNING " + productColumns
+	row := tx.QueryRow(query, id, product.Name, product.SKU, product.Barcode, product.Price, product.Cost, product.Stock)
 	var updatedProduct models.Product
-	err := row.Scan(&updatedProduct.ID, &updatedProduct.Name, &updatedProduct.Price, &updatedProduct.Stock)
+	err = row.Scan(&updatedProduct.ID, &updatedProduct.Name, &updatedProduct.SKU, &updatedProduct.Barcode, &updatedProduct.Price, &updatedProduct.Cost, &updatedProduct.Stock, &updatedProduct.Version)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return models.Product{}, nil
+		return models.Product{}, fmt.Errorf("failed to update product by id %s : %w", id, translateDuplicate(err))
+	}
+
+	if updatedProduct.Price != oldPrice {
+		err = insertPriceHistory(tx, updatedProduct.ID, updatedProduct.Price)
+		if err != nil {
+			return models.Product{}, err
 		}
-		return models.Product{}, fmt.Errorf("failed to update product by id %s : %w", id, err)
+	}
+
+	err = writeOutbox(tx, models.OutboxProductUpdated, updatedProduct.ID, updatedProduct)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.Product{}, err
 	}
 	return updatedProduct, nil
 }
 
-func (r *ProductRepository) DeleteProductByID(id string) (models.Product, error) {
-	query := "DELETE FROM products WHERE id = $1 RETURNING id, name, price, stock"
-	row := r.db.QueryRow(query, id)
+// DeleteProductByID archives the product. The row stays so sales history keeps
+// pointing at it, and it can be brought back with RestoreProductByID.
+func (r *ProductRepository) DeleteProductByID(actor models.Actor, id string, ifMatch []int64) (models.Product, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.Product{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	found, err := lockVersion(tx, "products", id, ifMatch)
+	if err != nil || !found {
+		return models.Product{}, err
+	}
+
+	query := "UPDATE products SET deleted_at = now() WHERE id = $1 RETURNING " + productColumns + ", deleted_at"
+	row := tx.QueryRow(query, id)
 
 	var deletedProduct models.Product
-	err := row.Scan(&deletedProduct.ID, &deletedProduct.Name, &deletedProduct.Price, &deletedProduct.Stock)
+	err = row.Scan(&deletedProduct.ID, &deletedProduct.Name, &deletedProduct.SKU, &deletedProduct.Barcode, &deletedProduct.Price, &deletedProduct.Cost, &deletedProduct.Stock, &deletedProduct.Version, &deletedProduct.DeletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return models.Product{}, nil
@@ -154,25 +258,130 @@ func (r *ProductRepository) DeleteProductByID(id string) (models.Product, error)
 		return models.Product{}, fmt.Errorf("failed to delete product by id %s : %w", id, err)
 	}
 
+	err = writeOutbox(tx, models.OutboxProductDeleted, deletedProduct.ID, deletedProduct)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.Product{}, err
+	}
 	return deletedProduct, nil
 }
 
-func (r *ProductRepository) AddCategoryToProduct(productID, categoryID string) error {
-	query := "INSERT INTO product_categories (product_id, category_id) VALUES ($1, $2)"
-	_, err := r.db.Exec(query, productID, categoryID)
+func (r *ProductRepository) RestoreProductByID(actor models.Actor, id string) (models.Product, error) {
+	var restoredProduct models.Product
+	err := inAuditedTx(r.db, actor, func(tx *sql.Tx) error {
+		query := "UPDATE products SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL RETURNING " + productColumns
+		row := tx.QueryRow(query, id)
+		err := row.Scan(&restoredProduct.ID, &restoredProduct.Name, &restoredProduct.SKU, &restoredProduct.Barcode, &restoredProduct.Price, &restoredProduct.Cost, &restoredProduct.Stock, &restoredProduct.Version)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return fmt.Errorf("failed to restore product by id %s : %w", id, err)
+		}
+		return writeOutbox(tx, models.OutboxProductRestored, restoredProduct.ID, restoredProduct)
+	})
+	if err != nil {
+		return models.Product{}, err
+	}
+	return restoredProduct, nil
+}
+
+func (r *ProductRepository) AddCategoryToProduct(actor models.Actor, productID, categoryID string) error {
+	return inAuditedTx(r.db, actor, func(tx *sql.Tx) error {
+		err := addCategoryToProduct(tx, productID, categoryID)
+		if err != nil {
+			return err
+		}
+		return writeProductCategoriesChanged(tx, productID)
+	})
+}
+
+// addCategoryToProduct returns a DuplicateError when the product is already in
+// the category and a NotFoundError when either does not exist. Archived
+// categories count as missing, since products never list them.
+func addCategoryToProduct(exec execer, productID, categoryID string) error {
+	query := `
+		INSERT INTO product_categories (product_id, category_id)
+		SELECT $1::uuid, id FROM categories WHERE id = $2 AND deleted_at IS NULL
+	`
+	result, err := exec.Exec(query, productID, categoryID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return apperrors.NewNotFoundError("product", productID)
+		}
+		return fmt.Errorf("failed to add category to product: %w", translateDuplicate(err))
+	}
+	added, err := result.RowsAffected()
 	if err != nil {
 		return fmt.Errorf("failed to add category to product: %w", err)
 	}
+	if added == 0 {
+		return apperrors.NewNotFoundError("category", categoryID)
+	}
 	return nil
 }
 
-func (r *ProductRepository) RemoveCategoryFromProduct(productID, categoryID string) error {
-	query := "DELETE FROM product_categories WHERE product_id = $1 AND category_id = $2"
-	_, err := r.db.Exec(query, productID, categoryID)
+func (r *ProductRepository) RemoveCategoryFromProduct(actor models.Actor, productID, categoryID string) error {
+	return inAuditedTx(r.db, actor, func(tx *sql.Tx) error {
+		query := "DELETE FROM product_categories WHERE product_id = $1 AND category_id = $2"
+		result, err := tx.Exec(query, productID, categoryID)
+		if err != nil {
+			return fmt.Errorf("failed to remove category from product: %w", err)
+		}
+		removed, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to remove category from product: %w", err)
+		}
+		if removed == 0 {
+			return nil
+		}
+		return writeProductCategoriesChanged(tx, productID)
+	})
+}
+
+// writeProductCategoriesChanged records a product.updated event carrying the
+// product with its categories as they stand inside tx, so subscribers see
+// category links change the same way they see field updates.
+func writeProductCategoriesChanged(tx *sql.Tx, productID string) error {
+	var product models.Product
+	row := tx.QueryRow("SELECT "+productColumns+" FROM products WHERE id = $1", productID)
+	err := row.Scan(&product.ID, &product.Name, &product.SKU, &product.Barcode, &product.Price, &product.Cost, &product.Stock, &product.Version)
 	if err != nil {
-		return fmt.Errorf("failed to remove category from product: %w", err)
+		return fmt.Errorf("failed to get product by id %s : %w", productID, err)
 	}
-	return nil
+
+	query := `
+		SELECT c.id, c.name, c.description, c.version, c.created_at
+		FROM categories c
+		INNER JOIN product_categories pc ON c.id = pc.category_id
+		WHERE pc.product_id = $1 AND c.deleted_at IS NULL
+		ORDER BY c.name
+	`
+	rows, err := tx.Query(query, productID)
+	if err != nil {
+		return fmt.Errorf("failed to get categories by product id %s : %w", productID, err)
+	}
+	product.Categories = []models.Category{}
+	for rows.Next() {
+		var category models.Category
+		err := rows.Scan(&category.ID, &category.Name, &category.Description, &category.Version, &category.CreatedAt)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan category: %w", err)
+		}
+		product.Categories = append(product.Categories, category)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to get categories by product id %s : %w", productID, err)
+	}
+
+	return writeOutbox(tx, models.OutboxProductUpdated, product.ID, product)
 }
 
 func (r *ProductRepository) GetCategoriesByProductID(productID string) ([]models.Category, error) {
@@ -180,7 +389,7 @@ func (r *ProductRepository) GetCategoriesByProductID(productID string) ([]models
 		SELECT c.id, c.name, c.description, c.created_at
 		FROM categories c
 		INNER JOIN product_categories pc ON c.id = pc.category_id
-		WHERE pc.product_id = $1
+		WHERE pc.product_id = $1 AND c.deleted_at IS NULL
 		ORDER BY c.name
 	`
 	rows, err := r.db.Query(query, productID)
@@ -201,3 +410,289 @@ func (r *ProductRepository) GetCategoriesByProductID(productID string) ([]models
 	}
 	return categories, nil
 }
+
+// ImportProducts upserts the rows by SKU inside a single transaction. Each row
+// runs under its own savepoint so every failing row is reported, then the
+// transaction is committed only when no row failed and dryRun is false. A row
+// updates the live product with its SKU, or else restores the archived one
+// archived last.
+func (r *ProductRepository) ImportProducts(actor models.Actor, rows []models.ProductImportRow, dryRun bool) (models.ProductImportResult, error) {
+	result := models.ProductImportResult{
+		DryRun:    dryRun,
+		TotalRows: len(rows),
+		Errors:    make([]models.ProductImportError, 0),
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return result, err
+	}
+
+	categoryIDs := make(map[string]string)
+	categoryRows, err := tx.Query("SELECT id, lower(name) FROM categories WHERE deleted_at IS NULL")
+	if err != nil {
+		return result, fmt.Errorf("failed to load categories: %w", err)
+	}
+	for categoryRows.Next() {
+		var id, name string
+		err = categoryRows.Scan(&id, &name)
+		if err != nil {
+			categoryRows.Close()
+			return result, fmt.Errorf("failed to scan category: %w", err)
+		}
+		categoryIDs[name] = id
+	}
+	categoryRows.Close()
+
+	for _, row := range rows {
+		_, err = tx.Exec("SAVEPOINT import_row")
+		if err != nil {
+			return result, err
+		}
+
+		created, rowErr := importProductRow(tx, row, categoryIDs)
+		if rowErr != nil {
+			_, err = tx.Exec("ROLLBACK TO SAVEPOINT import_row")
+			if err != nil {
+				return result, err
+			}
+			result.Errors = append(result.Errors, *rowErr)
+			continue
+		}
+
+		_, err = tx.Exec("RELEASE SAVEPOINT import_row")
+		if err != nil {
+			return result, err
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return result, err
+	}
+	result.Applied = true
+	return result, nil
+}
+
+func importProductRow(tx *sql.Tx, row models.ProductImportRow, categoryIDs map[string]string) (bool, *models.ProductImportError) {
+	rowError := func(field string, err error) *models.ProductImportError {
+		var duplicate *apperrors.DuplicateError
+		if errors.As(translateDuplicate(err), &duplicate) && (duplicate.Field == "sku" || duplicate.Field == "barcode") {
+			return &models.ProductImportError{Row: row.Row, Field: duplicate.Field, Message: "is already used by another product"}
+		}
+		log.Println(err)
+		return &models.ProductImportError{Row: row.Row, Field: field, Message: "could not be saved"}
+	}
+
+	categories := make([]string, 0, len(row.Categories))
+	for _, name := range row.Categories {
+		id, ok := categoryIDs[strings.ToLower(name)]
+		if !ok {
+			return false, &models.ProductImportError{Row: row.Row, Field: "categories", Message: fmt.Sprintf("unknown category %q", name)}
+		}
+		categories = append(categories, id)
+	}
+
+	var productID string
+	var oldPrice int64
+	var cost int64
+	var stock int
+	if row.SKU != "" {
+		err := tx.QueryRow("SELECT id, price FROM products WHERE sku = $1 ORDER BY deleted_at DESC NULLS FIRST LIMIT 1 FOR UPDATE", row.SKU).Scan(&productID, &oldPrice)
+		if err != nil && err != sql.ErrNoRows {
+			return false, rowError("sku", err)
+		}
+	}
+
+	created := productID == ""
+	if created {
+		err := tx.QueryRow("INSERT INTO products (name, sku, barcode, price, cost, stock) VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, COALESCE($5::bigint, 0), COALESCE($6::int, 0)) RETURNING id, cost, stock",
+			row.Name, row.SKU, row.Barcode, row.Price, row.Cost, row.Stock).Scan(&productID, &cost, &stock)
+		if err != nil {
+			return false, rowError("", err)
+		}
+		err = insertPriceHistory(tx, productID, row.Price)
+		if err != nil {
+			return false, rowError("price", err)
+		}
+	} else {
+		// A file without a cost or stock value leaves them as they are.
+		err := tx.QueryRow("UPDATE products SET name = $2, barcode = COALESCE(NULLIF($3, ''), barcode), price = $4, cost = COALESCE($5::bigint, cost), stock = COALESCE($6::int, stock), deleted_at = NULL WHERE id = $1 RETURNING cost, stock",
+			productID, row.Name, row.Barcode, row.Price, row.Cost, row.Stock).Scan(&cost, &stock)
+		if err != nil {
+			return false, rowError("", err)
+		}
+		if row.Price != oldPrice {
+			err = insertPriceHistory(tx, productID, row.Price)
+			if err != nil {
+				return false, rowError("price", err)
+			}
+		}
+	}
+
+	existing := make(map[string]bool)
+	if !created {
+		rows, err := tx.Query("SELECT category_id FROM product_categories WHERE product_id = $1", productID)
+		if err != nil {
+			return false, rowError("categories", err)
+		}
+		for rows.Next() {
+			var categoryID string
+			err = rows.Scan(&categoryID)
+			if err != nil {
+				rows.Close()
+				return false, rowError("categories", err)
+			}
+			existing[categoryID] = true
+		}
+		rows.Close()
+	}
+
+	for _, categoryID := range categories {
+		if existing[categoryID] {
+			continue
+		}
+		err := addCategoryToProduct(tx, productID, categoryID)
+		if err != nil {
+			return false, rowError("categories", err)
+		}
+		existing[categoryID] = true
+	}
+
+	eventType := models.OutboxProductUpdated
+	if created {
+		eventType = models.OutboxProductCreated
+	}
+	product := models.Product{ID: productID, Name: row.Name, SKU: row.SKU, Barcode: row.Barcode, Price: row.Price, Cost: cost, Stock: stock}
+	err := writeOutbox(tx, eventType, productID, product)
+	if err != nil {
+		return false, rowError("", err)
+	}
+
+	return created, nil
+}
+
+// ExportProducts streams products created in the given range to fn one row at
+// a time, without loading the whole catalog into memory. Archived products are
+// left out.
+func (r *ProductRepository) ExportProducts(dateRange models.DateRange, fn func(models.Product) error) error {
+	var args []any
+	query := `
+		SELECT
+			p.id,
+			p.name,
+			COALESCE(p.sku, ''),
+			COALESCE(p.barcode, ''),
+			p.price,
+			p.cost,
+			p.stock,
+			p.version,
+			p.created_at,
+			COALESCE(
+				json_agg(json_build_object(
+					'id', c.id,
+					'name', c.name,
+					'description', c.description,
+					'version', c.version,
+					'created_at', c.created_at
+				) ORDER BY c.name) FILTER (WHERE c.id IS NOT NULL),
+				'[]'::json
+			) AS categories
+		FROM products p
+		LEFT JOIN product_categories pc ON p.id = pc.product_id
+		LEFT JOIN categories c ON pc.category_id = c.id AND c.deleted_at IS NULL
+		WHERE p.deleted_at IS NULL
+	` + dateRangeCondition("p.created_at", dateRange, &args) + `
+		GROUP BY p.id
+		ORDER BY p.created_at, p.id
+	`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to export products: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var product models.Product
+		var categories string
+		err := rows.Scan(&product.ID, &product.Name, &product.SKU, &product.Barcode, &product.Price, &product.Cost, &product.Stock, &product.Version, &product.CreatedAt, &categories)
+		if err != nil {
+			return fmt.Errorf("failed to scan product: %w", err)
+		}
+		err = json.Unmarshal([]byte(categories), &product.Categories)
+		if err != nil {
+			return fmt.Errorf("failed to decode product categories: %w", err)
+		}
+		err = fn(product)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetProductsByIDs returns the products with the given ids, archived ones
+// included, without their categories. Unknown ids are left out.
+func (r *ProductRepository) GetProductsByIDs(ids []string) ([]models.Product, error) {
+	query := "SELECT " + productColumns + ", created_at, deleted_at FROM products WHERE id = ANY($1::uuid[])"
+	rows, err := r.db.Query(query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products by ids: %w", err)
+	}
+	defer rows.Close()
+
+	products := make([]models.Product, 0, len(ids))
+	for rows.Next() {
+		var product models.Product
+		err := rows.Scan(&product.ID, &product.Name, &product.SKU, &product.Barcode, &product.Price, &product.Cost, &product.Stock, &product.Version, &product.CreatedAt, &product.DeletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products = append(products, product)
+	}
+	return products, rows.Err()
+}
+
+// GetCategoriesByProductIDs returns the live categories of each of the given
+// products in one query, keyed by product id.
+func (r *ProductRepository) GetCategoriesByProductIDs(productIDs []string) (map[string][]models.Category, error) {
+	query := `
+		SELECT pc.product_id, c.id, c.name, c.description, c.version, c.created_at
+		FROM categories c
+		INNER JOIN product_categories pc ON c.id = pc.category_id
+		WHERE pc.product_id = ANY($1::uuid[]) AND c.deleted_at IS NULL
+		ORDER BY c.name
+	`
+	rows, err := r.db.Query(query, pq.Array(productIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories by product ids: %w", err)
+	}
+	defer rows.Close()
+
+	categories := make(map[string][]models.Category)
+	for rows.Next() {
+		var productID string
+		var category models.Category
+		err := rows.Scan(&productID, &category.ID, &category.Name, &category.Description, &category.Version, &category.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		categories[productID] = append(categories[productID], category)
+	}
+	return categories, rows.Err()
+}
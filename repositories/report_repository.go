@@ -2,8 +2,9 @@ package repositories
 
 import (
 	"database/sql"
+	"fmt"
 	"kasir-api/models"
-	"strconv"
+	"time"
 )
 
 type ReportRepository struct {
@@ -14,38 +15,21 @@ func NewReportRepository(db *sql.DB) *ReportRepository {
 	return &ReportRepository{db: db}
 }
 
-func (r *ReportRepository) GetReports(from string, to string) (models.Report, error) {
-	var params []string
+// GetReports reads from the daily sales rollup, so dateRange must fall on
+// store day boundaries.
+func (r *ReportRepository) GetReports(dateRange models.DateRange) (models.Report, error) {
+	var args []any
+	rangeCondition := saleDateCondition("ds.sale_date", dateRange, &args)
 	query := `
-		with transaction_range as 
-		(
-			select
-				product_id, p.name as product_name, quantity, subtotal, price, td.created_at as created_at
-			FROM transaction_details as td
-			left join products as p on p.id = td.product_id
-			WHERE 1=1
+		select ds.product_id, COALESCE(p.name, '') as product_name, sum(ds.quantity) as quantity, sum(ds.revenue) as subtotal,
+		       (select COALESCE(sum(ds.transaction_count), 0) from daily_sales_totals ds where 1=1 ` + rangeCondition + `) as transaction_count
+		from daily_sales as ds
+		left join products as p on p.id = ds.product_id
+		where 1=1 ` + rangeCondition + `
+		group by ds.product_id, p.name
+		order by quantity desc, subtotal desc
 	`
-	if len(from) != 0 {
-		params = append(params, from)
-		query += " AND td.created_at >= $" + strconv.Itoa(len(params))
-	}
-	if len(to) != 0 {
-		params = append(params, to)
-		query += " AND td.created_at <= $" + strconv.Itoa(len(params))
-	}
-	query += `)
-		select product_id, product_name, sum(quantity) as quantity, sum(subtotal) as subtotal, COUNT(*) OVER() as transaction_count
-		from transaction_range
-		group by product_id, product_name
-		order by quantity desc
-	`
-	var rows *sql.Rows
-	var err error
-	if len(from) != 0 || len(to) != 0 {
-		rows, err = r.db.Query(query, from, to)
-	} else {
-		rows, err = r.db.Query(query)
-	}
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return models.Report{}, err
 	}
@@ -61,8 +45,8 @@ func (r *ReportRepository) GetReports(from string, to string) (models.Report, er
 			return models.Report{}, err
 		}
 		report.TotalRevenue += transactionDetail.Subtotal
-		report.TotalTransactions += transactionCount
-		if transactionCount > int64(report.BestSeller.Quantity) {
+		report.TotalTransactions = transactionCount
+		if int64(transactionDetail.Quantity) > report.BestSeller.Quantity {
 			report.BestSeller.ProductID = transactionDetail.ProductID
 			report.BestSeller.ProductName = transactionDetail.ProductName
 			report.BestSeller.Quantity = int64(transactionDetail.Quantity)
@@ -72,3 +56,376 @@ func (r *ReportRepository) GetReports(from string, to string) (models.Report, er
 
 	return report, nil
 }
+
+// rollupSalesGroupings maps the group_by values that can be answered from the
+// daily sales rollup onto their key and label expressions. Day totals are
+// read from daily_sales_totals and product rows from daily_sales.
+var rollupSalesGroupings = map[string]struct {
+	key   string
+	label string
+	from  string
+}{
+	models.GroupByDay:   {key: "to_char(ds.sale_date, 'YYYY-MM-DD')", label: "to_char(ds.sale_date, 'YYYY-MM-DD')", from: "daily_sales_totals ds"},
+	models.GroupByWeek:  {key: "to_char(date_trunc('week', ds.sale_date), 'IYYY-\"W\"IW')", label: "to_char(date_trunc('week', ds.sale_date), 'YYYY-MM-DD')", from: "daily_sales_totals ds"},
+	models.GroupByMonth: {key: "to_char(ds.sale_date, 'YYYY-MM')", label: "to_char(ds.sale_date, 'YYYY-MM')", from: "daily_sales_totals ds"},
+	models.GroupByProduct: {
+		key:   "ds.product_id::text",
+		label: "COALESCE(p.name, '')",
+		from:  "daily_sales ds LEFT JOIN products p ON p.id = ds.product_id",
+	},
+}
+
+// salesGroupings maps the remaining group_by values onto expressions over the
+// raw transaction lines. Hours are cut in the timezone passed as $1. Products
+// in several categories count towards each of them, which is why categories
+// need the raw lines to count distinct transactions.
+var salesGroupings = map[string]struct {
+	key   string
+	label string
+	join  string
+	local bool
+}{
+	models.GroupByHour: {key: "to_char(date_trunc('hour', t.created_at AT TIME ZONE $1), 'YYYY-MM-DD\"T\"HH24:00')", label: "to_char(date_trunc('hour', t.created_at AT TIME ZONE $1), 'YYYY-MM-DD HH24:00')", local: true},
+	models.GroupByCategory: {
+		key:   "COALESCE(c.id::text, '')",
+		label: "COALESCE(c.name, 'Uncategorized')",
+		join:  "LEFT JOIN product_categories pc ON pc.product_id = td.product_id LEFT JOIN categories c ON c.id = pc.category_id",
+	},
+}
+
+// GetSalesReport groups sales by time bucket, product or category. Everything
+// but hours and categories is read from the daily sales rollup, so dateRange
+// must fall on store day boundaries.
+func (r *ReportRepository) GetSalesReport(groupBy string, dateRange models.DateRange, timezone string) (models.SalesReport, error) {
+	var args []any
+	var query string
+	if grouping, ok := rollupSalesGroupings[groupBy]; ok {
+		query = `
+			SELECT ` + grouping.key + ` AS key, ` + grouping.label + ` AS label,
+			       SUM(ds.revenue), SUM(ds.quantity), SUM(ds.transaction_count)
+			FROM ` + grouping.from + `
+			WHERE 1=1 ` + saleDateCondition("ds.sale_date", dateRange, &args) + `
+			GROUP BY 1, 2
+			ORDER BY 1
+		`
+	} else if grouping, ok := salesGroupings[groupBy]; ok {
+		if grouping.local {
+			args = append(args, timezone)
+		}
+		query = `
+			SELECT ` + grouping.key + ` AS key, ` + grouping.label + ` AS label,
+			       SUM(td.subtotal), SUM(td.quantity), COUNT(DISTINCT td.transaction_id)
+			FROM transaction_details td
+			INNER JOIN transactions t ON t.id = td.transaction_id
+			` + grouping.join + `
+			WHERE 1=1 ` + dateRangeCondition("t.created_at", dateRange, &args) + `
+			GROUP BY 1, 2
+			ORDER BY 1
+		`
+	} else {
+		return models.SalesReport{}, fmt.Errorf("unsupported group_by %q", groupBy)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return models.SalesReport{}, fmt.Errorf("failed to get sales report: %w", err)
+	}
+	defer rows.Close()
+
+	report := models.SalesReport{GroupBy: groupBy, Rows: make([]models.SalesReportRow, 0)}
+	for rows.Next() {
+		var row models.SalesReportRow
+		err := rows.Scan(&row.Key, &row.Label, &row.Revenue, &row.Quantity, &row.TransactionCount)
+		if err != nil {
+			return models.SalesReport{}, fmt.Errorf("failed to scan sales report row: %w", err)
+		}
+		if row.TransactionCount > 0 {
+			row.AverageBasket = row.Revenue / row.TransactionCount
+		}
+		report.Rows = append(report.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return models.SalesReport{}, err
+	}
+
+	// Totals come from the day totals so category overlap is not double counted.
+	var totalsArgs []any
+	totalsQuery := `
+		SELECT COALESCE(SUM(ds.revenue), 0), COALESCE(SUM(ds.quantity), 0), COALESCE(SUM(ds.transaction_count), 0)
+		FROM daily_sales_totals ds
+		WHERE 1=1 ` + saleDateCondition("ds.sale_date", dateRange, &totalsArgs)
+	report.Totals.Key = "total"
+	report.Totals.Label = "Total"
+	err = r.db.QueryRow(totalsQuery, totalsArgs...).Scan(&report.Totals.Revenue, &report.Totals.Quantity, &report.Totals.TransactionCount)
+	if err != nil {
+		return models.SalesReport{}, fmt.Errorf("failed to get sales report totals: %w", err)
+	}
+	if report.Totals.TransactionCount > 0 {
+		report.Totals.AverageBasket = report.Totals.Revenue / report.Totals.TransactionCount
+	}
+
+	return report, nil
+}
+
+var rankingMetrics = map[string]string{
+	models.RankByQuantity: "COALESCE(s.quantity, 0)",
+	models.RankByRevenue:  "COALESCE(s.revenue, 0)",
+	models.RankByMargin:   "COALESCE(s.revenue, 0) - COALESCE(s.quantity, 0) * p.cost",
+}
+
+// GetProductRanking returns the best and worst performing products for the
+// metric. The bottom list includes products that did not sell at all, which
+// are the slowest movers.
+func (r *ReportRepository) GetProductRanking(metric string, top int, bottom int, dateRange models.DateRange) (models.ProductRankingReport, error) {
+	expression, ok := rankingMetrics[metric]
+	if !ok {
+		return models.ProductRankingReport{}, fmt.Errorf("unsupported metric %q", metric)
+	}
+
+	report := models.ProductRankingReport{Metric: metric}
+	var err error
+	report.Top, err = r.rankProducts(expression, "DESC", top, dateRange, true)
+	if err != nil {
+		return models.ProductRankingReport{}, err
+	}
+	report.Bottom, err = r.rankProducts(expression, "ASC", bottom, dateRange, false)
+	if err != nil {
+		return models.ProductRankingReport{}, err
+	}
+	return report, nil
+}
+
+func (r *ReportRepository) rankProducts(expression string, direction string, limit int, dateRange models.DateRange, soldOnly bool) ([]models.ProductPerformance, error) {
+	if limit <= 0 {
+		return make([]models.ProductPerformance, 0), nil
+	}
+
+	var args []any
+	query := productSalesQuery(dateRange, &args)
+	if soldOnly {
+		query += " AND s.product_id IS NOT NULL"
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY %s %s, p.name LIMIT $%d", expression, direction, len(args))
+
+	return r.queryProductPerformance(query, args)
+}
+
+// GetProductSales returns every product with its sales in the range, highest
+// revenue first. Products without sales are included with zero totals.
+func (r *ReportRepository) GetProductSales(dateRange models.DateRange) ([]models.ProductPerformance, error) {
+	var args []any
+	query := productSalesQuery(dateRange, &args) + " ORDER BY COALESCE(s.revenue, 0) DESC, p.name"
+	return r.queryProductPerformance(query, args)
+}
+
+// productSalesQuery selects the columns of models.ProductPerformance for all
+// products, with sales from the daily rollup limited to the range. Archived
+// products are only included when they sold in the range. Callers append
+// filters and ordering.
+func productSalesQuery(dateRange models.DateRange, args *[]any) string {
+	return `
+		WITH sales AS (
+			SELECT product_id, SUM(quantity) AS quantity, SUM(revenue) AS revenue
+			FROM daily_sales
+			WHERE 1=1 ` + saleDateCondition("sale_date", dateRange, args) + `
+			GROUP BY product_id
+		)
+		SELECT p.id, p.name, p.stock, COALESCE(s.quantity, 0), COALESCE(s.revenue, 0), COALESCE(s.quantity, 0) * p.cost
+		FROM products p
+		LEFT JOIN sales s ON s.product_id = p.id
+		WHERE (p.deleted_at IS NULL OR s.product_id IS NOT NULL)
+	`
+}
+
+func (r *ReportRepository) queryProductPerformance(query string, args []any) ([]models.ProductPerformance, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product sales: %w", err)
+	}
+	defer rows.Close()
+
+	products := make([]models.ProductPerformance, 0)
+	for rows.Next() {
+		var product models.ProductPerformance
+		err := rows.Scan(&product.ProductID, &product.ProductName, &product.Stock, &product.Quantity, &product.Revenue, &product.Cost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product sales: %w", err)
+		}
+		product.Margin = product.Revenue - product.Cost
+		products = append(products, product)
+	}
+	return products, rows.Err()
+}
+
+// GetDeadStock lists products with stock on hand that have not sold since the
+// given time, most expensive inventory first.
+func (r *ReportRepository) GetDeadStock(since time.Time) ([]models.DeadStockItem, error) {
+	query := `
+		SELECT p.id, p.name, p.stock, p.cost, p.price, MAX(t.created_at) AS last_sold_at
+		FROM products p
+		LEFT JOIN transaction_details td ON td.product_id = p.id
+		LEFT JOIN transactions t ON t.id = td.transaction_id
+		WHERE p.stock > 0 AND p.deleted_at IS NULL
+		GROUP BY p.id
+		HAVING MAX(t.created_at) IS NULL OR MAX(t.created_at) < $1
+		ORDER BY p.stock * p.cost DESC, p.stock * p.price DESC, p.name
+	`
+	rows, err := r.db.Query(query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead stock: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]models.DeadStockItem, 0)
+	for rows.Next() {
+		var item models.DeadStockItem
+		err := rows.Scan(&item.ProductID, &item.ProductName, &item.Stock, &item.Cost, &item.Price, &item.LastSoldAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dead stock: %w", err)
+		}
+		item.InventoryCost = int64(item.Stock) * item.Cost
+		item.InventoryValue = int64(item.Stock) * item.Price
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// basketsQuery starts a query with the CTEs used for basket analysis: baskets
+// holds each distinct product per transaction in the range, item_counts the
+// number of transactions per product and totals the number of transactions.
+func basketsQuery(dateRange models.DateRange, args *[]any) string {
+	return `
+		WITH baskets AS (
+			SELECT DISTINCT td.transaction_id, td.product_id
+			FROM transaction_details td
+			INNER JOIN transactions t ON t.id = td.transaction_id
+			WHERE 1=1 ` + dateRangeCondition("t.created_at", dateRange, args) + `
+		),
+		item_counts AS (
+			SELECT product_id, COUNT(*) AS n FROM baskets GROUP BY product_id
+		),
+		totals AS (
+			SELECT COUNT(DISTINCT transaction_id) AS n FROM baskets
+		)
+	`
+}
+
+// GetProductPairs returns product pairs bought together in at least minCount
+// transactions, most frequent first.
+func (r *ReportRepository) GetProductPairs(dateRange models.DateRange, minCount int, limit int) (models.BasketReport, error) {
+	var args []any
+	query := basketsQuery(dateRange, &args)
+	args = append(args, minCount, limit)
+	query += fmt.Sprintf(`,
+		pairs AS (
+			SELECT a.product_id AS product_a, b.product_id AS product_b, COUNT(*) AS n
+			FROM baskets a
+			INNER JOIN baskets b ON b.transaction_id = a.transaction_id AND a.product_id < b.product_id
+			GROUP BY a.product_id, b.product_id
+			HAVING COUNT(*) >= $%d
+		)
+		SELECT pairs.product_a, COALESCE(pa.name, ''), pairs.product_b, COALESCE(pb.name, ''), pairs.n, ca.n, cb.n, totals.n
+		FROM pairs
+		INNER JOIN item_counts ca ON ca.product_id = pairs.product_a
+		INNER JOIN item_counts cb ON cb.product_id = pairs.product_b
+		LEFT JOIN products pa ON pa.id = pairs.product_a
+		LEFT JOIN products pb ON pb.id = pairs.product_b
+		CROSS JOIN totals
+		ORDER BY pairs.n DESC, pa.name, pb.name
+		LIMIT $%d
+	`, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return models.BasketReport{}, fmt.Errorf("failed to get product pairs: %w", err)
+	}
+	defer rows.Close()
+
+	report := models.BasketReport{Pairs: make([]models.ProductPair, 0)}
+	for rows.Next() {
+		var pair models.ProductPair
+		var countA, countB int64
+		err := rows.Scan(&pair.ProductAID, &pair.ProductAName, &pair.ProductBID, &pair.ProductBName,
+			&pair.TransactionCount, &countA, &countB, &report.TotalTransactions)
+		if err != nil {
+			return models.BasketReport{}, fmt.Errorf("failed to scan product pair: %w", err)
+		}
+		total := float64(report.TotalTransactions)
+		pair.Support = float64(pair.TransactionCount) / total
+		pair.ConfidenceAToB = float64(pair.TransactionCount) / float64(countA)
+		pair.ConfidenceBToA = float64(pair.TransactionCount) / float64(countB)
+		pair.Lift = pair.Support * total * total / float64(countA*countB)
+		report.Pairs = append(report.Pairs, pair)
+	}
+	if err := rows.Err(); err != nil {
+		return models.BasketReport{}, err
+	}
+
+	if len(report.Pairs) == 0 {
+		var countArgs []any
+		countQuery := basketsQuery(dateRange, &countArgs) + " SELECT n FROM totals"
+		err = r.db.QueryRow(countQuery, countArgs...).Scan(&report.TotalTransactions)
+		if err != nil {
+			return models.BasketReport{}, fmt.Errorf("failed to count transactions: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// GetFrequentlyBoughtWith returns the products that appear in the same
+// transactions as productID, most frequent first.
+func (r *ReportRepository) GetFrequentlyBoughtWith(productID string, dateRange models.DateRange, minCount int, limit int) (models.FrequentlyBoughtWith, error) {
+	result := models.FrequentlyBoughtWith{ProductID: productID, Products: make([]models.BoughtWith, 0)}
+
+	var args []any
+	query := basketsQuery(dateRange, &args)
+	args = append(args, productID)
+	query += fmt.Sprintf(`
+		SELECT COALESCE((SELECT n FROM item_counts WHERE product_id = $%d), 0), totals.n FROM totals
+	`, len(args))
+	err := r.db.QueryRow(query, args...).Scan(&result.TransactionCount, &result.TotalTransactions)
+	if err != nil {
+		return models.FrequentlyBoughtWith{}, fmt.Errorf("failed to count transactions for product %s : %w", productID, err)
+	}
+	if result.TransactionCount == 0 {
+		return result, nil
+	}
+
+	args = nil
+	query = basketsQuery(dateRange, &args)
+	args = append(args, productID, minCount, limit)
+	query += fmt.Sprintf(`
+		SELECT b.product_id, COALESCE(p.name, ''), COUNT(*) AS n, c.n
+		FROM baskets a
+		INNER JOIN baskets b ON b.transaction_id = a.transaction_id AND b.product_id <> a.product_id
+		INNER JOIN item_counts c ON c.product_id = b.product_id
+		LEFT JOIN products p ON p.id = b.product_id
+		WHERE a.product_id = $%d
+		GROUP BY b.product_id, p.name, c.n
+		HAVING COUNT(*) >= $%d
+		ORDER BY n DESC, p.name
+		LIMIT $%d
+	`, len(args)-2, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return models.FrequentlyBoughtWith{}, fmt.Errorf("failed to get products bought with %s : %w", productID, err)
+	}
+	defer rows.Close()
+
+	total := float64(result.TotalTransactions)
+	for rows.Next() {
+		var item models.BoughtWith
+		var count int64
+		err := rows.Scan(&item.ProductID, &item.ProductName, &item.TransactionCount, &count)
+		if err != nil {
+			return models.FrequentlyBoughtWith{}, fmt.Errorf("failed to scan bought with: %w", err)
+		}
+		item.Support = float64(item.TransactionCount) / total
+		item.Confidence = float64(item.TransactionCount) / float64(result.TransactionCount)
+		item.Lift = item.Confidence / (float64(count) / total)
+		result.Products = append(result.Products, item)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"kasir-api/internal"
+	apperrors "kasir-api/internal/errors"
+	"kasir-api/models"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// queryRower and execer are satisfied by both *sql.DB and *sql.Tx so helpers
+// can run inside or outside a transaction.
+type queryRower interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// dateRangeCondition filters column to the half-open range [From, To),
+// skipping whichever bound is zero.
+func dateRangeCondition(column string, dateRange models.DateRange, args *[]any) string {
+	condition := ""
+	if !dateRange.From.IsZero() {
+		*args = append(*args, dateRange.From)
+		condition += fmt.Sprintf(" AND %s >= $%d", column, len(*args))
+	}
+	if !dateRange.To.IsZero() {
+		*args = append(*args, dateRange.To)
+		condition += fmt.Sprintf(" AND %s < $%d", column, len(*args))
+	}
+	return condition
+}
+
+// saleDateCondition filters a store calendar date column to the days covered
+// by dateRange. Ranges are expected to fall on store day boundaries, as the
+// ones from internal.ParseDateRange and internal.DayRange do.
+func saleDateCondition(column string, dateRange models.DateRange, args *[]any) string {
+	condition := ""
+	if !dateRange.From.IsZero() {
+		*args = append(*args, internal.StoreDate(dateRange.From))
+		condition += fmt.Sprintf(" AND %s >= $%d::date", column, len(*args))
+	}
+	if !dateRange.To.IsZero() {
+		*args = append(*args, internal.StoreDate(dateRange.To))
+		condition += fmt.Sprintf(" AND %s < $%d::date", column, len(*args))
+	}
+	return condition
+}
+
+// lockVersion locks the live row with the given id in table and checks its
+// version against ifMatch, where nil accepts any version. It returns false
+// when the row does not exist or is archived.
+func lockVersion(db queryRower, table string, id string, ifMatch []int64) (bool, error) {
+	var version int64
+	err := db.QueryRow("SELECT version FROM "+table+" WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", id).Scan(&version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to lock %s %s : %w", table, id, err)
+	}
+	if ifMatch != nil && !slices.Contains(ifMatch, version) {
+		return true, apperrors.NewVersionMismatchError(version)
+	}
+	return true, nil
+}
+
+// duplicateFields names the request field behind each unique constraint, for
+// constraints whose key columns do not already say it.
+var duplicateFields = map[string]string{
+	"idx_categories_name":                                        "name",
+	"idx_product_categories_pair":                                "category_id",
+	"product_lots_product_id_lot_number_key":                     "lot_number",
+	"price_list_items_price_list_id_product_id_min_quantity_key": "min_quantity",
+}
+
+var duplicateKeyPattern = regexp.MustCompile(`^Key \((.+)\)=\((.*)\) already exists`)
+
+// translateDuplicate turns a Postgres unique violation into a DuplicateError
+// carrying the offending field and value, and returns any other error as is.
+func translateDuplicate(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != "23505" {
+		return err
+	}
+	columns, values := "", ""
+	if match := duplicateKeyPattern.FindStringSubmatch(pqErr.Detail); match != nil {
+		columns, values = match[1], match[2]
+	}
+
+	field, ok := duplicateFields[pqErr.Constraint]
+	if !ok {
+		field = columns
+	}
+	// For composite keys report only the value of the named field.
+	columnList := strings.Split(columns, ", ")
+	valueList := strings.Split(values, ", ")
+	if len(columnList) > 1 && len(columnList) == len(valueList) {
+		if i := slices.Index(columnList, field); i >= 0 {
+			values = valueList[i]
+		}
+	}
+	return apperrors.NewDuplicateError(field, values)
+}
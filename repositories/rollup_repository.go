@@ -0,0 +1,235 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"time"
+)
+
+type RollupRepository struct {
+	db *sql.DB
+}
+
+func NewRollupRepository(db *sql.DB) *RollupRepository {
+	return &RollupRepository{db: db}
+}
+
+// rawDailySalesQuery aggregates transaction lines per store day ($1 is the
+// timezone) and product. rawDailyTotalsQuery does the same per day only.
+const (
+	rawDailySalesQuery = `
+		SELECT (t.created_at AT TIME ZONE $1)::date AS sale_date, td.product_id,
+		       SUM(td.quantity) AS quantity, SUM(td.subtotal) AS revenue, COUNT(DISTINCT td.transaction_id) AS transaction_count
+		FROM transaction_details td
+		INNER JOIN transactions t ON t.id = td.transaction_id
+		WHERE td.product_id IS NOT NULL
+	`
+	rawDailyTotalsQuery = `
+		SELECT (t.created_at AT TIME ZONE $1)::date AS sale_date,
+		       SUM(td.quantity) AS quantity, SUM(td.subtotal) AS revenue, COUNT(DISTINCT td.transaction_id) AS transaction_count
+		FROM transaction_details td
+		INNER JOIN transactions t ON t.id = td.transaction_id
+		WHERE 1=1
+	`
+)
+
+// Every store day has its own advisory lock, keyed by its number of days
+// since 2000-01-01. Checkouts take the lock of their day shared, so they never
+// wait for each other, and RebuildDays takes it exclusive while it recomputes
+// that day only.
+const (
+	lockSaleDayShared = `
+		SELECT pg_advisory_xact_lock_shared(hashtext('daily_sales'), (created_at AT TIME ZONE $1)::date - DATE '2000-01-01')
+		FROM transactions WHERE id = $2
+	`
+	lockSaleDay = "SELECT pg_advisory_xact_lock(hashtext('daily_sales'), $1::date - DATE '2000-01-01')"
+)
+
+// addToDailySales adds a newly created transaction to the rollup. It runs in
+// the checkout transaction so the rollup never drifts from committed sales.
+func addToDailySales(exec execer, transactionID string, timezone string) error {
+	_, err := exec.Exec(lockSaleDayShared, timezone, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to lock daily sales: %w", err)
+	}
+
+	_, err = exec.Exec("INSERT INTO daily_sales (sale_date, product_id, quantity, revenue, transaction_count) "+rawDailySalesQuery+` AND td.transaction_id = $2 GROUP BY 1, 2
+		ON CONFLICT (sale_date, product_id) DO UPDATE SET
+			quantity = daily_sales.quantity + EXCLUDED.quantity,
+			revenue = daily_sales.revenue + EXCLUDED.revenue,
+			transaction_count = daily_sales.transaction_count + EXCLUDED.transaction_count
+	`, timezone, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to update daily sales: %w", err)
+	}
+
+	_, err = exec.Exec("INSERT INTO daily_sales_totals (sale_date, quantity, revenue, transaction_count) "+rawDailyTotalsQuery+` AND td.transaction_id = $2 GROUP BY 1
+		ON CONFLICT (sale_date) DO UPDATE SET
+			quantity = daily_sales_totals.quantity + EXCLUDED.quantity,
+			revenue = daily_sales_totals.revenue + EXCLUDED.revenue,
+			transaction_count = daily_sales_totals.transaction_count + EXCLUDED.transaction_count
+	`, timezone, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to update daily sales totals: %w", err)
+	}
+	return nil
+}
+
+// GetTimezone returns the timezone of the last full rebuild, or an empty
+// string if the rollup has never been built.
+func (r *RollupRepository) GetTimezone() (string, error) {
+	var timezone string
+	err := r.db.QueryRow("SELECT timezone FROM daily_sales_state").Scan(&timezone)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get daily sales state: %w", err)
+	}
+	return timezone, nil
+}
+
+// Rebuild recomputes the rollup for the days in dateRange from the raw
+// transactions, or all of it when the range is open on both sides. The
+// rollup tables are locked for the duration so checkouts running at the same
+// time are either fully counted by the rebuild or added after it. That blocks
+// every checkout, so it is meant for startup and the rollup-rebuild command;
+// RebuildDays repairs a few days while the API is serving.
+func (r *RollupRepository) Rebuild(dateRange models.DateRange, timezone string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("LOCK TABLE daily_sales, daily_sales_totals IN EXCLUSIVE MODE")
+	if err != nil {
+		return fmt.Errorf("failed to lock daily sales: %w", err)
+	}
+
+	err = rebuildRange(tx, dateRange, timezone)
+	if err != nil {
+		return err
+	}
+
+	if dateRange.From.IsZero() && dateRange.To.IsZero() {
+		_, err = tx.Exec(`
+			INSERT INTO daily_sales_state (id, timezone, rebuilt_at) VALUES (true, $1, now())
+			ON CONFLICT (id) DO UPDATE SET timezone = EXCLUDED.timezone, rebuilt_at = EXCLUDED.rebuilt_at
+		`, timezone)
+		if err != nil {
+			return fmt.Errorf("failed to save daily sales state: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// RebuildDays recomputes the store days in dateRange, which must be bounded on
+// both sides, one day per transaction. Each day is held under its advisory
+// lock, so only the checkouts of the day being recomputed wait, and only for
+// that day's queries.
+func (r *RollupRepository) RebuildDays(dateRange models.DateRange, timezone string) error {
+	for start := dateRange.From; start.Before(dateRange.To); start = start.AddDate(0, 0, 1) {
+		err := r.rebuildDay(start, timezone)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RollupRepository) rebuildDay(start time.Time, timezone string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(lockSaleDay, internal.StoreDate(start))
+	if err != nil {
+		return fmt.Errorf("failed to lock daily sales: %w", err)
+	}
+
+	err = rebuildRange(tx, models.DateRange{From: start, To: start.AddDate(0, 0, 1)}, timezone)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// rebuildRange replaces the rollup rows of the days in dateRange with sums of
+// the raw transactions.
+func rebuildRange(tx *sql.Tx, dateRange models.DateRange, timezone string) error {
+	for _, table := range []string{"daily_sales", "daily_sales_totals"} {
+		var args []any
+		_, err := tx.Exec("DELETE FROM "+table+" WHERE 1=1"+saleDateCondition("sale_date", dateRange, &args), args...)
+		if err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table, err)
+		}
+	}
+
+	args := []any{timezone}
+	query := "INSERT INTO daily_sales (sale_date, product_id, quantity, revenue, transaction_count) " +
+		rawDailySalesQuery + dateRangeCondition("t.created_at", dateRange, &args) + " GROUP BY 1, 2"
+	_, err := tx.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild daily sales: %w", err)
+	}
+
+	args = []any{timezone}
+	query = "INSERT INTO daily_sales_totals (sale_date, quantity, revenue, transaction_count) " +
+		rawDailyTotalsQuery + dateRangeCondition("t.created_at", dateRange, &args) + " GROUP BY 1"
+	_, err = tx.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild daily sales totals: %w", err)
+	}
+	return nil
+}
+
+// Check compares the rollup with the raw transactions for the days in
+// dateRange and returns every day and product where they differ. Day totals
+// are reported with an empty product id.
+func (r *RollupRepository) Check(dateRange models.DateRange, timezone string) ([]models.RollupMismatch, error) {
+	args := []any{timezone}
+	rawRange := dateRangeCondition("t.created_at", dateRange, &args)
+	rollupRange := saleDateCondition("sale_date", dateRange, &args)
+	query := `
+		WITH raw AS (` + rawDailySalesQuery + rawRange + ` GROUP BY 1, 2),
+		raw_totals AS (` + rawDailyTotalsQuery + rawRange + ` GROUP BY 1),
+		stored AS (SELECT * FROM daily_sales WHERE 1=1 ` + rollupRange + `),
+		stored_totals AS (SELECT * FROM daily_sales_totals WHERE 1=1 ` + rollupRange + `)
+		SELECT to_char(COALESCE(stored.sale_date, raw.sale_date), 'YYYY-MM-DD'), COALESCE(stored.product_id, raw.product_id)::text,
+		       COALESCE(stored.quantity, 0), COALESCE(raw.quantity, 0), COALESCE(stored.revenue, 0), COALESCE(raw.revenue, 0),
+		       COALESCE(stored.transaction_count, 0), COALESCE(raw.transaction_count, 0)
+		FROM stored
+		FULL OUTER JOIN raw ON raw.sale_date = stored.sale_date AND raw.product_id = stored.product_id
+		WHERE (stored.quantity, stored.revenue, stored.transaction_count) IS DISTINCT FROM (raw.quantity, raw.revenue, raw.transaction_count)
+		UNION ALL
+		SELECT to_char(COALESCE(stored_totals.sale_date, raw_totals.sale_date), 'YYYY-MM-DD'), '',
+		       COALESCE(stored_totals.quantity, 0), COALESCE(raw_totals.quantity, 0), COALESCE(stored_totals.revenue, 0), COALESCE(raw_totals.revenue, 0),
+		       COALESCE(stored_totals.transaction_count, 0), COALESCE(raw_totals.transaction_count, 0)
+		FROM stored_totals
+		FULL OUTER JOIN raw_totals ON raw_totals.sale_date = stored_totals.sale_date
+		WHERE (stored_totals.quantity, stored_totals.revenue, stored_totals.transaction_count) IS DISTINCT FROM (raw_totals.quantity, raw_totals.revenue, raw_totals.transaction_count)
+		ORDER BY 1, 2
+	`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check daily sales: %w", err)
+	}
+	defer rows.Close()
+
+	mismatches := make([]models.RollupMismatch, 0)
+	for rows.Next() {
+		var mismatch models.RollupMismatch
+		err := rows.Scan(&mismatch.Date, &mismatch.ProductID, &mismatch.RollupQuantity, &mismatch.RawQuantity,
+			&mismatch.RollupRevenue, &mismatch.RawRevenue, &mismatch.RollupTransactions, &mismatch.RawTransactions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan daily sales mismatch: %w", err)
+		}
+		mismatches = append(mismatches, mismatch)
+	}
+	return mismatches, rows.Err()
+}
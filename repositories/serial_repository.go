@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+)
+
+type SerialRepository struct {
+	db *sql.DB
+}
+
+func NewSerialRepository(db *sql.DB) *SerialRepository {
+	return &SerialRepository{db: db}
+}
+
+func (r *SerialRepository) GetSerialsByProductID(productID string, status string) ([]models.ProductSerial, error) {
+	query := `
+		SELECT id, product_id, serial_number, status, COALESCE(transaction_id::text, ''), received_at, sold_at
+		FROM product_serials
+		WHERE product_id = $1
+	`
+	args := []any{productID}
+	if status != "" {
+		query += " AND status = $2"
+		args = append(args, status)
+	}
+	query += " ORDER BY received_at ASC, serial_number ASC"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get serials by product id %s : %w", productID, err)
+	}
+	defer rows.Close()
+
+	serials := make([]models.ProductSerial, 0)
+	for rows.Next() {
+		var serial models.ProductSerial
+		err := rows.Scan(&serial.ID, &serial.ProductID, &serial.SerialNumber, &serial.Status, &serial.TransactionID, &serial.ReceivedAt, &serial.SoldAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan serial: %w", err)
+		}
+		serials = append(serials, serial)
+	}
+	return serials, nil
+}
+
+// ReceiveSerials registers received units by serial number and adds them to
+// the product stock. It returns nil when the product does not exist.
+func (r *SerialRepository) ReceiveSerials(actor models.Actor, productID string, serialNumbers []string) ([]models.ProductSerial, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec("UPDATE products SET stock = stock + $1 WHERE id = $2", len(serialNumbers), productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product stock: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, nil
+	}
+
+	insertSerial, err := tx.Prepare(`
+		INSERT INTO product_serials (product_id, serial_number)
+		VALUES ($1, $2)
+		RETURNING id, product_id, serial_number, status, received_at
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer insertSerial.Close()
+
+	insertEvent, err := tx.Prepare("INSERT INTO serial_events (serial_id, event) VALUES ($1, $2)")
+	if err != nil {
+		return nil, err
+	}
+	defer insertEvent.Close()
+
+	serials := make([]models.ProductSerial, 0, len(serialNumbers))
+	for _, serialNumber := range serialNumbers {
+		var serial models.ProductSerial
+		err = insertSerial.QueryRow(productID, serialNumber).Scan(&serial.ID, &serial.ProductID, &serial.SerialNumber, &serial.Status, &serial.ReceivedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create serial %s: %w", serialNumber, translateDuplicate(err))
+		}
+		_, err = insertEvent.Exec(serial.ID, models.SerialEventReceived)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record serial event: %w", err)
+		}
+		serials = append(serials, serial)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return serials, nil
+}
+
+func (r *SerialRepository) GetSerialHistory(serialNumber string) (models.SerialHistory, error) {
+	query := `
+		SELECT s.id, s.product_id, p.name, s.serial_number, s.status, COALESCE(s.transaction_id::text, ''), s.received_at, s.sold_at
+		FROM product_serials s
+		INNER JOIN products p ON p.id = s.product_id
+		WHERE s.serial_number = $1
+	`
+	var history models.SerialHistory
+	err := r.db.QueryRow(query, serialNumber).Scan(&history.ID, &history.ProductID, &history.ProductName, &history.SerialNumber,
+		&history.Status, &history.TransactionID, &history.ReceivedAt, &history.SoldAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.SerialHistory{}, nil
+		}
+		return models.SerialHistory{}, fmt.Errorf("failed to get serial %s : %w", serialNumber, err)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT e.event, COALESCE(e.transaction_id::text, ''), COALESCE(e.transaction_detail_id::text, ''),
+		       COALESCE(td.subtotal / NULLIF(td.quantity, 0), 0), e.created_at
+		FROM serial_events e
+		LEFT JOIN transaction_details td ON td.id = e.transaction_detail_id
+		WHERE e.serial_id = $1
+		ORDER BY e.created_at ASC
+	`, history.ID)
+	if err != nil {
+		return models.SerialHistory{}, fmt.Errorf("failed to get serial events for %s : %w", serialNumber, err)
+	}
+	defer rows.Close()
+
+	history.Events = make([]models.SerialEvent, 0)
+	for rows.Next() {
+		var event models.SerialEvent
+		err := rows.Scan(&event.Event, &event.TransactionID, &event.TransactionDetailID, &event.Price, &event.CreatedAt)
+		if err != nil {
+			return models.SerialHistory{}, fmt.Errorf("failed to scan serial event: %w", err)
+		}
+		history.Events = append(history.Events, event)
+	}
+	return history, nil
+}
@@ -3,7 +3,15 @@ package repositories
 import (
 	"database/sql"
 	"fmt"
+	"kasir-api/internal"
+	apperrors "kasir-api/internal/errors"
 	"kasir-api/models"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 type TransactionRepository struct {
@@ -14,79 +22,344 @@ func NewTransactionRepository(db *sql.DB) *TransactionRepository {
 	return &TransactionRepository{db: db}
 }
 
-func (r *TransactionRepository) CreateTransaction(items []models.CheckoutItem) (*models.Transaction, error) {
+func (r *TransactionRepository) CreateTransaction(actor models.Actor, request models.CheckoutRequest) (*models.Transaction, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.CustomerID != "" {
+		var exists bool
+		err = tx.QueryRow("SELECT EXISTS (SELECT 1 FROM customers WHERE id = $1)", request.CustomerID).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, apperrors.NewNotFoundError("customer", request.CustomerID)
+		}
+	}
+
+	// Tiers are resolved against the quantity of a product across the whole cart.
+	cartQuantities := make(map[string]int)
+	for _, item := range request.Items {
+		cartQuantities[item.ProductID] += item.Quantity
+	}
+
+	// Lock the products in id order, so two checkouts sharing products take
+	// their row locks in the same order and cannot deadlock. Lines keep the
+	// order of the request.
+	order := make([]int, len(request.Items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return request.Items[order[a]].ProductID < request.Items[order[b]].ProductID
+	})
+
 	var totalAmount int64 = 0
-	details := make([]models.TransactionDetail, 0)
-	for _, item := range items {
+	itemDetails := make([][]models.TransactionDetail, len(request.Items))
+	// Serials already taken by an earlier line of the cart, so two lines of the
+	// same product cannot both sell one unit.
+	reservedSerials := make(map[string]bool)
+	for _, i := range order {
+		item := request.Items[i]
+		field := fmt.Sprintf("items[%d]", i)
 		var productDetail models.ProductDetail
-		err = tx.QueryRow("SELECT id, name, price, stock FROM products WHERE id = $1", item.ProductID).Scan(&productDetail.ID, &productDetail.Name, &productDetail.Price, &productDetail.Stock)
+		err = tx.QueryRow("SELECT p.id, p.name, "+effectivePriceQuery+", p.stock FROM products p WHERE p.id = $1 AND p.deleted_at IS NULL FOR UPDATE OF p", item.ProductID).Scan(&productDetail.ID, &productDetail.Name, &productDetail.Price, &productDetail.Stock)
 		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperrors.NewNotFoundError("product", item.ProductID)
+			}
 			return nil, err
 		}
 
-		if productDetail.Stock == 0 {
-			return nil, fmt.Errorf("Insufficient stock for item %s, stock is %d", item.ProductID, productDetail.Stock)
+		if productDetail.Stock < item.Quantity {
+			return nil, apperrors.NewInsufficientStockError(field+".quantity", item.ProductID, productDetail.Stock, item.Quantity)
 		}
 
-		if productDetail.Stock < item.Quantity {
-			return nil, fmt.Errorf("Insufficient stock for item %s, stock is %d but requested %d", item.ProductID, productDetail.Stock, item.Quantity)
+		price, priceListID, err := resolvePrice(tx, productDetail.ID, request.CustomerID, cartQuantities[item.ProductID], productDetail.Price)
+		if err != nil {
+			return nil, err
 		}
 
-		subTotal := productDetail.Price * int64(item.Quantity)
-		totalAmount += subTotal
+		allocations, err := allocateLots(tx, field, productDetail, item.Quantity)
+		if err != nil {
+			return nil, err
+		}
 
-		_, err = tx.Exec("UPDATE products SET stock = stock - $1 WHERE id = $2", item.Quantity, item.ProductID)
+		serialNumbers, err := reserveSerials(tx, field, productDetail, item, reservedSerials)
 		if err != nil {
 			return nil, err
 		}
 
-		details = append(details, models.TransactionDetail{
-			ProductID:   productDetail.ID,
-			ProductName: productDetail.Name,
-			Quantity:    item.Quantity,
-			Subtotal:    subTotal,
-			Price:       productDetail.Price,
-		})
+		for _, allocation := range allocations {
+			if allocation.lotID != "" {
+				_, err = tx.Exec("UPDATE product_lots SET quantity = quantity - $1 WHERE id = $2", allocation.quantity, allocation.lotID)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			// Serialized units are recorded one per line so each line carries
+			// its serial; the unserialized rest shares one line.
+			serialized := min(len(serialNumbers), allocation.quantity)
+			lines := make([]int, serialized)
+			for i := range lines {
+				lines[i] = 1
+			}
+			if allocation.quantity > serialized {
+				lines = append(lines, allocation.quantity-serialized)
+			}
+
+			for j, quantity := range lines {
+				subTotal := price * int64(quantity)
+				totalAmount += subTotal
+
+				detail := models.TransactionDetail{
+					ProductID:   productDetail.ID,
+					ProductName: productDetail.Name,
+					Quantity:    quantity,
+					Subtotal:    subTotal,
+					Price:       price,
+					LotID:       allocation.lotID,
+					PriceListID: priceListID,
+				}
+				if j < serialized {
+					detail.SerialNumber = serialNumbers[0]
+					serialNumbers = serialNumbers[1:]
+				}
+				itemDetails[i] = append(itemDetails[i], detail)
+			}
+		}
+
+		_, err = tx.Exec("UPDATE products SET stock = stock - $1 WHERE id = $2", item.Quantity, item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	details := make([]models.TransactionDetail, 0)
+	for _, lines := range itemDetails {
+		details = append(details, lines...)
 	}
 
 	var transaction models.Transaction
-	err = tx.QueryRow("INSERT INTO transactions (total_amount) VALUES ($1) RETURNING id, created_at", totalAmount).Scan(&transaction.ID, &transaction.CreatedAt)
+	err = tx.QueryRow("INSERT INTO transactions (total_amount, customer_id) VALUES ($1, NULLIF($2, '')::uuid) RETURNING id, created_at", totalAmount, request.CustomerID).Scan(&transaction.ID, &transaction.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
 
-	bulkInsert, err := tx.Prepare("INSERT INTO transaction_details (transaction_id, product_id, quantity, subtotal) VALUES ($1, $2, $3, $4)")
+	bulkInsert, err := tx.Prepare("INSERT INTO transaction_details (transaction_id, product_id, quantity, subtotal, lot_id, serial_number, price_list_id) VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, NULLIF($6, ''), NULLIF($7, '')::uuid) RETURNING id")
 	if err != nil {
 		return nil, err
 	}
 	defer bulkInsert.Close()
 
-	for _, detail := range details {
-		_, err = bulkInsert.Exec(transaction.ID, detail.ProductID, detail.Quantity, detail.Subtotal)
+	for i := range details {
+		detail := &details[i]
+		detail.TransactionID = transaction.ID
+		detail.CreatedAt = transaction.CreatedAt
+		err = bulkInsert.QueryRow(transaction.ID, detail.ProductID, detail.Quantity, detail.Subtotal, detail.LotID, detail.SerialNumber, detail.PriceListID).Scan(&detail.ID)
 		if err != nil {
 			return nil, err
 		}
+
+		if detail.SerialNumber != "" {
+			err = markSerialSold(tx, transaction.ID, *detail)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	err = tx.Commit()
+	err = addToDailySales(tx, transaction.ID, internal.StoreLocation().String())
 	if err != nil {
 		return nil, err
 	}
+
 	transaction.TotalAmount = totalAmount
+	transaction.CustomerID = request.CustomerID
+	transaction.Details = details
+	err = writeOutbox(tx, models.OutboxTransactionCreated, transaction.ID, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
 
 	return &transaction, nil
 }
 
+type lotAllocation struct {
+	lotID    string
+	quantity int
+}
+
+// allocateLots splits the requested quantity across the product's lots in
+// first-expiry-first-out order. Expired lots are never sold. Stock that is not
+// covered by any lot (received before lot tracking) is consumed last and is
+// returned as an allocation without a lot id. field names the checkout item in
+// errors.
+func allocateLots(tx *sql.Tx, field string, product models.ProductDetail, quantity int) ([]lotAllocation, error) {
+	rows, err := tx.Query(`
+		SELECT id, quantity, COALESCE(expiry_date < $2::date, false) AS expired
+		FROM product_lots
+		WHERE product_id = $1 AND quantity > 0
+		ORDER BY expiry_date ASC NULLS LAST, created_at ASC
+		FOR UPDATE
+	`, product.ID, internal.StoreDate(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allocations := make([]lotAllocation, 0)
+	remaining := quantity
+	lotTotal := 0
+	for rows.Next() {
+		var lotID string
+		var lotQuantity int
+		var expired bool
+		err := rows.Scan(&lotID, &lotQuantity, &expired)
+		if err != nil {
+			return nil, err
+		}
+		lotTotal += lotQuantity
+		if expired {
+			continue
+		}
+		if remaining == 0 {
+			continue
+		}
+		take := min(lotQuantity, remaining)
+		allocations = append(allocations, lotAllocation{lotID: lotID, quantity: take})
+		remaining -= take
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	untracked := max(product.Stock-lotTotal, 0)
+	if remaining > 0 && untracked > 0 {
+		take := min(untracked, remaining)
+		allocations = append(allocations, lotAllocation{quantity: take})
+		remaining -= take
+	}
+
+	if remaining > 0 {
+		return nil, apperrors.NewInsufficientStockError(field+".quantity", product.ID, quantity-remaining, quantity)
+	}
+	return allocations, nil
+}
+
+// reserveSerials locks and validates the serial numbers chosen for a checkout
+// item and adds them to reserved, which spans the whole cart. A product can
+// hold serialized and unserialized units side by side: only the units not
+// covered by unserialized stock need a serial. It returns nil when the item
+// sells no serialized units. field names the checkout item in errors.
+func reserveSerials(tx *sql.Tx, field string, product models.ProductDetail, item models.CheckoutItem, reserved map[string]bool) ([]string, error) {
+	invalid := func(code, message string) error {
+		return apperrors.NewValidationError(models.FieldError{Field: field + ".serial_numbers", Code: code, Message: message})
+	}
+
+	// Serials reserved by an earlier line are still in stock until the
+	// transaction is written, but are no longer available to this one.
+	taken := make([]string, 0)
+	for key := range reserved {
+		serialNumber, found := strings.CutPrefix(key, product.ID+"/")
+		if found {
+			taken = append(taken, serialNumber)
+		}
+	}
+	var inStock int
+	err := tx.QueryRow("SELECT count(*) FROM product_serials WHERE product_id = $1 AND status = $2 AND NOT serial_number = ANY($3)",
+		product.ID, models.SerialStatusInStock, pq.Array(taken)).Scan(&inStock)
+	if err != nil {
+		return nil, err
+	}
+
+	if inStock == 0 {
+		if len(item.SerialNumbers) > 0 {
+			return nil, invalid("not_serial_tracked", fmt.Sprintf("product %s has no serial-tracked units in stock", item.ProductID))
+		}
+		return nil, nil
+	}
+
+	required, allowed := serialRange(product.Stock, inStock, item.Quantity)
+	if len(item.SerialNumbers) < required || len(item.SerialNumbers) > allowed {
+		expected := fmt.Sprintf("between %d and %d", required, allowed)
+		if required == allowed {
+			expected = strconv.Itoa(required)
+		}
+		return nil, invalid("count_mismatch", fmt.Sprintf("expected %s serial numbers but got %d", expected, len(item.SerialNumbers)))
+	}
+	if len(item.SerialNumbers) == 0 {
+		return nil, nil
+	}
+
+	for _, serialNumber := range item.SerialNumbers {
+		key := product.ID + "/" + serialNumber
+		if reserved[key] {
+			return nil, invalid("duplicate", fmt.Sprintf("serial %s is listed more than once", serialNumber))
+		}
+		reserved[key] = true
+
+		var status string
+		err = tx.QueryRow("SELECT status FROM product_serials WHERE product_id = $1 AND serial_number = $2 FOR UPDATE", product.ID, serialNumber).Scan(&status)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, invalid("unknown_serial", fmt.Sprintf("serial %s does not belong to product %s", serialNumber, item.ProductID))
+			}
+			return nil, err
+		}
+		if status != models.SerialStatusInStock {
+			return nil, apperrors.NewConflictError("serial_unavailable", fmt.Sprintf("serial %s is not available, status is %s", serialNumber, status))
+		}
+	}
+
+	return item.SerialNumbers, nil
+}
+
+// serialRange returns how many serial numbers a sale of quantity units must
+// and may list, given the product stock and how many of those units are
+// serialized and in stock. Units beyond the unserialized stock must be sold by
+// serial, and no more serials can be sold than are in stock.
+func serialRange(stock, inStock, quantity int) (required, allowed int) {
+	unserialized := max(stock-inStock, 0)
+	return max(quantity-unserialized, 0), min(quantity, inStock)
+}
+
+func markSerialSold(tx *sql.Tx, transactionID string, detail models.TransactionDetail) error {
+	var serialID string
+	err := tx.QueryRow(`
+		UPDATE product_serials SET status = $1, transaction_id = $2, sold_at = now()
+		WHERE product_id = $3 AND serial_number = $4
+		RETURNING id
+	`, models.SerialStatusSold, transactionID, detail.ProductID, detail.SerialNumber).Scan(&serialID)
+	if err != nil {
+		return fmt.Errorf("failed to mark serial %s as sold: %w", detail.SerialNumber, err)
+	}
+
+	_, err = tx.Exec("INSERT INTO serial_events (serial_id, event, transaction_id, transaction_detail_id) VALUES ($1, $2, $3, $4)",
+		serialID, models.SerialEventSold, transactionID, detail.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record serial event: %w", err)
+	}
+	return nil
+}
+
 func (r *TransactionRepository) GetTransactions() ([]models.Transaction, error) {
 	query := `
-		select id, total_amount, created_at
+		select id, total_amount, COALESCE(customer_id::text, ''), created_at
 		from transactions
 	`
 	rows, err := r.db.Query(query)
@@ -98,7 +371,7 @@ func (r *TransactionRepository) GetTransactions() ([]models.Transaction, error)
 	var transactions []models.Transaction
 	for rows.Next() {
 		var transaction models.Transaction
-		err := rows.Scan(&transaction.ID, &transaction.TotalAmount, &transaction.CreatedAt)
+		err := rows.Scan(&transaction.ID, &transaction.TotalAmount, &transaction.CustomerID, &transaction.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -107,13 +380,37 @@ func (r *TransactionRepository) GetTransactions() ([]models.Transaction, error)
 	return transactions, nil
 }
 
-func (r *TransactionRepository) GetTransactionsRange(from string, to string) ([]models.Transaction, error) {
+func (r *TransactionRepository) GetTransactionsRange(dateRange models.DateRange) ([]models.Transaction, error) {
+	var args []any
 	query := `
-		select id, total_amount, created_at
+		select id, total_amount, COALESCE(customer_id::text, ''), created_at
 		from transactions
-		where created_at BETWEEN $1 AND $2
+		where 1=1
+	` + dateRangeCondition("created_at", dateRange, &args) + `
+		order by created_at
 	`
-	rows, err := r.db.Query(query, from, to)
+	return r.queryTransactions(query, args...)
+}
+
+// GetLatestTransactions returns the last limit transactions in the range,
+// newest first.
+func (r *TransactionRepository) GetLatestTransactions(dateRange models.DateRange, limit int) ([]models.Transaction, error) {
+	var args []any
+	query := `
+		select id, total_amount, COALESCE(customer_id::text, ''), created_at
+		from transactions
+		where 1=1
+	` + dateRangeCondition("created_at", dateRange, &args)
+	args = append(args, limit)
+	query += fmt.Sprintf(`
+		order by created_at desc, id desc
+		limit $%d
+	`, len(args))
+	return r.queryTransactions(query, args...)
+}
+
+func (r *TransactionRepository) queryTransactions(query string, args ...any) ([]models.Transaction, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -122,11 +419,180 @@ func (r *TransactionRepository) GetTransactionsRange(from string, to string) ([]
 	var transactions []models.Transaction
 	for rows.Next() {
 		var transaction models.Transaction
-		err := rows.Scan(&transaction.ID, &transaction.TotalAmount, &transaction.CreatedAt)
+		err := rows.Scan(&transaction.ID, &transaction.TotalAmount, &transaction.CustomerID, &transaction.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
 		transactions = append(transactions, transaction)
 	}
-	return transactions, nil
+	return transactions, rows.Err()
+}
+
+// ExportTransactions streams transactions in the given range to fn one row at a time.
+func (r *TransactionRepository) ExportTransactions(dateRange models.DateRange, fn func(models.Transaction) error) error {
+	var args []any
+	query := `
+		SELECT id, total_amount, COALESCE(customer_id::text, ''), created_at
+		FROM transactions
+		WHERE 1=1
+	` + dateRangeCondition("created_at", dateRange, &args) + `
+		ORDER BY created_at, id
+	`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to export transactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transaction models.Transaction
+		err := rows.Scan(&transaction.ID, &transaction.TotalAmount, &transaction.CustomerID, &transaction.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		err = fn(transaction)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+const transactionDetailColumns = `
+	td.id, td.transaction_id, td.product_id, COALESCE(p.name, ''), td.quantity, td.subtotal,
+	td.subtotal / NULLIF(td.quantity, 0), COALESCE(td.lot_id::text, ''), COALESCE(td.serial_number, ''),
+	COALESCE(td.price_list_id::text, ''), t.created_at
+`
+
+func scanTransactionDetail(row scanner) (models.TransactionDetail, error) {
+	var detail models.TransactionDetail
+	var price sql.NullInt64
+	err := row.Scan(&detail.ID, &detail.TransactionID, &detail.ProductID, &detail.ProductName, &detail.Quantity, &detail.Subtotal,
+		&price, &detail.LotID, &detail.SerialNumber, &detail.PriceListID, &detail.CreatedAt)
+	detail.Price = price.Int64
+	return detail, err
+}
+
+// ExportTransactionDetails streams the line items of transactions in the given
+// range to fn one row at a time.
+func (r *TransactionRepository) ExportTransactionDetails(dateRange models.DateRange, fn func(models.TransactionDetail) error) error {
+	var args []any
+	query := `
+		SELECT ` + transactionDetailColumns + `
+		FROM transaction_details td
+		INNER JOIN transactions t ON t.id = td.transaction_id
+		LEFT JOIN products p ON p.id = td.product_id
+		WHERE 1=1
+	` + dateRangeCondition("t.created_at", dateRange, &args) + `
+		ORDER BY t.created_at, td.transaction_id, td.id
+	`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to export transaction details: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		detail, err := scanTransactionDetail(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan transaction detail: %w", err)
+		}
+		err = fn(detail)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetDayTotals returns the running totals of a store day from the daily sales rollup.
+func (r *TransactionRepository) GetDayTotals(date string) (models.DayTotals, error) {
+	totals := models.DayTotals{Date: date}
+	err := r.db.QueryRow("SELECT revenue, quantity, transaction_count FROM daily_sales_totals WHERE sale_date = $1::date", date).
+		Scan(&totals.Revenue, &totals.Quantity, &totals.TransactionCount)
+	if err != nil && err != sql.ErrNoRows {
+		return models.DayTotals{}, fmt.Errorf("failed to get day totals: %w", err)
+	}
+	return totals, nil
+}
+
+// GetLowStockProducts returns the given products whose stock is at or below threshold.
+func (r *TransactionRepository) GetLowStockProducts(productIDs []string, threshold int) ([]models.LowStockEvent, error) {
+	rows, err := r.db.Query("SELECT id, name, stock FROM products WHERE id = ANY($1::uuid[]) AND stock <= $2 ORDER BY name", pq.Array(productIDs), threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get low stock products: %w", err)
+	}
+	defer rows.Close()
+
+	products := make([]models.LowStockEvent, 0)
+	for rows.Next() {
+		product := models.LowStockEvent{Threshold: threshold}
+		err := rows.Scan(&product.ProductID, &product.ProductName, &product.Stock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan low stock product: %w", err)
+		}
+		products = append(products, product)
+	}
+	return products, rows.Err()
+}
+
+// GetDetailsByTransactionIDs returns the line items of each of the given
+// transactions in one query, keyed by transaction id.
+func (r *TransactionRepository) GetDetailsByTransactionIDs(transactionIDs []string) (map[string][]models.TransactionDetail, error) {
+	query := `
+		SELECT ` + transactionDetailColumns + `
+		FROM transaction_details td
+		INNER JOIN transactions t ON t.id = td.transaction_id
+		LEFT JOIN products p ON p.id = td.product_id
+		WHERE td.transaction_id = ANY($1::uuid[])
+		ORDER BY td.id
+	`
+	rows, err := r.db.Query(query, pq.Array(transactionIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction details: %w", err)
+	}
+	defer rows.Close()
+
+	details := make(map[string][]models.TransactionDetail)
+	for rows.Next() {
+		detail, err := scanTransactionDetail(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction detail: %w", err)
+		}
+		details[detail.TransactionID] = append(details[detail.TransactionID], detail)
+	}
+	return details, rows.Err()
+}
+
+// GetDetailsByProductIDs returns the latest limit sales lines of each of the
+// given products in one query, newest first and keyed by product id.
+func (r *TransactionRepository) GetDetailsByProductIDs(productIDs []string, limit int) (map[string][]models.TransactionDetail, error) {
+	query := `
+		SELECT id, transaction_id, product_id, product_name, quantity, subtotal, price, lot_id, serial_number, price_list_id, created_at
+		FROM (
+			SELECT ` + transactionDetailColumns + `,
+			       ROW_NUMBER() OVER (PARTITION BY td.product_id ORDER BY t.created_at DESC, td.id) AS n
+			FROM transaction_details td
+			INNER JOIN transactions t ON t.id = td.transaction_id
+			LEFT JOIN products p ON p.id = td.product_id
+			WHERE td.product_id = ANY($1::uuid[])
+		) AS sales (id, transaction_id, product_id, product_name, quantity, subtotal, price, lot_id, serial_number, price_list_id, created_at, n)
+		WHERE n <= $2
+		ORDER BY created_at DESC, id
+	`
+	rows, err := r.db.Query(query, pq.Array(productIDs), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales by product ids: %w", err)
+	}
+	defer rows.Close()
+
+	details := make(map[string][]models.TransactionDetail)
+	for rows.Next() {
+		detail, err := scanTransactionDetail(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction detail: %w", err)
+		}
+		details[detail.ProductID] = append(details[detail.ProductID], detail)
+	}
+	return details, rows.Err()
 }
@@ -0,0 +1,33 @@
+package repositories
+
+import "testing"
+
+func TestSerialRange(t *testing.T) {
+	tests := []struct {
+		name         string
+		stock        int
+		inStock      int
+		quantity     int
+		wantRequired int
+		wantAllowed  int
+	}{
+		{name: "fully serialized", stock: 3, inStock: 3, quantity: 2, wantRequired: 2, wantAllowed: 2},
+		{name: "mixed stock within unserialized units", stock: 5, inStock: 2, quantity: 3, wantRequired: 0, wantAllowed: 2},
+		{name: "mixed stock beyond unserialized units", stock: 5, inStock: 2, quantity: 4, wantRequired: 1, wantAllowed: 2},
+		{name: "mixed stock sold out", stock: 5, inStock: 2, quantity: 5, wantRequired: 2, wantAllowed: 2},
+		{name: "single serial", stock: 10, inStock: 1, quantity: 1, wantRequired: 0, wantAllowed: 1},
+		{name: "more serials than stock", stock: 1, inStock: 3, quantity: 1, wantRequired: 1, wantAllowed: 1},
+		{name: "no serials", stock: 4, inStock: 0, quantity: 4, wantRequired: 0, wantAllowed: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			required, allowed := serialRange(test.stock, test.inStock, test.quantity)
+			if required != test.wantRequired {
+				t.Errorf("required = %d, want %d", required, test.wantRequired)
+			}
+			if allowed != test.wantAllowed {
+				t.Errorf("allowed = %d, want %d", allowed, test.wantAllowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"kasir-api/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// writeOutbox records an event in the outbox and queues a delivery for every
+// active subscription that wants it. It must run in the transaction that makes
+// the change, so an event exists exactly when the change was committed.
+func writeOutbox(exec execer, eventType string, entityID string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox payload: %w", err)
+	}
+
+	query := `
+		WITH event AS (
+			INSERT INTO outbox_events (event_type, entity_id, payload) VALUES ($1, $2, $3) RETURNING id
+		)
+		INSERT INTO webhook_deliveries (subscription_id, event_id)
+		SELECT s.id, event.id
+		FROM webhook_subscriptions s, event
+		WHERE s.active AND (cardinality(s.event_types) = 0 OR $1 = ANY(s.event_types))
+	`
+	_, err = exec.Exec(query, eventType, entityID, data)
+	if err != nil {
+		return fmt.Errorf("failed to write outbox event: %w", err)
+	}
+	return nil
+}
+
+const webhookColumns = "id, url, event_types, active, created_at"
+
+func scanWebhook(row scanner) (models.WebhookSubscription, error) {
+	var webhook models.WebhookSubscription
+	var eventTypes pq.StringArray
+	err := row.Scan(&webhook.ID, &webhook.URL, &eventTypes, &webhook.Active, &webhook.CreatedAt)
+	webhook.EventTypes = eventTypes
+	return webhook, err
+}
+
+func (r *WebhookRepository) GetWebhooks() ([]models.WebhookSubscription, error) {
+	rows, err := r.db.Query("SELECT " + webhookColumns + " FROM webhook_subscriptions ORDER BY created_at")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := make([]models.WebhookSubscription, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+func (r *WebhookRepository) CreateWebhook(actor models.Actor, webhook models.WebhookSubscription) (models.WebhookSubscription, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+
+	query := "INSERT INTO webhook_subscriptions (url, secret, event_types, active) VALUES ($1, $2, $3, $4) RETURNING " + webhookColumns
+	newWebhook, err := scanWebhook(tx.QueryRow(query, webhook.URL, webhook.Secret, pq.Array(webhook.EventTypes), webhook.Active))
+	if err != nil {
+		return models.WebhookSubscription{}, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	newWebhook.Secret = webhook.Secret
+
+	err = tx.Commit()
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+	return newWebhook, nil
+}
+
+func (r *WebhookRepository) GetWebhookByID(id string) (models.WebhookSubscription, error) {
+	webhook, err := scanWebhook(r.db.QueryRow("SELECT "+webhookColumns+" FROM webhook_subscriptions WHERE id = $1", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.WebhookSubscription{}, nil
+		}
+		return models.WebhookSubscription{}, fmt.Errorf("failed to get webhook by id %s : %w", id, err)
+	}
+	return webhook, nil
+}
+
+// UpdateWebhookByID changes the url, event types and active flag. The secret
+// is kept unless a new one is given.
+func (r *WebhookRepository) UpdateWebhookByID(actor models.Actor, id string, webhook models.WebhookSubscription) (models.WebhookSubscription, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+
+	query := `
+		UPDATE webhook_subscriptions SET url = $2, secret = COALESCE(NULLIF($3, ''), secret), event_types = $4, active = $5
+		WHERE id = $1
+		RETURNING ` + webhookColumns
+	updated, err := scanWebhook(tx.QueryRow(query, id, webhook.URL, webhook.Secret, pq.Array(webhook.EventTypes), webhook.Active))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.WebhookSubscription{}, nil
+		}
+		return models.WebhookSubscription{}, fmt.Errorf("failed to update webhook by id %s : %w", id, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+	return updated, nil
+}
+
+func (r *WebhookRepository) DeleteWebhookByID(actor models.Actor, id string) (models.WebhookSubscription, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+	defer tx.Rollback()
+
+	err = setAuditActor(tx, actor)
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+
+	deleted, err := scanWebhook(tx.QueryRow("DELETE FROM webhook_subscriptions WHERE id = $1 RETURNING "+webhookColumns, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.WebhookSubscription{}, nil
+		}
+		return models.WebhookSubscription{}, fmt.Errorf("failed to delete webhook by id %s : %w", id, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+	return deleted, nil
+}
+
+const deliveryColumns = `
+	d.id, d.subscription_id, s.url, d.status, d.attempts, d.next_attempt_at, d.last_status_code, d.last_error, d.delivered_at, d.created_at,
+	e.id, e.event_type, e.entity_id, e.created_at, e.payload, s.secret
+`
+
+func scanDelivery(row scanner) (models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	var payload []byte
+	err := row.Scan(&delivery.ID, &delivery.SubscriptionID, &delivery.URL, &delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt,
+		&delivery.LastStatusCode, &delivery.LastError, &delivery.DeliveredAt, &delivery.CreatedAt,
+		&delivery.Event.ID, &delivery.Event.Type, &delivery.Event.EntityID, &delivery.Event.CreatedAt, &payload, &delivery.Secret)
+	delivery.Event.Data = payload
+	return delivery, err
+}
+
+// GetDeliveries lists deliveries with the given status, newest first. Dead
+// deliveries are the dead-letter queue.
+func (r *WebhookRepository) GetDeliveries(status string, limit int) ([]models.WebhookDelivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries d
+		INNER JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		INNER JOIN outbox_events e ON e.id = d.event_id
+		WHERE d.status = $1
+		ORDER BY d.id DESC
+		LIMIT $2
+	`
+	rows, err := r.db.Query(query, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// ClaimDueDeliveries picks up to limit pending deliveries that are due and
+// pushes their next attempt back by lease, so another dispatcher will not pick
+// them up while they are in flight. The lease has to cover the whole batch,
+// since the last delivery is only attempted after all the others.
+func (r *WebhookRepository) ClaimDueDeliveries(limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE webhook_deliveries SET next_attempt_at = now() + $2 * interval '1 second'
+			WHERE id IN (SELECT id FROM due)
+			RETURNING *
+		)
+		SELECT ` + deliveryColumns + `
+		FROM claimed d
+		INNER JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		INNER JOIN outbox_events e ON e.id = d.event_id
+		ORDER BY d.id
+	`
+	rows, err := r.db.Query(query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookRepository) MarkDelivered(id int64, statusCode int) error {
+	_, err := r.db.Exec(`
+		UPDATE webhook_deliveries SET status = 'delivered', attempts = attempts + 1, last_status_code = $2, last_error = '', delivered_at = now()
+		WHERE id = $1
+	`, id, statusCode)
+	if err != nil {
+		return fmt.Errorf("failed to mark webhook delivery %d delivered: %w", id, err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt. The delivery is retried at nextAttempt,
+// or becomes dead when dead is set.
+func (r *WebhookRepository) MarkFailed(id int64, statusCode *int, message string, nextAttempt time.Time, dead bool) error {
+	status := models.DeliveryPending
+	if dead {
+		status = models.DeliveryDead
+	}
+	_, err := r.db.Exec(`
+		UPDATE webhook_deliveries SET status = $2, attempts = attempts + 1, last_status_code = $3, last_error = $4, next_attempt_at = $5
+		WHERE id = $1
+	`, id, status, statusCode, message, nextAttempt)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery %d failure: %w", id, err)
+	}
+	return nil
+}
+
+// RetryDelivery puts a dead delivery back in the queue with a fresh set of
+// attempts. It returns false when there is no dead delivery with that id.
+func (r *WebhookRepository) RetryDelivery(id int64) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE webhook_deliveries SET status = 'pending', attempts = 0, next_attempt_at = now()
+		WHERE id = $1 AND status = 'dead'
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to retry webhook delivery %d: %w", id, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
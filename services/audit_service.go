@@ -0,0 +1,40 @@
+package services
+
+import (
+	"kasir-api/models"
+	"kasir-api/repositories"
+)
+
+// auditSealBatch is how many audit entries one sealing transaction links.
+const auditSealBatch = 1000
+
+type AuditService struct {
+	repo *repositories.AuditRepository
+}
+
+func NewAuditService(repo *repositories.AuditRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+func (s *AuditService) GetAuditLog(filter models.AuditFilter) ([]models.AuditEntry, error) {
+	return s.repo.GetAuditLog(filter)
+}
+
+// SealAuditLog links every entry written since the last run into the hash
+// chain, a batch at a time.
+func (s *AuditService) SealAuditLog() error {
+	for {
+		sealed, err := s.repo.SealAuditLog(auditSealBatch)
+		if err != nil || sealed < auditSealBatch {
+			return err
+		}
+	}
+}
+
+func (s *AuditService) VerifyAuditLog() (models.AuditVerification, error) {
+	return s.repo.VerifyAuditLog()
+}
+
+func (s *AuditService) GetStockHistory(productIDs []string, limit int) (map[string][]models.StockChange, error) {
+	return s.repo.GetStockHistory(productIDs, limit)
+}
@@ -13,26 +13,34 @@ func NewCategoryService(repo *repositories.CategoryRepository) *CategoryService
 	return &CategoryService{repo: repo}
 }
 
-func (s *CategoryService) GetCategories() ([]models.Category, error) {
-	return s.repo.GetCategories()
+func (s *CategoryService) GetCategories(includeArchived bool) ([]models.Category, error) {
+	return s.repo.GetCategories(includeArchived)
 }
 
-func (s *CategoryService) CreateCategory(category models.Category) (models.Category, error) {
-	return s.repo.CreateCategory(category)
+func (s *CategoryService) CreateCategory(actor models.Actor, category models.Category) (models.Category, error) {
+	return s.repo.CreateCategory(actor, category)
 }
 
 func (s *CategoryService) GetCategoryByID(id string) (models.Category, error) {
 	return s.repo.GetCategoryByID(id)
 }
 
-func (s *CategoryService) UpdateCategoryByID(id string, category models.Category) (models.Category, error) {
-	return s.repo.UpdateCategoryByID(id, category)
+func (s *CategoryService) UpdateCategoryByID(actor models.Actor, id string, category models.Category, ifMatch []int64) (models.Category, error) {
+	return s.repo.UpdateCategoryByID(actor, id, category, ifMatch)
 }
 
-func (s *CategoryService) DeleteCategoryByID(id string) (models.Category, error) {
-	return s.repo.DeleteCategoryByID(id)
+func (s *CategoryService) DeleteCategoryByID(actor models.Actor, id string, ifMatch []int64) (models.Category, error) {
+	return s.repo.DeleteCategoryByID(actor, id, ifMatch)
+}
+
+func (s *CategoryService) RestoreCategoryByID(actor models.Actor, id string) (models.Category, error) {
+	return s.repo.RestoreCategoryByID(actor, id)
 }
 
 func (s *CategoryService) GetProductsByCategoryID(categoryID string) ([]models.CategoryWithProducts, error) {
 	return s.repo.GetProductsByCategoryID(categoryID)
 }
+
+func (s *CategoryService) GetProductsByCategoryIDs(categoryIDs []string) (map[string][]models.Product, error) {
+	return s.repo.GetProductsByCategoryIDs(categoryIDs)
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"kasir-api/models"
+	"kasir-api/repositories"
+)
+
+type CustomerService struct {
+	repo *repositories.CustomerRepository
+}
+
+func NewCustomerService(repo *repositories.CustomerRepository) *CustomerService {
+	return &CustomerService{repo: repo}
+}
+
+func (s *CustomerService) GetCustomerGroups() ([]models.CustomerGroup, error) {
+	return s.repo.GetCustomerGroups()
+}
+
+func (s *CustomerService) CreateCustomerGroup(actor models.Actor, group models.CustomerGroup) (models.CustomerGroup, error) {
+	return s.repo.CreateCustomerGroup(actor, group)
+}
+
+func (s *CustomerService) GetCustomerGroupByID(id string) (models.CustomerGroup, error) {
+	return s.repo.GetCustomerGroupByID(id)
+}
+
+func (s *CustomerService) UpdateCustomerGroupByID(actor models.Actor, id string, group models.CustomerGroup) (models.CustomerGroup, error) {
+	return s.repo.UpdateCustomerGroupByID(actor, id, group)
+}
+
+func (s *CustomerService) DeleteCustomerGroupByID(actor models.Actor, id string) (models.CustomerGroup, error) {
+	return s.repo.DeleteCustomerGroupByID(actor, id)
+}
+
+func (s *CustomerService) GetCustomers(name string) ([]models.Customer, error) {
+	return s.repo.GetCustomers(name)
+}
+
+func (s *CustomerService) CreateCustomer(actor models.Actor, customer models.Customer) (models.Customer, error) {
+	return s.repo.CreateCustomer(actor, customer)
+}
+
+func (s *CustomerService) GetCustomerByID(id string) (models.Customer, error) {
+	return s.repo.GetCustomerByID(id)
+}
+
+func (s *CustomerService) UpdateCustomerByID(actor models.Actor, id string, customer models.Customer) (models.Customer, error) {
+	return s.repo.UpdateCustomerByID(actor, id, customer)
+}
+
+func (s *CustomerService) DeleteCustomerByID(actor models.Actor, id string) (models.Customer, error) {
+	return s.repo.DeleteCustomerByID(actor, id)
+}
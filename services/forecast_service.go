@@ -0,0 +1,175 @@
+package services
+
+import (
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"log"
+	"math"
+	"time"
+)
+
+const (
+	// forecastHistoryDays is how much history feeds a forecast: twelve full
+	// weeks, so every weekday is seen the same number of times.
+	forecastHistoryDays = 84
+	// forecastSmoothing is the exponential smoothing weight of the newest day.
+	forecastSmoothing = 0.2
+)
+
+type ForecastService struct {
+	repo *repositories.ForecastRepository
+}
+
+func NewForecastService(repo *repositories.ForecastRepository) *ForecastService {
+	return &ForecastService{repo: repo}
+}
+
+// GenerateForecasts rebuilds the forecast of every product that sold in the
+// history window. Each product's daily sales are split into a weekday factor,
+// the weekday's average over the overall average, and a level found by
+// exponentially smoothing the sales with the weekday effect removed.
+func (s *ForecastService) GenerateForecasts() error {
+	today := internal.DayRange(time.Now()).From
+	start := today.AddDate(0, 0, -forecastHistoryDays)
+	sales, err := s.repo.GetDailySales(start)
+	if err != nil {
+		return err
+	}
+
+	series := make(map[string][]float64)
+	for _, day := range sales {
+		date, err := time.ParseInLocation("2006-01-02", day.Date, internal.StoreLocation())
+		if err != nil {
+			return err
+		}
+		index := int(math.Round(date.Sub(start).Hours() / 24))
+		if index < 0 || index >= forecastHistoryDays {
+			continue
+		}
+		if series[day.ProductID] == nil {
+			series[day.ProductID] = make([]float64, forecastHistoryDays)
+		}
+		series[day.ProductID][index] += float64(day.Quantity)
+	}
+
+	forecasts := make([]models.DemandForecast, 0, len(series))
+	for productID, quantities := range series {
+		factors := weekdayFactors(start, quantities)
+		level := 0.0
+		for i, quantity := range quantities {
+			weekday := start.AddDate(0, 0, i).Weekday()
+			deseasonalized := quantity
+			if factors[weekday] > 0 {
+				deseasonalized = quantity / factors[weekday]
+			}
+			if i == 0 {
+				level = deseasonalized
+				continue
+			}
+			level = forecastSmoothing*deseasonalized + (1-forecastSmoothing)*level
+		}
+		forecasts = append(forecasts, models.DemandForecast{
+			ProductID:      productID,
+			DailyDemand:    level,
+			WeekdayFactors: factors,
+			HistoryDays:    forecastHistoryDays,
+		})
+	}
+
+	err = s.repo.ReplaceForecasts(forecasts, time.Now())
+	if err != nil {
+		return err
+	}
+	log.Printf("Generated demand forecasts for %d products", len(forecasts))
+	return nil
+}
+
+// weekdayFactors returns, Sunday first, each weekday's average sales divided by
+// the overall daily average. A weekday that never sold gets 0, and every
+// factor is 1 when nothing sold at all.
+func weekdayFactors(start time.Time, quantities []float64) []float64 {
+	var totals, counts [7]float64
+	var total float64
+	for i, quantity := range quantities {
+		weekday := start.AddDate(0, 0, i).Weekday()
+		totals[weekday] += quantity
+		counts[weekday]++
+		total += quantity
+	}
+
+	factors := make([]float64, 7)
+	average := total / float64(len(quantities))
+	for weekday := range factors {
+		if average == 0 || counts[weekday] == 0 {
+			factors[weekday] = 1
+			continue
+		}
+		factors[weekday] = totals[weekday] / counts[weekday] / average
+	}
+	return factors
+}
+
+// GetForecasts returns every product's forecast with a suggested order
+// quantity covering its lead time plus coverDays. With reorderOnly, products
+// that do not need ordering are left out.
+func (s *ForecastService) GetForecasts(coverDays int, reorderOnly bool) ([]models.DemandForecast, error) {
+	forecasts, err := s.repo.GetForecasts()
+	if err != nil {
+		return nil, err
+	}
+
+	today := internal.DayRange(time.Now()).From
+	result := make([]models.DemandForecast, 0, len(forecasts))
+	for _, forecast := range forecasts {
+		suggestReorder(&forecast, today, coverDays)
+		if reorderOnly && forecast.SuggestedOrderQuantity == 0 {
+			continue
+		}
+		result = append(result, forecast)
+	}
+	return result, nil
+}
+
+// GetForecastByProductID returns a product's forecast with the expected demand
+// for each of the next `days` store days.
+func (s *ForecastService) GetForecastByProductID(productID string, coverDays int, days int) (models.DemandForecast, error) {
+	forecast, err := s.repo.GetForecastByProductID(productID)
+	if err != nil || forecast.ProductID == "" {
+		return forecast, err
+	}
+
+	today := internal.DayRange(time.Now()).From
+	suggestReorder(&forecast, today, coverDays)
+	forecast.Days = make([]models.ForecastDay, days)
+	for i := range forecast.Days {
+		date := today.AddDate(0, 0, i)
+		forecast.Days[i] = models.ForecastDay{Date: internal.StoreDate(date), Quantity: demandOn(forecast, date)}
+	}
+	return forecast, nil
+}
+
+func (s *ForecastService) SetLeadTime(actor models.Actor, productID string, days int) (bool, error) {
+	return s.repo.SetLeadTime(actor, productID, days)
+}
+
+// suggestReorder fills in the demand expected from today until an order placed
+// now has arrived and lasted coverDays, and the whole units to order on top of
+// the current stock to meet it.
+func suggestReorder(forecast *models.DemandForecast, today time.Time, coverDays int) {
+	forecast.CoverDays = coverDays
+	forecast.ExpectedDemand = 0
+	for i := 0; i < forecast.LeadTimeDays+coverDays; i++ {
+		forecast.ExpectedDemand += demandOn(*forecast, today.AddDate(0, 0, i))
+	}
+	shortfall := math.Ceil(forecast.ExpectedDemand) - float64(forecast.Stock)
+	forecast.SuggestedOrderQuantity = int64(math.Max(shortfall, 0))
+}
+
+func demandOn(forecast models.DemandForecast, date time.Time) float64 {
+	factor := 1.0
+	if len(forecast.WeekdayFactors) == 7 {
+		factor = forecast.WeekdayFactors[date.Weekday()]
+	}
+	return forecast.DailyDemand * factor
+}
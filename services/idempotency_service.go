@@ -0,0 +1,72 @@
+package services
+
+import (
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"log"
+	"net/http"
+	"time"
+)
+
+// idempotencyRetention is how long a client may retry a request and get the
+// stored response back.
+const idempotencyRetention = 24 * time.Hour
+
+// An attempt leases its key for idempotencyLease and renews the lease every
+// idempotencyRenewal while it runs, so a retry only takes the key over from
+// an attempt that has died, however long a live one takes.
+const (
+	idempotencyLease   = 30 * time.Second
+	idempotencyRenewal = 10 * time.Second
+)
+
+type IdempotencyService struct {
+	repo *repositories.IdempotencyRepository
+}
+
+func NewIdempotencyService(repo *repositories.IdempotencyRepository) *IdempotencyService {
+	return &IdempotencyService{repo: repo}
+}
+
+func (s *IdempotencyService) ReserveKey(actor, key, method, path, requestHash string) (models.IdempotentResponse, bool, error) {
+	return s.repo.ReserveKey(actor, key, method, path, requestHash, idempotencyLease)
+}
+
+// HoldKey renews the lease of a reserved key in the background until the
+// returned function is called.
+func (s *IdempotencyService) HoldKey(reserved models.IdempotentResponse) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(idempotencyRenewal)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				held, err := s.repo.RenewKey(reserved.Actor, reserved.Key, reserved.Attempt, idempotencyLease)
+				if err != nil {
+					log.Println(err)
+					continue
+				}
+				if !held {
+					log.Printf("Idempotency key %s was taken over while its request was still running", reserved.Key)
+					return
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+func (s *IdempotencyService) SaveResponse(reserved models.IdempotentResponse, statusCode int, header http.Header, body []byte) error {
+	return s.repo.SaveResponse(reserved.Actor, reserved.Key, reserved.Attempt, statusCode, header, body)
+}
+
+func (s *IdempotencyService) ReleaseKey(reserved models.IdempotentResponse) error {
+	return s.repo.ReleaseKey(reserved.Actor, reserved.Key, reserved.Attempt)
+}
+
+func (s *IdempotencyService) DeleteExpiredKeys() error {
+	return s.repo.DeleteKeysBefore(time.Now().Add(-idempotencyRetention))
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"kasir-api/models"
+	"kasir-api/repositories"
+)
+
+type LotService struct {
+	repo *repositories.LotRepository
+}
+
+func NewLotService(repo *repositories.LotRepository) *LotService {
+	return &LotService{repo: repo}
+}
+
+func (s *LotService) GetLotsByProductID(productID string) ([]models.ProductLot, error) {
+	return s.repo.GetLotsByProductID(productID)
+}
+
+func (s *LotService) ReceiveLot(actor models.Actor, lot models.ProductLot) (models.ProductLot, error) {
+	return s.repo.ReceiveLot(actor, lot)
+}
+
+func (s *LotService) GetExpiringLots(days int) ([]models.ExpiringLot, error) {
+	return s.repo.GetExpiringLots(days)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"kasir-api/models"
+	"kasir-api/repositories"
+)
+
+type PriceListService struct {
+	repo *repositories.PriceListRepository
+}
+
+func NewPriceListService(repo *repositories.PriceListRepository) *PriceListService {
+	return &PriceListService{repo: repo}
+}
+
+func (s *PriceListService) GetPriceLists() ([]models.PriceList, error) {
+	return s.repo.GetPriceLists()
+}
+
+func (s *PriceListService) CreatePriceList(actor models.Actor, priceList models.PriceList) (models.PriceList, error) {
+	return s.repo.CreatePriceList(actor, priceList)
+}
+
+func (s *PriceListService) GetPriceListByID(id string) (models.PriceList, error) {
+	return s.repo.GetPriceListByID(id)
+}
+
+func (s *PriceListService) UpdatePriceListByID(actor models.Actor, id string, priceList models.PriceList) (models.PriceList, error) {
+	return s.repo.UpdatePriceListByID(actor, id, priceList)
+}
+
+func (s *PriceListService) DeletePriceListByID(actor models.Actor, id string) (models.PriceList, error) {
+	return s.repo.DeletePriceListByID(actor, id)
+}
+
+func (s *PriceListService) GetPriceListItems(priceListID string) ([]models.PriceListItem, error) {
+	return s.repo.GetPriceListItems(priceListID)
+}
+
+func (s *PriceListService) SetPriceListItem(actor models.Actor, item models.PriceListItem) (models.PriceListItem, error) {
+	return s.repo.SetPriceListItem(actor, item)
+}
+
+func (s *PriceListService) DeletePriceListItem(actor models.Actor, priceListID, productID string, minQuantity int) (models.PriceListItem, error) {
+	return s.repo.DeletePriceListItem(actor, priceListID, productID, minQuantity)
+}
+
+func (s *PriceListService) QuotePrice(productID, customerID string, quantity int) (models.PriceQuote, error) {
+	return s.repo.QuotePrice(productID, customerID, quantity)
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"log"
+	"time"
+)
+
+type PriceService struct {
+	repo *repositories.PriceRepository
+}
+
+func NewPriceService(repo *repositories.PriceRepository) *PriceService {
+	return &PriceService{repo: repo}
+}
+
+func (s *PriceService) GetPriceHistory(productID string) ([]models.ProductPrice, error) {
+	return s.repo.GetPriceHistory(productID)
+}
+
+func (s *PriceService) SchedulePriceChange(actor models.Actor, productID string, price int64, effectiveAt time.Time) (models.ProductPrice, error) {
+	return s.repo.SchedulePriceChange(actor, productID, price, effectiveAt)
+}
+
+func (s *PriceService) CancelScheduledPrice(actor models.Actor, productID string, priceID string) (models.ProductPrice, error) {
+	return s.repo.CancelScheduledPrice(actor, productID, priceID)
+}
+
+func (s *PriceService) ApplyDuePriceChanges() error {
+	applied, err := s.repo.ApplyDuePriceChanges()
+	if err != nil {
+		return err
+	}
+	if applied > 0 {
+		log.Printf("Applied scheduled prices to %d products", applied)
+	}
+	return nil
+}
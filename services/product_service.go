@@ -1,46 +1,190 @@
 package services
 
 import (
+	"fmt"
+	"kasir-api/internal"
 	"kasir-api/models"
 	"kasir-api/repositories"
+	"strconv"
+	"strings"
 )
 
 type ProductService struct {
-	repo *repositories.ProductRepository
+	repo   *repositories.ProductRepository
+	events *internal.EventBroker
 }
 
-func NewProductService(repo *repositories.ProductRepository) *ProductService {
-	return &ProductService{repo: repo}
+func NewProductService(repo *repositories.ProductRepository, events *internal.EventBroker) *ProductService {
+	return &ProductService{repo: repo, events: events}
 }
 
-func (s *ProductService) GetProducts(name string) ([]models.Product, error) {
-	return s.repo.GetProducts(name)
+func (s *ProductService) GetProducts(name string, includeArchived bool) ([]models.Product, error) {
+	return s.repo.GetProducts(name, includeArchived)
 }
 
-func (s *ProductService) CreateProduct(product models.Product) (models.Product, error) {
-	return s.repo.CreateProduct(product)
+func (s *ProductService) CreateProduct(actor models.Actor, product models.Product) (models.Product, error) {
+	return s.repo.CreateProduct(actor, product)
 }
 
 func (s *ProductService) GetProductByID(id string) (models.Product, error) {
 	return s.repo.GetProductByID(id)
 }
 
-func (s *ProductService) UpdateProductByID(id string, product models.Product) (models.Product, error) {
-	return s.repo.UpdateProductByID(id, product)
+// UpdateProductByID publishes a low stock event when the update leaves the
+// product at or below the threshold.
+func (s *ProductService) UpdateProductByID(actor models.Actor, id string, product models.Product, ifMatch []int64) (models.Product, error) {
+	updated, err := s.repo.UpdateProductByID(actor, id, product, ifMatch)
+	if err != nil {
+		return updated, err
+	}
+	if updated.ID != "" && updated.Stock <= internal.LowStockThreshold() {
+		s.events.Publish(models.EventLowStock, models.LowStockEvent{
+			ProductID:   updated.ID,
+			ProductName: updated.Name,
+			Stock:       updated.Stock,
+			Threshold:   internal.LowStockThreshold(),
+		})
+	}
+	return updated, nil
 }
 
-func (s *ProductService) DeleteProductByID(id string) (models.Product, error) {
-	return s.repo.DeleteProductByID(id)
+func (s *ProductService) DeleteProductByID(actor models.Actor, id string, ifMatch []int64) (models.Product, error) {
+	return s.repo.DeleteProductByID(actor, id, ifMatch)
 }
 
-func (s *ProductService) AddCategoryToProduct(productID, categoryID string) error {
-	return s.repo.AddCategoryToProduct(productID, categoryID)
+func (s *ProductService) RestoreProductByID(actor models.Actor, id string) (models.Product, error) {
+	return s.repo.RestoreProductByID(actor, id)
 }
 
-func (s *ProductService) RemoveCategoryFromProduct(productID, categoryID string) error {
-	return s.repo.RemoveCategoryFromProduct(productID, categoryID)
+func (s *ProductService) AddCategoryToProduct(actor models.Actor, productID, categoryID string) error {
+	return s.repo.AddCategoryToProduct(actor, productID, categoryID)
+}
+
+func (s *ProductService) RemoveCategoryFromProduct(actor models.Actor, productID, categoryID string) error {
+	return s.repo.RemoveCategoryFromProduct(actor, productID, categoryID)
 }
 
 func (s *ProductService) GetCategoriesByProductID(productID string) ([]models.Category, error) {
 	return s.repo.GetCategoriesByProductID(productID)
 }
+
+// ImportProducts maps spreadsheet rows onto products and upserts them by SKU.
+// The first row must be a header naming the columns. Validation problems are
+// reported per row; nothing is written when there are any, or when dryRun is set.
+func (s *ProductService) ImportProducts(actor models.Actor, records [][]string, dryRun bool) (models.ProductImportResult, error) {
+	rows, errs := parseProductImport(records)
+	if len(errs) > 0 {
+		return models.ProductImportResult{
+			DryRun:    dryRun,
+			TotalRows: max(len(records)-1, 0),
+			Errors:    errs,
+		}, nil
+	}
+	return s.repo.ImportProducts(actor, rows, dryRun)
+}
+
+func parseProductImport(records [][]string) ([]models.ProductImportRow, []models.ProductImportError) {
+	errs := make([]models.ProductImportError, 0)
+	if len(records) == 0 {
+		return nil, append(errs, models.ProductImportError{Row: 1, Message: "file is empty"})
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"name", "price"} {
+		if _, ok := columns[required]; !ok {
+			errs = append(errs, models.ProductImportError{Row: 1, Field: required, Message: "missing column"})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	cell := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := make([]models.ProductImportRow, 0, len(records)-1)
+	skus := make(map[string]int)
+	barcodes := make(map[string]int)
+	for i, record := range records[1:] {
+		rowNumber := i + 2
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		row := models.ProductImportRow{
+			Row:     rowNumber,
+			Name:    cell(record, "name"),
+			SKU:     cell(record, "sku"),
+			Barcode: cell(record, "barcode"),
+		}
+
+		if row.Name == "" {
+			errs = append(errs, models.ProductImportError{Row: rowNumber, Field: "name", Message: "is required"})
+		}
+
+		price, err := strconv.ParseInt(cell(record, "price"), 10, 64)
+		if err != nil || price < 0 {
+			errs = append(errs, models.ProductImportError{Row: rowNumber, Field: "price", Message: "must be a non-negative whole number"})
+		}
+		row.Price = price
+
+		if value := cell(record, "cost"); value != "" {
+			cost, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || cost < 0 {
+				errs = append(errs, models.ProductImportError{Row: rowNumber, Field: "cost", Message: "must be a non-negative whole number"})
+			}
+			row.Cost = &cost
+		}
+
+		if value := cell(record, "stock"); value != "" {
+			stock, err := strconv.Atoi(value)
+			if err != nil || stock < 0 {
+				errs = append(errs, models.ProductImportError{Row: rowNumber, Field: "stock", Message: "must be a non-negative whole number"})
+			}
+			row.Stock = &stock
+		}
+
+		if row.SKU != "" {
+			if first, ok := skus[row.SKU]; ok {
+				errs = append(errs, models.ProductImportError{Row: rowNumber, Field: "sku", Message: fmt.Sprintf("duplicates row %d", first)})
+			}
+			skus[row.SKU] = rowNumber
+		}
+		if row.Barcode != "" {
+			if first, ok := barcodes[row.Barcode]; ok {
+				errs = append(errs, models.ProductImportError{Row: rowNumber, Field: "barcode", Message: fmt.Sprintf("duplicates row %d", first)})
+			}
+			barcodes[row.Barcode] = rowNumber
+		}
+
+		for _, category := range strings.FieldsFunc(cell(record, "categories"), func(r rune) bool { return r == '|' || r == ';' }) {
+			if category = strings.TrimSpace(category); category != "" {
+				row.Categories = append(row.Categories, category)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, errs
+}
+
+func (s *ProductService) ExportProducts(dateRange models.DateRange, fn func(models.Product) error) error {
+	return s.repo.ExportProducts(dateRange, fn)
+}
+
+func (s *ProductService) GetProductsByIDs(ids []string) ([]models.Product, error) {
+	return s.repo.GetProductsByIDs(ids)
+}
+
+func (s *ProductService) GetCategoriesByProductIDs(productIDs []string) (map[string][]models.Category, error) {
+	return s.repo.GetCategoriesByProductIDs(productIDs)
+}
@@ -1,8 +1,10 @@
 package services
 
 import (
+	"kasir-api/internal"
 	"kasir-api/models"
 	"kasir-api/repositories"
+	"math"
 	"time"
 )
 
@@ -15,13 +17,120 @@ func NewReportService(repo *repositories.ReportRepository) *ReportService {
 }
 
 func (s *ReportService) GetReports() (models.Report, error) {
-	return s.repo.GetReports("", "")
+	return s.repo.GetReports(models.DateRange{})
 }
-func (s *ReportService) GetReportsRange(from string, to string) (models.Report, error) {
-	return s.repo.GetReports(from, to)
+func (s *ReportService) GetReportsRange(dateRange models.DateRange) (models.Report, error) {
+	return s.repo.GetReports(dateRange)
 }
 
+// GetReportToday reports on the current calendar day of the store, not of the server.
 func (s *ReportService) GetReportToday() (models.Report, error) {
-	today := time.Now().Format("2006-01-02")
-	return s.repo.GetReports(today, today)
+	return s.repo.GetReports(internal.DayRange(time.Now()))
+}
+
+func (s *ReportService) GetSalesReport(groupBy string, dateRange models.DateRange) (models.SalesReport, error) {
+	return s.repo.GetSalesReport(groupBy, dateRange, internal.StoreLocation().String())
+}
+
+func (s *ReportService) GetProductRanking(metric string, top int, bottom int, dateRange models.DateRange) (models.ProductRankingReport, error) {
+	return s.repo.GetProductRanking(metric, top, bottom, dateRange)
+}
+
+// GetDeadStock lists stocked products without a sale in the last `days` store days.
+func (s *ReportService) GetDeadStock(days int) (models.DeadStockReport, error) {
+	since := internal.DayRange(time.Now()).From.AddDate(0, 0, -days)
+	items, err := s.repo.GetDeadStock(since)
+	if err != nil {
+		return models.DeadStockReport{}, err
+	}
+
+	report := models.DeadStockReport{Days: days, Since: since, Items: items}
+	for _, item := range items {
+		report.TotalInventoryCost += item.InventoryCost
+		report.TotalInventoryValue += item.InventoryValue
+	}
+	return report, nil
+}
+
+const defaultABCDays = 30
+
+// GetABCReport classifies products by their cumulative share of revenue, highest
+// first: products that start before thresholdA percent are A, before thresholdB
+// are B and the rest, including anything that did not sell, are C. A missing
+// end defaults to the end of today and a missing start to 30 days before the end.
+func (s *ReportService) GetABCReport(dateRange models.DateRange, thresholdA float64, thresholdB float64) (models.ABCReport, error) {
+	if dateRange.To.IsZero() {
+		dateRange.To = internal.DayRange(time.Now()).To
+	}
+	if dateRange.From.IsZero() {
+		dateRange.From = dateRange.To.AddDate(0, 0, -defaultABCDays)
+	}
+
+	products, err := s.repo.GetProductSales(dateRange)
+	if err != nil {
+		return models.ABCReport{}, err
+	}
+
+	report := models.ABCReport{
+		From:       dateRange.From,
+		To:         dateRange.To,
+		Days:       int(math.Round(dateRange.To.Sub(dateRange.From).Hours() / 24)),
+		ThresholdA: thresholdA,
+		ThresholdB: thresholdB,
+		Items:      make([]models.ABCItem, 0, len(products)),
+	}
+	if report.Days < 1 {
+		report.Days = 1
+	}
+	for _, product := range products {
+		report.TotalRevenue += product.Revenue
+	}
+
+	summaries := map[string]*models.ABCClassSummary{
+		models.ClassA: {Class: models.ClassA},
+		models.ClassB: {Class: models.ClassB},
+		models.ClassC: {Class: models.ClassC},
+	}
+	var cumulative float64
+	for _, product := range products {
+		item := models.ABCItem{ProductPerformance: product, Class: models.ClassC}
+		if report.TotalRevenue > 0 {
+			item.RevenueShare = float64(product.Revenue) * 100 / float64(report.TotalRevenue)
+		}
+		if product.Revenue > 0 {
+			switch {
+			case cumulative < thresholdA:
+				item.Class = models.ClassA
+			case cumulative < thresholdB:
+				item.Class = models.ClassB
+			}
+		}
+		cumulative += item.RevenueShare
+		item.CumulativeShare = cumulative
+
+		if available := product.Quantity + int64(product.Stock); available > 0 {
+			item.SellThroughRate = float64(product.Quantity) * 100 / float64(available)
+		}
+		item.AverageDailySales = float64(product.Quantity) / float64(report.Days)
+		if item.AverageDailySales > 0 {
+			cover := float64(product.Stock) / item.AverageDailySales
+			item.DaysOfCover = &cover
+		}
+
+		summary := summaries[item.Class]
+		summary.ProductCount++
+		summary.Revenue += product.Revenue
+		summary.RevenueShare += item.RevenueShare
+		report.Items = append(report.Items, item)
+	}
+	report.Classes = []models.ABCClassSummary{*summaries[models.ClassA], *summaries[models.ClassB], *summaries[models.ClassC]}
+	return report, nil
+}
+
+func (s *ReportService) GetProductPairs(dateRange models.DateRange, minCount int, limit int) (models.BasketReport, error) {
+	return s.repo.GetProductPairs(dateRange, minCount, limit)
+}
+
+func (s *ReportService) GetFrequentlyBoughtWith(productID string, dateRange models.DateRange, minCount int, limit int) (models.FrequentlyBoughtWith, error) {
+	return s.repo.GetFrequentlyBoughtWith(productID, dateRange, minCount, limit)
 }
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"log"
+	"time"
+)
+
+type RollupService struct {
+	repo *repositories.RollupRepository
+}
+
+func NewRollupService(repo *repositories.RollupRepository) *RollupService {
+	return &RollupService{repo: repo}
+}
+
+// EnsureRollup does a full rebuild when the rollup has never been built or was
+// built in a different store timezone, whose days would not line up.
+func (s *RollupService) EnsureRollup() error {
+	timezone, err := s.repo.GetTimezone()
+	if err != nil {
+		return err
+	}
+	current := internal.StoreLocation().String()
+	if timezone == current {
+		return nil
+	}
+
+	log.Printf("Rebuilding daily sales in %s", current)
+	return s.repo.Rebuild(models.DateRange{}, current)
+}
+
+// RebuildRecent recomputes yesterday and today a day at a time, without
+// blocking checkouts of the other day. Checkout keeps the rollup current on
+// its own, this only repairs drift from changes made outside the API.
+func (s *RollupService) RebuildRecent() error {
+	today := internal.DayRange(time.Now())
+	return s.repo.RebuildDays(models.DateRange{From: today.From.AddDate(0, 0, -1), To: today.To}, internal.StoreLocation().String())
+}
+
+func (s *RollupService) Rebuild(dateRange models.DateRange) error {
+	return s.repo.Rebuild(dateRange, internal.StoreLocation().String())
+}
+
+func (s *RollupService) Check(dateRange models.DateRange) ([]models.RollupMismatch, error) {
+	return s.repo.Check(dateRange, internal.StoreLocation().String())
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"kasir-api/models"
+	"kasir-api/repositories"
+)
+
+type SerialService struct {
+	repo *repositories.SerialRepository
+}
+
+func NewSerialService(repo *repositories.SerialRepository) *SerialService {
+	return &SerialService{repo: repo}
+}
+
+func (s *SerialService) GetSerialsByProductID(productID string, status string) ([]models.ProductSerial, error) {
+	return s.repo.GetSerialsByProductID(productID, status)
+}
+
+func (s *SerialService) ReceiveSerials(actor models.Actor, productID string, serialNumbers []string) ([]models.ProductSerial, error) {
+	return s.repo.ReceiveSerials(actor, productID, serialNumbers)
+}
+
+func (s *SerialService) GetSerialHistory(serialNumber string) (models.SerialHistory, error) {
+	return s.repo.GetSerialHistory(serialNumber)
+}
@@ -1,26 +1,80 @@
 package services
 
 import (
+	"kasir-api/internal"
 	"kasir-api/models"
 	"kasir-api/repositories"
+	"log"
+	"time"
 )
 
 type TransactionService struct {
-	repo *repositories.TransactionRepository
+	repo   *repositories.TransactionRepository
+	events *internal.EventBroker
 }
 
-func NewTransactionService(repo *repositories.TransactionRepository) *TransactionService {
-	return &TransactionService{repo: repo}
+func NewTransactionService(repo *repositories.TransactionRepository, events *internal.EventBroker) *TransactionService {
+	return &TransactionService{repo: repo, events: events}
 }
 
-func (s *TransactionService) Checkout(items []models.CheckoutItem) (*models.Transaction, error) {
-	return s.repo.CreateTransaction(items)
+// Checkout records the sale and publishes it with today's running totals,
+// followed by a low stock event for every sold product that ran low.
+func (s *TransactionService) Checkout(actor models.Actor, request models.CheckoutRequest) (*models.Transaction, error) {
+	transaction, err := s.repo.CreateTransaction(actor, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// The sale is committed, failing to announce it must not fail the request.
+	today, err := s.GetTodayTotals()
+	if err != nil {
+		log.Println(err)
+	}
+	s.events.Publish(models.EventCheckout, models.CheckoutEvent{Transaction: *transaction, Today: today})
+
+	productIDs := make([]string, 0, len(request.Items))
+	for _, item := range request.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	lowStock, err := s.repo.GetLowStockProducts(productIDs, internal.LowStockThreshold())
+	if err != nil {
+		log.Println(err)
+	}
+	for _, product := range lowStock {
+		s.events.Publish(models.EventLowStock, product)
+	}
+
+	return transaction, nil
+}
+
+func (s *TransactionService) GetTodayTotals() (models.DayTotals, error) {
+	return s.repo.GetDayTotals(internal.StoreDate(time.Now()))
 }
 
 func (s *TransactionService) GetTransactions() ([]models.Transaction, error) {
 	return s.repo.GetTransactions()
 }
 
-func (s *TransactionService) GetTransactionsRange(from string, to string) ([]models.Transaction, error) {
-	return s.repo.GetTransactionsRange(from, to)
+func (s *TransactionService) GetTransactionsRange(dateRange models.DateRange) ([]models.Transaction, error) {
+	return s.repo.GetTransactionsRange(dateRange)
+}
+
+func (s *TransactionService) GetLatestTransactions(dateRange models.DateRange, limit int) ([]models.Transaction, error) {
+	return s.repo.GetLatestTransactions(dateRange, limit)
+}
+
+func (s *TransactionService) ExportTransactions(dateRange models.DateRange, fn func(models.Transaction) error) error {
+	return s.repo.ExportTransactions(dateRange, fn)
+}
+
+func (s *TransactionService) ExportTransactionDetails(dateRange models.DateRange, fn func(models.TransactionDetail) error) error {
+	return s.repo.ExportTransactionDetails(dateRange, fn)
+}
+
+func (s *TransactionService) GetDetailsByTransactionIDs(transactionIDs []string) (map[string][]models.TransactionDetail, error) {
+	return s.repo.GetDetailsByTransactionIDs(transactionIDs)
+}
+
+func (s *TransactionService) GetDetailsByProductIDs(productIDs []string, limit int) (map[string][]models.TransactionDetail, error) {
+	return s.repo.GetDetailsByProductIDs(productIDs, limit)
 }
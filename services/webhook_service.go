@@ -0,0 +1,152 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"kasir-api/internal"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	webhookBatchSize = 20
+	webhookTimeout   = 10 * time.Second
+	// webhookLease outlasts a batch in which every delivery times out, so a
+	// claimed delivery is not picked up again while it is still queued.
+	webhookLease       = webhookBatchSize*webhookTimeout + time.Minute
+	webhookMaxAttempts = 10
+	webhookBaseBackoff = 30 * time.Second
+	webhookMaxBackoff  = 6 * time.Hour
+)
+
+type WebhookService struct {
+	repo   *repositories.WebhookRepository
+	client *http.Client
+}
+
+func NewWebhookService(repo *repositories.WebhookRepository) *WebhookService {
+	return &WebhookService{repo: repo, client: internal.NewWebhookClient(webhookTimeout)}
+}
+
+func (s *WebhookService) GetWebhooks() ([]models.WebhookSubscription, error) {
+	return s.repo.GetWebhooks()
+}
+
+// CreateWebhook generates a secret when none is given.
+func (s *WebhookService) CreateWebhook(actor models.Actor, webhook models.WebhookSubscription) (models.WebhookSubscription, error) {
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		_, err := rand.Read(secret)
+		if err != nil {
+			return models.WebhookSubscription{}, err
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+	return s.repo.CreateWebhook(actor, webhook)
+}
+
+func (s *WebhookService) GetWebhookByID(id string) (models.WebhookSubscription, error) {
+	return s.repo.GetWebhookByID(id)
+}
+
+func (s *WebhookService) UpdateWebhookByID(actor models.Actor, id string, webhook models.WebhookSubscription) (models.WebhookSubscription, error) {
+	return s.repo.UpdateWebhookByID(actor, id, webhook)
+}
+
+func (s *WebhookService) DeleteWebhookByID(actor models.Actor, id string) (models.WebhookSubscription, error) {
+	return s.repo.DeleteWebhookByID(actor, id)
+}
+
+func (s *WebhookService) GetDeliveries(status string, limit int) ([]models.WebhookDelivery, error) {
+	return s.repo.GetDeliveries(status, limit)
+}
+
+func (s *WebhookService) RetryDelivery(id int64) (bool, error) {
+	return s.repo.RetryDelivery(id)
+}
+
+// DispatchDue delivers a batch of due webhooks. Failed deliveries are retried
+// with exponential backoff and become dead after webhookMaxAttempts. A
+// delivery whose outcome cannot be recorded is logged and retried once its
+// lease runs out, without holding up the rest of the batch.
+func (s *WebhookService) DispatchDue() error {
+	deliveries, err := s.repo.ClaimDueDeliveries(webhookBatchSize, webhookLease)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		statusCode, err := s.deliver(delivery)
+		if err == nil {
+			err = s.repo.MarkDelivered(delivery.ID, statusCode)
+			if err != nil {
+				log.Printf("Error recording webhook delivery %d: %s", delivery.ID, err)
+			}
+			continue
+		}
+
+		var code *int
+		if statusCode != 0 {
+			code = &statusCode
+		}
+		attempts := delivery.Attempts + 1
+		dead := attempts >= webhookMaxAttempts
+		if dead {
+			log.Printf("Webhook delivery %d to %s is dead after %d attempts: %s", delivery.ID, delivery.URL, attempts, err)
+		}
+		err = s.repo.MarkFailed(delivery.ID, code, err.Error(), time.Now().Add(webhookBackoff(attempts)), dead)
+		if err != nil {
+			log.Printf("Error recording webhook delivery %d: %s", delivery.ID, err)
+		}
+	}
+	return nil
+}
+
+// webhookBackoff doubles the wait after every failed attempt, up to webhookMaxBackoff.
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff
+	for i := 1; i < attempts && backoff < webhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, webhookMaxBackoff)
+}
+
+// deliver posts the event and treats any 2xx response as success.
+func (s *WebhookService) deliver(delivery models.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "kasir-api-webhooks")
+	request.Header.Set("X-Webhook-ID", strconv.FormatInt(delivery.Event.ID, 10))
+	request.Header.Set("X-Webhook-Event", delivery.Event.Type)
+	request.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	request.Header.Set("X-Webhook-Signature", internal.SignWebhook(delivery.Secret, timestamp, body))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}